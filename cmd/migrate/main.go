@@ -17,7 +17,7 @@ import (
 
 func main() {
 	cfg := config.LoadConfig()
-	db := database.ConnectDatabase(cfg)
+	db := database.ConnectDatabase(cfg.Database)
 
 	log.Println("Running AutoMigrate (syncing schema from Go models)...")
 	if err := db.AutoMigrate(
@@ -25,6 +25,30 @@ func main() {
 		&models.Location{},
 		&models.Sports{},
 		&models.Turf{},
+		&models.LoginHistory{},
+		&models.Team{},
+		&models.TeamMember{},
+		&models.TeamInvitation{},
+		&models.Booking{},
+		&models.Match{},
+		&models.MatchPlayer{},
+		&models.PaymentSplit{},
+		&models.PaymentShare{},
+		&models.Payment{},
+		&models.Tournament{},
+		&models.TournamentTeam{},
+		&models.Fixture{},
+		&models.MatchResult{},
+		&models.PlayerResult{},
+		&models.PlayerRating{},
+		&models.TurfReview{},
+		&models.ReviewReport{},
+		&models.FavouriteTurf{},
+		&models.SavedSearch{},
+		&models.BookingSeries{},
+		&models.PricingRule{},
+		&models.TurfHours{},
+		&models.TurfClosure{},
 	); err != nil {
 		log.Fatalf("AutoMigrate failed: %v", err)
 	}
@@ -1,57 +1,497 @@
+// Package config loads the application settings. Values are layered, later layers
+// winning: struct-tag defaults, the APP_ENV profile, an optional YAML file
+// (CONFIG_FILE, or ./config.yaml when present), environment variables (including .env),
+// and finally file-based secrets (<VAR>_FILE) for fields tagged secret.
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/goccy/go-yaml"
 	"github.com/joho/godotenv"
 )
 
+// Supported APP_ENV values.
+const (
+	EnvDevelopment = "development"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// Supported REDIS_MODE values.
+const (
+	RedisStandalone = "standalone"
+	RedisSentinel   = "sentinel"
+	RedisCluster    = "cluster"
+)
+
+// Supported PAYMENT_PROVIDER values.
+const (
+	PaymentProviderFake = "fake"
+)
+
+// Supported TRACING_EXPORTER values.
+const (
+	TracingOTLP   = "otlp"
+	TracingStdout = "stdout"
+	TracingNone   = "none"
+)
+
+const defaultConfigFile = "config.yaml"
+
 type Config struct {
-	ServerPort  string
-	DBName      string
-	DBHost      string
-	DBUser      string
-	DBPass      string
-	DBPort      string
-	JWTSecret   string
-	SQSQueueURL string
+	Environment string `yaml:"environment" env:"APP_ENV" default:"development"`
+
+	Server     ServerConfig     `yaml:"server"`
+	Timeouts   TimeoutConfig    `yaml:"timeouts"`
+	API        APIConfig        `yaml:"api"`
+	Log        LogConfig        `yaml:"log"`
+	Tracing    TracingConfig    `yaml:"tracing"`
+	Database   DatabaseConfig   `yaml:"database"`
+	Auth       AuthConfig       `yaml:"auth"`
+	Redis      RedisConfig      `yaml:"redis"`
+	AWS        AWSConfig        `yaml:"aws"`
+	Cloudinary CloudinaryConfig `yaml:"cloudinary"`
+	LocationIQ LocationIQConfig `yaml:"locationIQ"`
+	SMTP       SMTPConfig       `yaml:"smtp"`
+	Facebook   FacebookConfig   `yaml:"facebook"`
+	RateLimit  RateLimitConfig  `yaml:"rateLimit"`
+	CORS       CORSConfig       `yaml:"cors"`
+	Cookie     CookieConfig     `yaml:"cookie"`
+	Matches    MatchesConfig    `yaml:"matches"`
+	Payments   PaymentsConfig   `yaml:"payments"`
+	Turfs      TurfsConfig      `yaml:"turfs"`
+}
+
+// TimeoutConfig holds the per-operation request deadlines applied to the API. Each must
+// be shorter than the server write timeout so the error response can still be written.
+type TimeoutConfig struct {
+	Read   time.Duration `yaml:"read" env:"REQUEST_TIMEOUT_READ" default:"5s"`
+	Write  time.Duration `yaml:"write" env:"REQUEST_TIMEOUT_WRITE" default:"10s"`
+	Upload time.Duration `yaml:"upload" env:"REQUEST_TIMEOUT_UPLOAD" default:"60s"`
+}
+
+// APIConfig schedules the retirement of API v1. Dates are YYYY-MM-DD (UTC) and are
+// announced on every v1 response with the Deprecation and Sunset headers; an empty
+// date omits its header.
+type APIConfig struct {
+	V1DeprecatedAt string `yaml:"v1DeprecatedAt" env:"API_V1_DEPRECATED_AT" default:"2026-10-19"`
+	V1SunsetAt     string `yaml:"v1SunsetAt" env:"API_V1_SUNSET_AT" default:"2027-04-19"`
 }
 
-func validateConfig(cfg *Config) {
-	if cfg.ServerPort == "" {
-		log.Fatal("SERVER_PORT is required")
+// APIDateLayout is the format of the dates in APIConfig.
+const APIDateLayout = "2006-01-02"
+
+// V1Schedule returns the parsed v1 deprecation and sunset dates; unset dates are zero.
+func (c APIConfig) V1Schedule() (deprecatedAt, sunsetAt time.Time) {
+	deprecatedAt, _ = time.Parse(APIDateLayout, c.V1DeprecatedAt)
+	sunsetAt, _ = time.Parse(APIDateLayout, c.V1SunsetAt)
+	return deprecatedAt, sunsetAt
+}
+
+type LogConfig struct {
+	// Level is debug, info, warn or error. Format is json (default) or text.
+	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Format string `yaml:"format" env:"LOG_FORMAT" default:"json"`
+}
+
+type TracingConfig struct {
+	// Exporter is otlp, stdout or none. The OTLP exporter also honours the standard
+	// OTEL_EXPORTER_OTLP_* variables for headers and certificates.
+	Exporter     string  `yaml:"exporter" env:"TRACING_EXPORTER" default:"none"`
+	OTLPEndpoint string  `yaml:"otlpEndpoint" env:"TRACING_OTLP_ENDPOINT" default:"localhost:4318"`
+	OTLPInsecure bool    `yaml:"otlpInsecure" env:"TRACING_OTLP_INSECURE" default:"false"`
+	SampleRatio  float64 `yaml:"sampleRatio" env:"TRACING_SAMPLE_RATIO" default:"1"`
+	ServiceName  string  `yaml:"serviceName" env:"TRACING_SERVICE_NAME" default:"sports-app"`
+}
+
+type ServerConfig struct {
+	Port            string        `yaml:"port" env:"SERVER_PORT"`
+	ReadTimeout     time.Duration `yaml:"readTimeout" env:"SERVER_READ_TIMEOUT" default:"15s"`
+	WriteTimeout    time.Duration `yaml:"writeTimeout" env:"SERVER_WRITE_TIMEOUT" default:"75s"`
+	IdleTimeout     time.Duration `yaml:"idleTimeout" env:"SERVER_IDLE_TIMEOUT" default:"60s"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env:"SERVER_SHUTDOWN_TIMEOUT" default:"30s"`
+	// DrainDelay is how long readiness reports draining before the server stops
+	// accepting connections, giving load balancers time to deregister the instance.
+	DrainDelay         time.Duration `yaml:"drainDelay" env:"SERVER_DRAIN_DELAY" default:"5s"`
+	HealthCheckTimeout time.Duration `yaml:"healthCheckTimeout" env:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+	// TrustedProxies holds IPs/CIDRs of the load balancers in front of the API. Only
+	// their X-Forwarded-For is believed; by default none are, and the client IP is the
+	// connection's.
+	TrustedProxies []string `yaml:"trustedProxies" env:"SERVER_TRUSTED_PROXIES"`
+}
+
+type DatabaseConfig struct {
+	Host            string        `yaml:"host" env:"DB_HOST"`
+	Port            int           `yaml:"port" env:"DB_PORT" default:"5432"`
+	User            string        `yaml:"user" env:"DB_USER"`
+	Password        string        `yaml:"password" env:"DB_PASS" secret:"true"`
+	Name            string        `yaml:"name" env:"DB_NAME"`
+	SSLMode         string        `yaml:"sslMode" env:"DB_SSLMODE" default:"disable"`
+	MaxIdleConns    int           `yaml:"maxIdleConns" env:"DB_MAX_IDLE_CONNS" default:"10"`
+	MaxOpenConns    int           `yaml:"maxOpenConns" env:"DB_MAX_OPEN_CONNS" default:"50"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" env:"DB_CONN_MAX_LIFETIME" default:"1h"`
+}
+
+type AuthConfig struct {
+	JWTSecret string        `yaml:"jwtSecret" env:"JWT_SECRET" secret:"true"`
+	JWTTTL    time.Duration `yaml:"jwtTTL" env:"JWT_TTL" default:"24h"`
+	OTPTTL    time.Duration `yaml:"otpTTL" env:"OTP_TTL" default:"5m"`
+}
+
+type RedisConfig struct {
+	// Mode is standalone, sentinel or cluster. Addrs holds the server, sentinel or
+	// cluster seed addresses respectively.
+	Mode       string   `yaml:"mode" env:"REDIS_MODE" default:"standalone"`
+	Addrs      []string `yaml:"addrs" env:"REDIS_ADDR" default:"localhost:6379"`
+	MasterName string   `yaml:"masterName" env:"REDIS_MASTER_NAME"`
+	Username   string   `yaml:"username" env:"REDIS_USERNAME"`
+	Password   string   `yaml:"password" env:"REDIS_PASSWORD" secret:"true"`
+	// SentinelPassword authenticates against the sentinels when it differs from Password.
+	SentinelPassword string `yaml:"sentinelPassword" env:"REDIS_SENTINEL_PASSWORD" secret:"true"`
+	DB               int    `yaml:"db" env:"REDIS_DB" default:"0"`
+
+	TLS                   bool   `yaml:"tls" env:"REDIS_TLS" default:"false"`
+	TLSServerName         string `yaml:"tlsServerName" env:"REDIS_TLS_SERVER_NAME"`
+	TLSInsecureSkipVerify bool   `yaml:"tlsInsecureSkipVerify" env:"REDIS_TLS_INSECURE_SKIP_VERIFY" default:"false"`
+
+	PoolSize     int           `yaml:"poolSize" env:"REDIS_POOL_SIZE" default:"20"`
+	MinIdleConns int           `yaml:"minIdleConns" env:"REDIS_MIN_IDLE_CONNS" default:"2"`
+	MaxRetries   int           `yaml:"maxRetries" env:"REDIS_MAX_RETRIES" default:"3"`
+	DialTimeout  time.Duration `yaml:"dialTimeout" env:"REDIS_DIAL_TIMEOUT" default:"5s"`
+	ReadTimeout  time.Duration `yaml:"readTimeout" env:"REDIS_READ_TIMEOUT" default:"3s"`
+	WriteTimeout time.Duration `yaml:"writeTimeout" env:"REDIS_WRITE_TIMEOUT" default:"3s"`
+
+	// HealthCheckInterval is how often the connection is probed to detect outages and recovery.
+	HealthCheckInterval time.Duration `yaml:"healthCheckInterval" env:"REDIS_HEALTH_CHECK_INTERVAL" default:"10s"`
+}
+
+type AWSConfig struct {
+	Region      string `yaml:"region" env:"AWS_REGION"`
+	SQSQueueURL string `yaml:"sqsQueueURL" env:"SQS_QUEUE_URL"`
+	WorkerCount int    `yaml:"workerCount" env:"SQS_WORKER_COUNT" default:"5"`
+}
+
+type CloudinaryConfig struct {
+	CloudName     string        `yaml:"cloudName" env:"CLOUDINARY_CLOUD_NAME"`
+	APIKey        string        `yaml:"apiKey" env:"CLOUDINARY_API_KEY"`
+	APISecret     string        `yaml:"apiSecret" env:"CLOUDINARY_API_SECRET" secret:"true"`
+	UploadTimeout time.Duration `yaml:"uploadTimeout" env:"CLOUDINARY_UPLOAD_TIMEOUT" default:"120s"`
+}
+
+type LocationIQConfig struct {
+	APIKey  string        `yaml:"apiKey" env:"LOCATION_IQ_KEY" secret:"true"`
+	BaseURL string        `yaml:"baseURL" env:"LOCATION_IQ_BASE_URL" default:"https://us1.locationiq.com/v1"`
+	Timeout time.Duration `yaml:"timeout" env:"LOCATION_IQ_TIMEOUT" default:"10s"`
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host" env:"SMTP_HOST" default:"smtp.gmail.com"`
+	Port     int    `yaml:"port" env:"SMTP_PORT" default:"587"`
+	From     string `yaml:"from" env:"EMAIL_FROM"`
+	Password string `yaml:"password" env:"EMAIL_PASSWORD" secret:"true"`
+}
+
+type FacebookConfig struct {
+	ClientID     string `yaml:"clientID" env:"FACEBOOK_CLIENT_ID"`
+	ClientSecret string `yaml:"clientSecret" env:"FACEBOOK_CLIENT_SECRET" secret:"true"`
+	RedirectURL  string `yaml:"redirectURL" env:"FACEBOOK_REDIRECT_URL" default:"http://localhost:8080/oauth/callback"`
+}
+
+type RateLimitConfig struct {
+	// Allowlist holds IPs/CIDRs of internal callers exempt from rate limiting.
+	Allowlist []string `yaml:"allowlist" env:"RATE_LIMIT_ALLOWLIST"`
+}
+
+// MatchesConfig controls open matches. A match that has not reached its sport's
+// MinPlayers by its cutoff is cancelled by a sweeper running every SweepInterval.
+type MatchesConfig struct {
+	DefaultCutoff time.Duration `yaml:"defaultCutoff" env:"MATCH_DEFAULT_CUTOFF" default:"2h"`
+	SweepInterval time.Duration `yaml:"sweepInterval" env:"MATCH_SWEEP_INTERVAL" default:"1m"`
+}
+
+// PaymentsConfig controls split payments for bookings. Amounts are in the currency's
+// minor unit. A split booking not fully paid by its deadline (DefaultDeadline after
+// booking unless the organiser sets one) is released and its payments refunded by a
+// sweeper running every SweepInterval.
+type PaymentsConfig struct {
+	Provider        string        `yaml:"provider" env:"PAYMENT_PROVIDER" default:"fake"`
+	Currency        string        `yaml:"currency" env:"PAYMENT_CURRENCY" default:"PKR"`
+	DefaultDeadline time.Duration `yaml:"defaultDeadline" env:"PAYMENT_DEFAULT_DEADLINE" default:"24h"`
+	SweepInterval   time.Duration `yaml:"sweepInterval" env:"PAYMENT_SWEEP_INTERVAL" default:"1m"`
+}
+
+// TurfsConfig holds defaults for new turfs. DefaultTimeZone is the IANA time zone a
+// turf is given when its owner doesn't pick one.
+type TurfsConfig struct {
+	DefaultTimeZone string `yaml:"defaultTimeZone" env:"TURF_DEFAULT_TIME_ZONE" default:"Asia/Karachi"`
+}
+
+// CORSConfig lists the browser origins allowed to call the API with credentials.
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowedOrigins" env:"CORS_ALLOWED_ORIGINS"`
+}
+
+// CookieConfig controls the auth cookie issued on signup, verify and login.
+type CookieConfig struct {
+	Domain   string `yaml:"domain" env:"COOKIE_DOMAIN"`
+	Path     string `yaml:"path" env:"COOKIE_PATH" default:"/"`
+	Secure   bool   `yaml:"secure" env:"COOKIE_SECURE"`
+	SameSite string `yaml:"sameSite" env:"COOKIE_SAMESITE" default:"lax"`
+	// MaxAge is in seconds and always follows the JWT lifetime.
+	MaxAge int `yaml:"-"`
+}
+
+// SameSiteMode converts the configured SameSite value for net/http.
+func (c CookieConfig) SameSiteMode() http.SameSite {
+	switch strings.ToLower(c.SameSite) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
 	}
-	if cfg.DBHost == "" {
-		log.Fatal("DB_HOST is required")
+}
+
+// profile holds per-environment defaults, applied before the YAML file and env vars.
+type profile func(cfg *Config)
+
+var profiles = map[string]profile{
+	EnvDevelopment: func(cfg *Config) {
+		cfg.CORS.AllowedOrigins = []string{"http://localhost:3000", "http://localhost:5173"}
+		cfg.Cookie.Domain = "localhost"
+		cfg.Cookie.Secure = false
+		cfg.Cookie.SameSite = "lax"
+		cfg.Server.DrainDelay = 0
+	},
+	EnvStaging: func(cfg *Config) {
+		cfg.Cookie.Secure = true
+		cfg.Cookie.SameSite = "lax"
+	},
+	EnvProduction: func(cfg *Config) {
+		cfg.Cookie.Secure = true
+		cfg.Cookie.SameSite = "strict"
+		cfg.Database.SSLMode = "require"
+	},
+}
+
+// Load builds the configuration from all layers and returns every validation
+// problem at once rather than stopping at the first.
+func Load() (*Config, error) {
+	if err := godotenv.Load(); err != nil {
+		slog.Info(".env file not found, using system environment variables")
+	}
+
+	cfg := &Config{}
+	if err := applyDefaults(cfg); err != nil {
+		return nil, err
 	}
-	if cfg.DBUser == "" {
-		log.Fatal("DB_USER is required")
+
+	file, explicit := os.LookupEnv("CONFIG_FILE")
+	if !explicit {
+		file = defaultConfigFile
+	}
+	var fileData []byte
+	if data, err := os.ReadFile(file); err == nil {
+		fileData = data
+	} else if explicit || !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading config file %s: %w", file, err)
+	}
+
+	// The profile depends on the environment, which may itself come from the file or env.
+	env := cfg.Environment
+	if fileData != nil {
+		var head struct {
+			Environment string `yaml:"environment"`
+		}
+		if err := yaml.Unmarshal(fileData, &head); err != nil {
+			return nil, fmt.Errorf("parsing config file %s: %w", file, err)
+		}
+		if head.Environment != "" {
+			env = head.Environment
+		}
 	}
-	if cfg.DBName == "" {
-		log.Fatal("DB_NAME is required")
+	if v, ok := os.LookupEnv("APP_ENV"); ok {
+		env = v
 	}
-	if cfg.JWTSecret == "" {
-		log.Fatal("JWT_SECRET is required")
+	env = strings.ToLower(env)
+	if apply, ok := profiles[env]; ok {
+		apply(cfg)
 	}
+
+	if fileData != nil {
+		if err := yaml.UnmarshalWithOptions(fileData, cfg, yaml.Strict()); err != nil {
+			return nil, fmt.Errorf("parsing config file %s: %w", file, err)
+		}
+	}
+
+	var errs []error
+	if err := applyEnv(cfg); err != nil {
+		errs = append(errs, err)
+	}
+	cfg.Environment = strings.ToLower(cfg.Environment)
+	cfg.Cookie.MaxAge = int(cfg.Auth.JWTTTL.Seconds())
+
+	if err := cfg.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return cfg, nil
 }
 
+// LoadConfig loads the configuration and exits listing every problem if it is invalid.
 func LoadConfig() *Config {
-	if err := godotenv.Load(); err != nil {
-		log.Println("Warning: .env file not found, using system environment variables")
+	cfg, err := Load()
+	if err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
 	}
+	return cfg
+}
 
-	cfg := &Config{
-		ServerPort:  os.Getenv("SERVER_PORT"),
-		DBName:      os.Getenv("DB_NAME"),
-		DBHost:      os.Getenv("DB_HOST"),
-		DBUser:      os.Getenv("DB_USER"),
-		DBPass:      os.Getenv("DB_PASS"),
-		DBPort:      os.Getenv("DB_PORT"),
-		JWTSecret:   os.Getenv("JWT_SECRET"),
-		SQSQueueURL: os.Getenv("SQS_QUEUE_URL"),
+// Validate reports all configuration problems joined into one error.
+func (c *Config) Validate() error {
+	var errs []error
+	require := func(value, name string) {
+		if strings.TrimSpace(value) == "" {
+			errs = append(errs, fmt.Errorf("%s is required", name))
+		}
+	}
+	positive := func(d time.Duration, name string) {
+		if d <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a positive duration", name))
+		}
 	}
 
-	validateConfig(cfg)
-	return cfg
+	if _, ok := profiles[c.Environment]; !ok {
+		errs = append(errs, fmt.Errorf("APP_ENV must be one of: %s, %s, %s", EnvDevelopment, EnvStaging, EnvProduction))
+	}
+
+	require(c.Server.Port, "SERVER_PORT")
+	require(c.Database.Host, "DB_HOST")
+	require(c.Database.User, "DB_USER")
+	require(c.Database.Name, "DB_NAME")
+	require(c.Auth.JWTSecret, "JWT_SECRET")
+
+	positive(c.Auth.JWTTTL, "JWT_TTL")
+	positive(c.Server.ReadTimeout, "SERVER_READ_TIMEOUT")
+	positive(c.Server.WriteTimeout, "SERVER_WRITE_TIMEOUT")
+	positive(c.Server.ShutdownTimeout, "SERVER_SHUTDOWN_TIMEOUT")
+	positive(c.Server.HealthCheckTimeout, "HEALTH_CHECK_TIMEOUT")
+	positive(c.Timeouts.Read, "REQUEST_TIMEOUT_READ")
+	positive(c.Timeouts.Write, "REQUEST_TIMEOUT_WRITE")
+	positive(c.Timeouts.Upload, "REQUEST_TIMEOUT_UPLOAD")
+	positive(c.Matches.DefaultCutoff, "MATCH_DEFAULT_CUTOFF")
+	positive(c.Matches.SweepInterval, "MATCH_SWEEP_INTERVAL")
+	positive(c.Payments.DefaultDeadline, "PAYMENT_DEFAULT_DEADLINE")
+	positive(c.Payments.SweepInterval, "PAYMENT_SWEEP_INTERVAL")
+	for name, d := range map[string]time.Duration{
+		"REQUEST_TIMEOUT_READ":   c.Timeouts.Read,
+		"REQUEST_TIMEOUT_WRITE":  c.Timeouts.Write,
+		"REQUEST_TIMEOUT_UPLOAD": c.Timeouts.Upload,
+	} {
+		if d >= c.Server.WriteTimeout {
+			errs = append(errs, fmt.Errorf("%s must be shorter than SERVER_WRITE_TIMEOUT", name))
+		}
+	}
+	if c.Server.DrainDelay < 0 {
+		errs = append(errs, fmt.Errorf("SERVER_DRAIN_DELAY cannot be negative"))
+	}
+
+	apiDates := map[string]string{"API_V1_DEPRECATED_AT": c.API.V1DeprecatedAt, "API_V1_SUNSET_AT": c.API.V1SunsetAt}
+	for name, value := range apiDates {
+		if _, err := time.Parse(APIDateLayout, value); value != "" && err != nil {
+			errs = append(errs, fmt.Errorf("%s must be a date in YYYY-MM-DD format", name))
+		}
+	}
+	if deprecatedAt, sunsetAt := c.API.V1Schedule(); !deprecatedAt.IsZero() && !sunsetAt.IsZero() && !sunsetAt.After(deprecatedAt) {
+		errs = append(errs, fmt.Errorf("API_V1_SUNSET_AT must be after API_V1_DEPRECATED_AT"))
+	}
+
+	if c.Database.Port <= 0 || c.Database.Port > 65535 {
+		errs = append(errs, fmt.Errorf("DB_PORT must be a valid port"))
+	}
+	if c.Database.MaxOpenConns < 1 || c.Database.MaxIdleConns < 0 {
+		errs = append(errs, fmt.Errorf("DB_MAX_OPEN_CONNS must be at least 1 and DB_MAX_IDLE_CONNS non-negative"))
+	}
+	switch c.Redis.Mode {
+	case RedisStandalone, RedisCluster:
+	case RedisSentinel:
+		require(c.Redis.MasterName, "REDIS_MASTER_NAME")
+	default:
+		errs = append(errs, fmt.Errorf("REDIS_MODE must be one of: %s, %s, %s", RedisStandalone, RedisSentinel, RedisCluster))
+	}
+	if c.Payments.Provider != PaymentProviderFake {
+		errs = append(errs, fmt.Errorf("PAYMENT_PROVIDER must be one of: %s", PaymentProviderFake))
+	}
+	if len(c.Payments.Currency) != 3 {
+		errs = append(errs, fmt.Errorf("PAYMENT_CURRENCY must be a three-letter ISO 4217 code"))
+	}
+	if _, err := time.LoadLocation(c.Turfs.DefaultTimeZone); err != nil || c.Turfs.DefaultTimeZone == "" || c.Turfs.DefaultTimeZone == "Local" {
+		errs = append(errs, fmt.Errorf("TURF_DEFAULT_TIME_ZONE must be an IANA time zone such as Asia/Karachi"))
+	}
+	if len(c.Redis.Addrs) == 0 {
+		errs = append(errs, fmt.Errorf("REDIS_ADDR is required"))
+	}
+	if c.Redis.PoolSize < 1 || c.Redis.MinIdleConns < 0 {
+		errs = append(errs, fmt.Errorf("REDIS_POOL_SIZE must be at least 1 and REDIS_MIN_IDLE_CONNS non-negative"))
+	}
+	positive(c.Redis.HealthCheckInterval, "REDIS_HEALTH_CHECK_INTERVAL")
+	positive(c.Auth.OTPTTL, "OTP_TTL")
+
+	if c.AWS.WorkerCount < 1 {
+		errs = append(errs, fmt.Errorf("SQS_WORKER_COUNT must be at least 1"))
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		errs = append(errs, fmt.Errorf("CORS_ALLOWED_ORIGINS is required in %s", c.Environment))
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			errs = append(errs, fmt.Errorf("CORS_ALLOWED_ORIGINS cannot contain * because credentials are allowed"))
+		}
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("LOG_LEVEL must be one of: debug, info, warn, error"))
+	}
+	switch strings.ToLower(c.Log.Format) {
+	case "json", "text":
+	default:
+		errs = append(errs, fmt.Errorf("LOG_FORMAT must be json or text"))
+	}
+
+	switch c.Tracing.Exporter {
+	case TracingNone, TracingStdout:
+	case TracingOTLP:
+		require(c.Tracing.OTLPEndpoint, "TRACING_OTLP_ENDPOINT")
+	default:
+		errs = append(errs, fmt.Errorf("TRACING_EXPORTER must be one of: %s, %s, %s", TracingOTLP, TracingStdout, TracingNone))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("TRACING_SAMPLE_RATIO must be between 0 and 1"))
+	}
+
+	switch strings.ToLower(c.Cookie.SameSite) {
+	case "lax", "strict":
+	case "none":
+		if !c.Cookie.Secure {
+			errs = append(errs, fmt.Errorf("COOKIE_SAMESITE=none requires COOKIE_SECURE=true"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("COOKIE_SAMESITE must be one of: lax, strict, none"))
+	}
+
+	return errors.Join(errs...)
 }
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyDefaults sets every field from its `default` struct tag.
+func applyDefaults(cfg *Config) error {
+	return walkFields(reflect.ValueOf(cfg).Elem(), func(field reflect.Value, sf reflect.StructField) error {
+		def, ok := sf.Tag.Lookup("default")
+		if !ok {
+			return nil
+		}
+		if err := setField(field, def); err != nil {
+			return fmt.Errorf("default for %s: %w", sf.Name, err)
+		}
+		return nil
+	})
+}
+
+// applyEnv overrides fields from their `env` variables. Fields tagged secret may also be
+// read from the file named by <VAR>_FILE, which takes precedence. All parse errors are
+// collected so they can be reported together.
+func applyEnv(cfg *Config) error {
+	var errs []error
+	_ = walkFields(reflect.ValueOf(cfg).Elem(), func(field reflect.Value, sf reflect.StructField) error {
+		name := sf.Tag.Get("env")
+		if name == "" {
+			return nil
+		}
+
+		raw, ok := os.LookupEnv(name)
+		if sf.Tag.Get("secret") == "true" {
+			if path, isSet := os.LookupEnv(name + "_FILE"); isSet {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s_FILE: %w", name, err))
+					return nil
+				}
+				raw, ok = strings.TrimRight(string(data), "\r\n"), true
+			}
+		}
+		if !ok {
+			return nil
+		}
+		if err := setField(field, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		return nil
+	})
+	return errors.Join(errs...)
+}
+
+// walkFields calls fn for every leaf field, descending into nested config structs.
+func walkFields(v reflect.Value, fn func(field reflect.Value, sf reflect.StructField) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		field := v.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if field.Kind() == reflect.Struct {
+			if err := walkFields(field, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(field, sf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, raw string) error {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(strings.TrimSpace(raw))
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", field.Type())
+		}
+		field.Set(reflect.ValueOf(splitList(raw)))
+	default:
+		return fmt.Errorf("unsupported config field type %s", field.Type())
+	}
+	return nil
+}
+
+// splitList parses a comma-separated value, dropping empty entries.
+func splitList(value string) []string {
+	out := []string{}
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+// Package apidocs embeds the OpenAPI document describing the HTTP API. The same
+// document is served to clients, drives request validation and is checked against
+// the handlers by the contract test, so it must be updated with every route change.
+package apidocs
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+// kin-openapi only checks string formats it has validators for, and parameter
+// validation ignores per-request options, so the formats the document uses are
+// registered globally.
+func init() {
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForUUIDOfRFC9562))
+	openapi3.DefineStringFormatValidator("email", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForEmail))
+}
+
+// Spec returns the raw OpenAPI document.
+func Spec() []byte {
+	return spec
+}
+
+// Load parses and validates the OpenAPI document.
+func Load(ctx context.Context) (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("parse openapi document: %w", err)
+	}
+	if err := doc.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("invalid openapi document: %w", err)
+	}
+	return doc, nil
+}
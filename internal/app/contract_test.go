@@ -0,0 +1,750 @@
+package app
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/apidocs"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/cache"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/handlers"
+	"github.com/musishere/sportsApp/internal/health"
+	"github.com/musishere/sportsApp/internal/middleware"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/ratelimit"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// These tests keep internal/apidocs/openapi.yaml honest: every route must be
+// documented, and the field names handlers read and the types they write must match
+// the document. When one fails, update the spec alongside the handler.
+
+// responseTypes maps each operation's success response to the Go type the handler
+// writes.
+var responseTypes = map[string]map[int]any{
+	"RegisterUser":               {http.StatusCreated: handlers.RegisterResponse{}},
+	"VerifyEmailOtp":             {http.StatusOK: handlers.VerifyEmailResponse{}},
+	"LoginUser":                  {http.StatusOK: handlers.LoginResponse{}},
+	"GetCurrentUser":             {http.StatusOK: handlers.CurrentUserResponse{}},
+	"GetLoginHistory":            {http.StatusOK: handlers.LoginHistoryResponse{}},
+	"LogOutUser":                 {http.StatusOK: handlers.MessageResponse{}},
+	"RegisterNewSports":          {http.StatusCreated: models.Sports{}},
+	"GetAllRegisteredSports":     {http.StatusOK: types.GetAllSportsResponse{}},
+	"GetRegisteredSportsByID":    {http.StatusOK: types.SportsResponse{}},
+	"UpdateRegisteredSports":     {http.StatusOK: types.SportsResponse{}},
+	"DeleteRegisteredSport":      {http.StatusOK: handlers.MessageResponse{}},
+	"RegisterTurf":               {http.StatusCreated: dto.Turf{}},
+	"GetRegisteredTurfs":         {http.StatusOK: handlers.TurfListResponse{}},
+	"GetRegisteredTurfByID":      {http.StatusOK: dto.Turf{}},
+	"UpdateRegisteredTurf":       {http.StatusOK: dto.Turf{}},
+	"DeleteRegisteredTurf":       {http.StatusOK: handlers.MessageResponse{}},
+	"Signup":                     {http.StatusCreated: dto.Envelope[dto.User]{}},
+	"VerifyEmail":                {http.StatusOK: dto.Envelope[dto.Session]{}},
+	"Login":                      {http.StatusOK: dto.Envelope[dto.Session]{}},
+	"Me":                         {http.StatusOK: dto.Envelope[dto.User]{}},
+	"LoginHistory":               {http.StatusOK: dto.List[dto.LoginAttempt]{}},
+	"GetUser":                    {http.StatusOK: dto.Envelope[dto.PublicUser]{}},
+	"AdminGetUser":               {http.StatusOK: dto.Envelope[dto.AdminUser]{}},
+	"CreateSport":                {http.StatusCreated: dto.Envelope[dto.Sport]{}},
+	"ListSports":                 {http.StatusOK: dto.List[dto.Sport]{}},
+	"GetSport":                   {http.StatusOK: dto.Envelope[dto.Sport]{}},
+	"UpdateSport":                {http.StatusOK: dto.Envelope[dto.Sport]{}},
+	"CreateTurf":                 {http.StatusCreated: dto.Envelope[dto.Turf]{}},
+	"ListTurfs":                  {http.StatusOK: dto.List[dto.Turf]{}},
+	"GetTurf":                    {http.StatusOK: dto.Envelope[dto.Turf]{}},
+	"UpdateTurf":                 {http.StatusOK: dto.Envelope[dto.Turf]{}},
+	"CreateTeam":                 {http.StatusCreated: dto.Envelope[dto.Team]{}},
+	"ListMyTeams":                {http.StatusOK: dto.List[dto.Team]{}},
+	"GetTeam":                    {http.StatusOK: dto.Envelope[dto.Team]{}},
+	"InviteToTeam":               {http.StatusCreated: dto.Envelope[dto.Invitation]{}},
+	"ListMyInvitations":          {http.StatusOK: dto.List[dto.Invitation]{}},
+	"AcceptInvitation":           {http.StatusOK: dto.Envelope[dto.Team]{}},
+	"DeclineInvitation":          {http.StatusOK: dto.Envelope[dto.Invitation]{}},
+	"CreateBooking":              {http.StatusCreated: dto.Envelope[dto.Booking]{}},
+	"ListMyBookings":             {http.StatusOK: dto.List[dto.Booking]{}},
+	"GetBooking":                 {http.StatusOK: dto.Envelope[dto.Booking]{}},
+	"CancelBooking":              {http.StatusOK: dto.Envelope[dto.Booking]{}},
+	"CreateMatch":                {http.StatusCreated: dto.Envelope[dto.Match]{}},
+	"FindNearbyMatches":          {http.StatusOK: dto.List[dto.NearbyMatch]{}},
+	"ListMyMatches":              {http.StatusOK: dto.List[dto.Match]{}},
+	"GetMatch":                   {http.StatusOK: dto.Envelope[dto.Match]{}},
+	"JoinMatch":                  {http.StatusOK: dto.Envelope[dto.Match]{}},
+	"CancelMatch":                {http.StatusOK: dto.Envelope[dto.Match]{}},
+	"CreateSplitBooking":         {http.StatusCreated: dto.Envelope[dto.PaymentSplit]{}},
+	"GetBookingPayments":         {http.StatusOK: dto.Envelope[dto.PaymentSplit]{}},
+	"PayBookingShare":            {http.StatusOK: dto.Envelope[dto.PaymentSplit]{}},
+	"CreateTournament":           {http.StatusCreated: dto.Envelope[dto.Tournament]{}},
+	"ListTournaments":            {http.StatusOK: dto.List[dto.Tournament]{}},
+	"GetTournament":              {http.StatusOK: dto.Envelope[dto.Tournament]{}},
+	"RegisterTournamentTeam":     {http.StatusOK: dto.Envelope[dto.Tournament]{}},
+	"ScheduleTournament":         {http.StatusOK: dto.Envelope[dto.Tournament]{}},
+	"CancelTournament":           {http.StatusOK: dto.Envelope[dto.Tournament]{}},
+	"ListTournamentFixtures":     {http.StatusOK: dto.List[dto.Fixture]{}},
+	"RecordFixtureResult":        {http.StatusOK: dto.Envelope[dto.Fixture]{}},
+	"GetTournamentStandings":     {http.StatusOK: dto.List[dto.StandingsTable]{}},
+	"GetTournamentBracket":       {http.StatusOK: dto.List[dto.BracketRound]{}},
+	"RecordMatchResult":          {http.StatusCreated: dto.Envelope[dto.MatchResult]{}},
+	"GetMatchResult":             {http.StatusOK: dto.Envelope[dto.MatchResult]{}},
+	"GetPlayerStats":             {http.StatusOK: dto.Envelope[dto.PlayerProfile]{}},
+	"GetLeaderboard":             {http.StatusOK: dto.List[dto.LeaderboardEntry]{}},
+	"RebuildLeaderboards":        {http.StatusOK: dto.Envelope[dto.LeaderboardRebuild]{}},
+	"GetPlayerRatings":           {http.StatusOK: dto.List[dto.PlayerRating]{}},
+	"SuggestMatchTeams":          {http.StatusOK: dto.Envelope[dto.TeamSplit]{}},
+	"RecommendMatches":           {http.StatusOK: dto.List[dto.RecommendedMatch]{}},
+	"CreateTurfReview":           {http.StatusCreated: dto.Envelope[dto.Review]{}},
+	"ListTurfReviews":            {http.StatusOK: dto.List[dto.Review]{}},
+	"ReplyToReview":              {http.StatusOK: dto.Envelope[dto.Review]{}},
+	"ListReportedReviews":        {http.StatusOK: dto.List[dto.ModeratedReview]{}},
+	"HideReview":                 {http.StatusOK: dto.Envelope[dto.ModeratedReview]{}},
+	"RestoreReview":              {http.StatusOK: dto.Envelope[dto.ModeratedReview]{}},
+	"ListFavouriteTurfs":         {http.StatusOK: dto.List[dto.FavouriteTurf]{}},
+	"CreateSavedSearch":          {http.StatusCreated: dto.Envelope[dto.SavedSearch]{}},
+	"ListSavedSearches":          {http.StatusOK: dto.List[dto.SavedSearch]{}},
+	"GetSavedSearch":             {http.StatusOK: dto.Envelope[dto.SavedSearch]{}},
+	"CreateBookingSeries":        {http.StatusCreated: dto.Envelope[dto.BookingSeries]{}},
+	"QuoteBookingSeries":         {http.StatusOK: dto.Envelope[dto.SeriesQuote]{}},
+	"ListMyBookingSeries":        {http.StatusOK: dto.List[dto.BookingSeries]{}},
+	"GetBookingSeries":           {http.StatusOK: dto.Envelope[dto.BookingSeries]{}},
+	"CancelBookingSeries":        {http.StatusOK: dto.Envelope[dto.BookingSeries]{}},
+	"CancelSeriesOccurrence":     {http.StatusOK: dto.Envelope[dto.BookingSeries]{}},
+	"RescheduleSeriesOccurrence": {http.StatusOK: dto.Envelope[dto.BookingSeries]{}},
+	"ListPricingRules":           {http.StatusOK: dto.List[dto.PricingRule]{}},
+	"CreatePricingRule":          {http.StatusCreated: dto.Envelope[dto.PricingRule]{}},
+	"SetTurfOpeningHours":        {http.StatusOK: dto.Envelope[dto.Turf]{}},
+	"CreateTurfClosure":          {http.StatusCreated: dto.Envelope[dto.TurfClosure]{}},
+	"ListTurfClosures":           {http.StatusOK: dto.List[dto.TurfClosure]{}},
+	"GetTurfClosure":             {http.StatusOK: dto.Envelope[dto.TurfClosure]{}},
+	"Liveness":                   {http.StatusOK: handlers.LivenessResponse{}},
+	"Readiness":                  {http.StatusOK: health.Report{}},
+}
+
+// requestTypes maps each operation with a bound request body to the Go type it binds.
+var requestTypes = map[string]any{
+	"RegisterUser":               types.RegisterRequest{},
+	"LoginUser":                  types.LoginRequest{},
+	"UpdateRegisteredSports":     types.UpdateSportRequest{},
+	"UpdateRegisteredTurf":       types.UpdateTurfRequest{},
+	"Signup":                     types.RegisterRequest{},
+	"VerifyEmail":                types.VerifyEmailRequest{},
+	"Login":                      types.LoginRequest{},
+	"UpdateSport":                types.UpdateSportRequest{},
+	"UpdateTurf":                 types.UpdateTurfRequest{},
+	"CreateTeam":                 types.CreateTeamRequest{},
+	"InviteToTeam":               types.InviteToTeamRequest{},
+	"CreateBooking":              types.CreateBookingRequest{},
+	"CreateMatch":                types.CreateMatchRequest{},
+	"CreateSplitBooking":         types.CreateSplitBookingRequest{},
+	"PayBookingShare":            types.PayShareRequest{},
+	"CreateTournament":           types.CreateTournamentRequest{},
+	"RegisterTournamentTeam":     types.RegisterTournamentTeamRequest{},
+	"RecordFixtureResult":        types.FixtureResultRequest{},
+	"RecordMatchResult":          types.RecordMatchResultRequest{},
+	"ReplyToReview":              types.ReplyToReviewRequest{},
+	"ReportReview":               types.ReportReviewRequest{},
+	"HideReview":                 types.HideReviewRequest{},
+	"CreateSavedSearch":          types.CreateSavedSearchRequest{},
+	"CreateBookingSeries":        types.CreateBookingSeriesRequest{},
+	"QuoteBookingSeries":         types.CreateBookingSeriesRequest{},
+	"RescheduleSeriesOccurrence": types.RescheduleOccurrenceRequest{},
+	"CreatePricingRule":          types.CreatePricingRuleRequest{},
+	"SetTurfOpeningHours":        types.SetOpeningHoursRequest{},
+	"CreateTurfClosure":          types.CreateClosureRequest{},
+}
+
+// handDecodedBodies are JSON bodies read without a request type, e.g. to accept a
+// field as either a string or a number.
+var handDecodedBodies = map[string]bool{
+	"VerifyEmailOtp": true,
+}
+
+// undocumentedRoutes are served outside internal/routes and intentionally left out of
+// the document.
+var undocumentedRoutes = map[string]bool{
+	"GET /metrics": true,
+}
+
+var handlerNamePattern = regexp.MustCompile(`\(\*(\w+)\)\.(\w+)-fm$`)
+
+func loadSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+	doc, err := apidocs.Load(context.Background())
+	if err != nil {
+		t.Fatalf("load spec: %v", err)
+	}
+	return doc
+}
+
+// newTestRouter builds the real route table over zero-value services. Nothing here
+// reaches a database or Redis: the tests only inspect routes and exercise middleware
+// that rejects requests before a handler runs.
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	redisClient, err := cache.NewClient(config.RedisConfig{Mode: config.RedisStandalone, Addrs: []string{"127.0.0.1:0"}})
+	if err != nil {
+		t.Fatalf("redis client: %v", err)
+	}
+	t.Cleanup(func() { redisClient.Close() })
+	limiter, err := ratelimit.NewLimiter(redisClient, nil)
+	if err != nil {
+		t.Fatalf("limiter: %v", err)
+	}
+
+	cfg := &config.Config{
+		Tracing:  config.TracingConfig{ServiceName: "sportsapp-test"},
+		CORS:     config.CORSConfig{AllowedOrigins: []string{"http://localhost:3000"}},
+		Auth:     config.AuthConfig{JWTSecret: "contract-test"},
+		Timeouts: config.TimeoutConfig{Read: time.Second, Write: time.Second, Upload: time.Second},
+		API:      config.APIConfig{V1DeprecatedAt: "2026-10-19", V1SunsetAt: "2027-04-19"},
+	}
+	router, err := NewRouter(cfg, Dependencies{
+		Checker:            health.NewChecker(time.Second),
+		Limiter:            limiter,
+		UserService:        &services.UserService{},
+		SportsService:      &services.SportsService{},
+		TurfService:        &services.TurfService{},
+		TeamService:        &services.TeamService{},
+		BookingService:     &services.BookingService{},
+		MatchService:       &services.MatchService{},
+		PaymentService:     &services.PaymentService{},
+		TournamentService:  &services.TournamentService{},
+		StatsService:       &services.StatsService{},
+		MatchmakingService: &services.MatchmakingService{},
+		ReviewService:      &services.ReviewService{},
+		FavouriteService:   &services.FavouriteService{},
+		SavedSearchService: &services.SavedSearchService{},
+		SeriesService:      &services.BookingSeriesService{},
+		PricingService:     &services.PricingService{},
+		CalendarService:    &services.CalendarService{},
+	})
+	if err != nil {
+		t.Fatalf("router: %v", err)
+	}
+	return router
+}
+
+// specPath converts a gin path such as /turfs/:id to the OpenAPI form /turfs/{id}.
+func specPath(ginPath string) string {
+	parts := strings.Split(ginPath, "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, ":") {
+			parts[i] = "{" + p[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// routeHandlers maps each documented operationId to the handler serving it, as
+// "Type.Method".
+func routeHandlers(t *testing.T, doc *openapi3.T, router *gin.Engine) map[string]string {
+	t.Helper()
+	byOperation := map[string]string{}
+	served := map[string]bool{}
+
+	for _, route := range router.Routes() {
+		key := route.Method + " " + route.Path
+		if undocumentedRoutes[key] {
+			continue
+		}
+		served[route.Method+" "+specPath(route.Path)] = true
+
+		item := doc.Paths.Find(specPath(route.Path))
+		if item == nil || item.GetOperation(route.Method) == nil {
+			t.Errorf("%s is served but not documented in openapi.yaml", key)
+			continue
+		}
+		op := item.GetOperation(route.Method)
+
+		m := handlerNamePattern.FindStringSubmatch(route.Handler)
+		if m == nil {
+			t.Errorf("%s: cannot identify handler %s", key, route.Handler)
+			continue
+		}
+		if m[2] != op.OperationID {
+			t.Errorf("%s: operationId %q does not match handler %s.%s", key, op.OperationID, m[1], m[2])
+			continue
+		}
+		byOperation[op.OperationID] = m[1] + "." + m[2]
+	}
+
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			if !served[method+" "+path] {
+				t.Errorf("%s %s is documented but not served", method, path)
+			}
+		}
+	}
+	return byOperation
+}
+
+func TestRoutesMatchSpec(t *testing.T) {
+	doc := loadSpec(t)
+	routeHandlers(t, doc, newTestRouter(t))
+}
+
+func TestResponseShapesMatchSpec(t *testing.T) {
+	doc := loadSpec(t)
+
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			for code, resp := range op.Responses.Map() {
+				status, err := strconv.Atoi(code)
+				if err != nil || status >= 400 || resp.Value.Content.Get("application/json") == nil {
+					continue
+				}
+				goType, ok := responseTypes[op.OperationID][status]
+				if !ok {
+					t.Errorf("%s %s: no Go type registered for the %d response", method, path, status)
+					continue
+				}
+				schema := resp.Value.Content.Get("application/json").Schema.Value
+				compareSchema(t, op.OperationID+" "+code, reflect.TypeOf(goType), schema)
+			}
+		}
+	}
+
+	errSchema := doc.Components.Schemas["Error"].Value
+	compareSchema(t, "Error", reflect.TypeOf(middleware.ErrorResponse{}), errSchema)
+}
+
+func TestRequestBodiesMatchSpec(t *testing.T) {
+	doc := loadSpec(t)
+
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			if op.RequestBody == nil {
+				continue
+			}
+			goType, bound := requestTypes[op.OperationID]
+			for mediaType, content := range op.RequestBody.Value.Content {
+				schema := content.Schema.Value
+				switch {
+				case mediaType == "application/json" && handDecodedBodies[op.OperationID]:
+					continue
+				case bound:
+					tag := "json"
+					if mediaType != "application/json" {
+						tag = "form"
+					}
+					compareRequest(t, op.OperationID+" "+mediaType, reflect.TypeOf(goType), tag, schema)
+				case mediaType == "application/json":
+					t.Errorf("%s %s: no Go type registered for the JSON request body", method, path)
+				}
+			}
+		}
+	}
+}
+
+// TestHandlerFieldsMatchSpec compares the form fields, query parameters and path
+// parameters each handler reads by name with those its operation documents.
+func TestHandlerFieldsMatchSpec(t *testing.T) {
+	doc := loadSpec(t)
+	byOperation := routeHandlers(t, doc, newTestRouter(t))
+	reads := handlerReads(t, "../handlers")
+
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			handler, ok := byOperation[op.OperationID]
+			if !ok {
+				continue
+			}
+			read := reads[handler]
+
+			documented := map[string][]string{}
+			for _, p := range append(item.Parameters, op.Parameters...) {
+				documented[p.Value.In] = append(documented[p.Value.In], p.Value.Name)
+			}
+			// Form fields bound through a request type are covered by TestRequestBodiesMatchSpec.
+			if _, bound := requestTypes[op.OperationID]; !bound && op.RequestBody != nil {
+				if content := op.RequestBody.Value.Content.Get("multipart/form-data"); content != nil {
+					documented["form"] = keys(content.Schema.Value.Properties)
+				}
+			}
+
+			for _, in := range []string{"form", "query", "path"} {
+				if diff := symmetricDiff(read[in], documented[in]); diff != "" {
+					t.Errorf("%s %s (%s): %s fields differ between handler and spec: %s", method, path, handler, in, diff)
+				}
+			}
+		}
+	}
+}
+
+func TestRequestsAreValidatedAgainstSpec(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		field  string
+	}{
+		{"missing required field", http.MethodPost, "/api/v1/signup", `{"name":"a"}`, "email"},
+		{"query parameter of the wrong type", http.MethodGet, "/api/v1/turfs?page=first", "", "page"},
+		{"path parameter of the wrong format", http.MethodGet, "/api/v1/sports/not-a-uuid", "", "id"},
+		{"value out of range", http.MethodPut, "/api/v1/turfs/0b6f4d1c-3b8a-4a7e-9a43-2f1d7c1e5b11", `{"startTime":24}`, "startTime"},
+		{"page size over the maximum", http.MethodGet, "/api/v2/turfs?pageSize=500", "", "pageSize"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400; body %s", rec.Code, rec.Body)
+			}
+			var resp middleware.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if resp.Error.Message != "request does not match the API specification" {
+				t.Fatalf("request was not rejected by spec validation: %+v", resp.Error)
+			}
+			if !slices.ContainsFunc(resp.Error.Details, func(f apperrors.FieldError) bool { return f.Field == tt.field }) {
+				t.Fatalf("details %+v do not mention %q", resp.Error.Details, tt.field)
+			}
+		})
+	}
+}
+
+func TestV1RoutesAnnounceDeprecation(t *testing.T) {
+	router := newTestRouter(t)
+
+	for target, deprecated := range map[string]bool{"/api/v1/sports": true, "/api/v2/sports": false} {
+		req := httptest.NewRequest(http.MethodGet, target+"/not-a-uuid", nil)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		want := map[string]string{"Deprecation": "", "Sunset": "", "Link": ""}
+		if deprecated {
+			want = map[string]string{
+				"Deprecation": "@1792368000",
+				"Sunset":      "Mon, 19 Apr 2027 00:00:00 GMT",
+				"Link":        `</api/v2>; rel="successor-version"`,
+			}
+		}
+		for header, value := range want {
+			if got := rec.Header().Get(header); got != value {
+				t.Errorf("%s: %s = %q, want %q", target, header, got, value)
+			}
+		}
+	}
+}
+
+// compareSchema checks that the JSON encoding of goType has exactly the properties,
+// recursively, that schema documents.
+func compareSchema(t *testing.T, where string, goType reflect.Type, schema *openapi3.Schema) {
+	t.Helper()
+	for goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+	if isJSONLeaf(goType) {
+		if want := jsonKind(goType); want != "" && schema.Type != nil && !schema.Type.Is(want) {
+			t.Errorf("%s: Go type %s encodes as %s, spec says %v", where, goType, want, schema.Type.Slice())
+		}
+		return
+	}
+
+	switch goType.Kind() {
+	case reflect.Struct:
+		fields := jsonFields(goType, "json")
+		if diff := symmetricDiff(keys(fields), keys(schema.Properties)); diff != "" {
+			t.Errorf("%s: properties differ between %s and spec: %s", where, goType, diff)
+		}
+		for name, field := range fields {
+			if prop, ok := schema.Properties[name]; ok {
+				compareSchema(t, where+"."+name, field.Type, prop.Value)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if schema.Items == nil {
+			t.Errorf("%s: Go type %s is a list, spec has no items", where, goType)
+			return
+		}
+		compareSchema(t, where+"[]", goType.Elem(), schema.Items.Value)
+	case reflect.Map:
+		if schema.AdditionalProperties.Schema == nil {
+			t.Errorf("%s: Go type %s is a map, spec has no additionalProperties", where, goType)
+			return
+		}
+		compareSchema(t, where+"{}", goType.Elem(), schema.AdditionalProperties.Schema.Value)
+	}
+}
+
+// compareRequest checks a bound request type against its schema, including which
+// fields are required.
+func compareRequest(t *testing.T, where string, goType reflect.Type, tag string, schema *openapi3.Schema) {
+	t.Helper()
+	fields := jsonFields(goType, tag)
+	if diff := symmetricDiff(keys(fields), keys(schema.Properties)); diff != "" {
+		t.Errorf("%s: %s fields differ between %s and spec: %s", where, tag, goType, diff)
+	}
+
+	var required []string
+	for name, field := range fields {
+		if slices.Contains(strings.Split(field.Tag.Get("binding"), ","), "required") {
+			required = append(required, name)
+		}
+		if prop, ok := schema.Properties[name]; ok && tag == "json" {
+			compareSchema(t, where+"."+name, field.Type, prop.Value)
+		}
+	}
+	if diff := symmetricDiff(required, schema.Required); diff != "" {
+		t.Errorf("%s: required fields differ between %s and spec: %s", where, goType, diff)
+	}
+}
+
+// jsonFields returns the encoded fields of a struct by name, following embedded structs.
+func jsonFields(goType reflect.Type, tag string) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := range goType.NumField() {
+		field := goType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for n, f := range jsonFields(field.Type, tag) {
+				fields[n] = f
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+var (
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// isJSONLeaf reports whether goType encodes as a single JSON value rather than an
+// object or list.
+func isJSONLeaf(goType reflect.Type) bool {
+	if goType.Implements(jsonMarshalerType) || goType.Implements(textMarshalerType) {
+		return true
+	}
+	switch goType.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return false
+	}
+	return true
+}
+
+func jsonKind(goType reflect.Type) string {
+	if goType.Implements(textMarshalerType) {
+		return "string"
+	}
+	switch goType.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return ""
+}
+
+// handlerReads parses the handler sources and records, for each "Type.Method", the
+// form fields, query parameters and path parameters it reads by literal name, including
+// those read by the package-level functions it calls.
+func handlerReads(t *testing.T, dir string) map[string]map[string][]string {
+	t.Helper()
+	accessors := map[string]string{
+		"PostForm": "form", "DefaultPostForm": "form", "FormFile": "form",
+		"Query": "query", "DefaultQuery": "query", "GetQuery": "query",
+		"Param": "path",
+	}
+	// Local helpers that read a form field named by one of their arguments.
+	formHelpers := map[string]bool{"optionalFloatForm": true, "readFormFile": true}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, 0)
+	if err != nil {
+		t.Fatalf("parse handlers: %v", err)
+	}
+
+	direct := map[string]map[string][]string{}
+	calls := map[string][]string{}
+	var methods []string
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil {
+					continue
+				}
+				name := fn.Name.Name
+				if fn.Recv != nil {
+					recv := fn.Recv.List[0].Type
+					if star, ok := recv.(*ast.StarExpr); ok {
+						recv = star.X
+					}
+					ident, ok := recv.(*ast.Ident)
+					if !ok {
+						continue
+					}
+					name = ident.Name + "." + name
+					methods = append(methods, name)
+				}
+
+				// Loop variables ranging over a literal list of field names.
+				ranged := map[string][]string{}
+				found := map[string][]string{}
+				ast.Inspect(fn.Body, func(n ast.Node) bool {
+					if loop, ok := n.(*ast.RangeStmt); ok {
+						if v, ok := loop.Value.(*ast.Ident); ok {
+							if lit, ok := loop.X.(*ast.CompositeLit); ok {
+								ranged[v.Name] = stringLiterals(lit.Elts)
+							}
+						}
+						return true
+					}
+					call, ok := n.(*ast.CallExpr)
+					if !ok {
+						return true
+					}
+					var in string
+					switch f := call.Fun.(type) {
+					case *ast.SelectorExpr:
+						in = accessors[f.Sel.Name]
+					case *ast.Ident:
+						if formHelpers[f.Name] {
+							in = "form"
+						} else {
+							calls[name] = append(calls[name], f.Name)
+						}
+					}
+					if in == "" {
+						return true
+					}
+					for _, arg := range call.Args {
+						names := stringLiterals([]ast.Expr{arg})
+						if ident, ok := arg.(*ast.Ident); ok {
+							names = ranged[ident.Name]
+						}
+						if len(names) > 0 {
+							for _, field := range names {
+								if !slices.Contains(found[in], field) {
+									found[in] = append(found[in], field)
+								}
+							}
+							break
+						}
+					}
+					return true
+				})
+				direct[name] = found
+			}
+		}
+	}
+
+	reads := map[string]map[string][]string{}
+	for _, method := range methods {
+		found := map[string][]string{}
+		seen := map[string]bool{}
+		var visit func(string)
+		visit = func(fn string) {
+			if seen[fn] {
+				return
+			}
+			seen[fn] = true
+			for in, fields := range direct[fn] {
+				for _, field := range fields {
+					if !slices.Contains(found[in], field) {
+						found[in] = append(found[in], field)
+					}
+				}
+			}
+			for _, callee := range calls[fn] {
+				visit(callee)
+			}
+		}
+		visit(method)
+		reads[method] = found
+	}
+	return reads
+}
+
+// stringLiterals returns the values of the string literals in exprs.
+func stringLiterals(exprs []ast.Expr) []string {
+	var out []string
+	for _, expr := range exprs {
+		if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			value, _ := strconv.Unquote(lit.Value)
+			out = append(out, value)
+		}
+	}
+	return out
+}
+
+func keys[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
+
+// symmetricDiff describes the names only in got ("handler") and only in want ("spec"),
+// or returns "" when the sets match.
+func symmetricDiff(got, want []string) string {
+	var onlyGot, onlyWant []string
+	for _, g := range got {
+		if !slices.Contains(want, g) {
+			onlyGot = append(onlyGot, g)
+		}
+	}
+	for _, w := range want {
+		if !slices.Contains(got, w) {
+			onlyWant = append(onlyWant, w)
+		}
+	}
+	sort.Strings(onlyGot)
+	sort.Strings(onlyWant)
+
+	var parts []string
+	if len(onlyGot) > 0 {
+		parts = append(parts, "only in code: "+strings.Join(onlyGot, ", "))
+	}
+	if len(onlyWant) > 0 {
+		parts = append(parts, "only in spec: "+strings.Join(onlyWant, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
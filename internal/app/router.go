@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/apidocs"
+	"github.com/musishere/sportsApp/internal/health"
+	"github.com/musishere/sportsApp/internal/metrics"
+	"github.com/musishere/sportsApp/internal/middleware"
+	"github.com/musishere/sportsApp/internal/oauth"
+	"github.com/musishere/sportsApp/internal/ratelimit"
+	"github.com/musishere/sportsApp/internal/routes"
+	"github.com/musishere/sportsApp/internal/services"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// Dependencies are the services and infrastructure the HTTP routes are built on.
+type Dependencies struct {
+	Checker            *health.Checker
+	Limiter            *ratelimit.Limiter
+	Facebook           *oauth.Facebook
+	UserService        *services.UserService
+	SportsService      *services.SportsService
+	TurfService        *services.TurfService
+	TeamService        *services.TeamService
+	BookingService     *services.BookingService
+	MatchService       *services.MatchService
+	PaymentService     *services.PaymentService
+	TournamentService  *services.TournamentService
+	StatsService       *services.StatsService
+	MatchmakingService *services.MatchmakingService
+	ReviewService      *services.ReviewService
+	FavouriteService   *services.FavouriteService
+	SavedSearchService *services.SavedSearchService
+	SeriesService      *services.BookingSeriesService
+	PricingService     *services.PricingService
+	CalendarService    *services.CalendarService
+}
+
+// NewRouter builds the HTTP handler with its middleware and the full route table.
+func NewRouter(cfg *config.Config, deps Dependencies) (*gin.Engine, error) {
+	doc, err := apidocs.Load(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	validateRequests, err := middleware.ValidateRequests(doc)
+	if err != nil {
+		return nil, fmt.Errorf("request validation: %w", err)
+	}
+
+	router := gin.New()
+	// Client IPs key rate limits and login history, so forwarding headers are only
+	// believed from the configured proxies.
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("trusted proxies: %w", err)
+	}
+	// Probe and scrape endpoints are polled constantly; tracing them would drown real traffic.
+	router.Use(otelgin.Middleware(cfg.Tracing.ServiceName, otelgin.WithGinFilter(func(c *gin.Context) bool {
+		switch c.FullPath() {
+		case "/healthz", "/readyz", "/metrics":
+			return false
+		}
+		return true
+	})))
+	router.Use(middleware.RequestID(), middleware.AccessLog(), metrics.Middleware(), middleware.Recovery(), middleware.ErrorHandler())
+
+	// CORS - only the configured origins may send credentials (cookies)
+	router.Use(cors.New(cors.Config{
+		AllowOrigins:     cfg.CORS.AllowedOrigins,
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", middleware.RequestIDHeader},
+		AllowCredentials: true,
+		ExposeHeaders: []string{
+			"Content-Length", "Location", middleware.RequestIDHeader, "Retry-After",
+			"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy",
+			"Deprecation", "Sunset", "Link",
+		},
+	}))
+
+	// Identify signed-in callers so rate limits are keyed per user rather than per IP
+	router.Use(middleware.Authenticate(cfg.Auth.JWTSecret))
+
+	routes.SetupHealthRoutes(router, deps.Checker)
+	routes.SetupDocsRoutes(router)
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// v1 is frozen and scheduled for removal; clients are pointed at v2 on every response.
+	deprecatedAt, sunsetAt := cfg.API.V1Schedule()
+	v1 := router.Group("/api/v1")
+	v1.Use(middleware.Deprecation(deprecatedAt, sunsetAt, "/api/v2"), middleware.Deadlines(cfg.Timeouts), validateRequests)
+
+	routes.SetupUserRoutes(v1, deps.UserService, deps.Facebook, deps.Limiter, cfg.Cookie)
+	routes.SetupSportsRoutes(v1, deps.SportsService, deps.Limiter)
+	routes.SetupTurfRoutes(v1, deps.TurfService, deps.Limiter)
+
+	v2 := router.Group("/api/v2")
+	v2.Use(middleware.Deadlines(cfg.Timeouts), validateRequests)
+
+	routes.SetupV2UserRoutes(v2, deps.UserService, deps.Limiter, cfg.Cookie)
+	routes.SetupV2SportsRoutes(v2, deps.SportsService, deps.Limiter)
+	routes.SetupV2TurfRoutes(v2, deps.TurfService, deps.Limiter)
+	routes.SetupV2TeamRoutes(v2, deps.TeamService, deps.Limiter)
+	routes.SetupV2BookingRoutes(v2, deps.BookingService, deps.Limiter)
+	routes.SetupV2MatchRoutes(v2, deps.MatchService, deps.Limiter)
+	routes.SetupV2PaymentRoutes(v2, deps.PaymentService, deps.Limiter)
+	routes.SetupV2TournamentRoutes(v2, deps.TournamentService, deps.Limiter)
+	routes.SetupV2StatsRoutes(v2, deps.StatsService, deps.Limiter)
+	routes.SetupV2MatchmakingRoutes(v2, deps.MatchmakingService, deps.Limiter)
+	routes.SetupV2ReviewRoutes(v2, deps.ReviewService, deps.Limiter)
+	routes.SetupV2FavouriteRoutes(v2, deps.FavouriteService, deps.SavedSearchService, deps.Limiter)
+	routes.SetupV2SeriesRoutes(v2, deps.SeriesService, deps.PricingService, deps.Limiter)
+	routes.SetupV2CalendarRoutes(v2, deps.CalendarService, deps.Limiter)
+
+	return router, nil
+}
@@ -2,104 +2,200 @@ package app
 
 import (
 	"context"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
 	"github.com/musishere/sportsApp/config"
 	"github.com/musishere/sportsApp/internal/cache"
 	"github.com/musishere/sportsApp/internal/database"
+	"github.com/musishere/sportsApp/internal/health"
 	"github.com/musishere/sportsApp/internal/helpers"
+	"github.com/musishere/sportsApp/internal/leaderboard"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/metrics"
+	"github.com/musishere/sportsApp/internal/notifications"
+	"github.com/musishere/sportsApp/internal/oauth"
+	"github.com/musishere/sportsApp/internal/payments"
 	"github.com/musishere/sportsApp/internal/queue"
+	"github.com/musishere/sportsApp/internal/ratelimit"
 	"github.com/musishere/sportsApp/internal/repositories"
-	"github.com/musishere/sportsApp/internal/routes"
 	"github.com/musishere/sportsApp/internal/services"
-	"golang.org/x/time/rate"
+	"github.com/musishere/sportsApp/internal/tracing"
+	"github.com/musishere/sportsApp/internal/utils"
 )
 
 func StartServer() {
 	cfg := config.LoadConfig()
 
-	//! Redis cache
-	cache.ConnectRedis()
+	//! Structured logging - JSON by default, sensitive fields redacted
+	logger := logging.New(cfg.Log, os.Stdout)
+	logging.SetDefault(logger)
+
+	ctx, cancel := context.WithCancel(logging.WithLogger(context.Background(), logger))
+	defer cancel()
+
+	//! Tracing - OTLP to a collector, or stdout for local runs
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, cfg.Environment)
+	if err != nil {
+		logging.Fatal("Tracing init failed", logging.Err(err))
+	}
+
+	//! Redis cache - the API keeps serving reads while Redis is down
+	redisClient, err := cache.NewClient(cfg.Redis)
+	if err != nil {
+		logging.Fatal("Redis init failed", logging.Err(err))
+	}
+	if err := redisClient.Check(ctx); err != nil {
+		logger.Warn("Redis unavailable at startup, running in degraded mode", logging.Err(err))
+	}
+	go redisClient.Monitor(ctx)
+	otpStore := helpers.NewOTPStore(redisClient, cfg.Auth.OTPTTL)
+	loginFailures := helpers.NewLoginFailures(redisClient)
+	leaderboards := leaderboard.NewBoard(redisClient)
 
 	//! connect database
-	db := database.ConnectDatabase(cfg)
+	db := database.ConnectDatabase(cfg.Database)
 	// Schema is managed by golang-migrate (make migrate-up). Do not use AutoMigrate here.
-
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	if sqlDB, err := db.DB(); err == nil {
+		if err := metrics.RegisterDBStats(sqlDB, cfg.Database.Name); err != nil {
+			logger.Warn("Failed to register database metrics", logging.Err(err))
+		}
+	}
 
 	//! Repositories
 	userRepo := repositories.NewUserRepository(db)
 	locationRepo := repositories.NewLocationRepository(db)
 	sportsRepo := repositories.NewSportsRepository(db)
 	turfRepo := repositories.NewTurfRepository(db)
+	loginHistoryRepo := repositories.NewLoginHistoryRepository(db)
+	teamRepo := repositories.NewTeamRepository(db)
+	bookingRepo := repositories.NewBookingRepository(db)
+	matchRepo := repositories.NewMatchRepository(db)
+	paymentRepo := repositories.NewPaymentRepository(db)
+	tournamentRepo := repositories.NewTournamentRepository(db)
+	resultRepo := repositories.NewResultRepository(db)
+	ratingRepo := repositories.NewRatingRepository(db)
+	reviewRepo := repositories.NewReviewRepository(db)
+	favouriteRepo := repositories.NewFavouriteRepository(db)
+	savedSearchRepo := repositories.NewSavedSearchRepository(db)
+	seriesRepo := repositories.NewSeriesRepository(db)
+	pricingRepo := repositories.NewPricingRepository(db)
+	calendarRepo := repositories.NewCalendarRepository(db)
 
 	//! Amazon SQS
-	sqsClient, err := queue.NewClient(ctx)
+	sqsClient, err := queue.NewClient(ctx, cfg.AWS.Region)
+	if err != nil {
+		logging.Fatal("Error creating client for amazonSqs", logging.Err(err))
+	}
+	queueURL := cfg.AWS.SQSQueueURL
+
+	//! Notifications (delivered by the worker pool)
+	mailer := helpers.NewMailer(cfg.SMTP)
+	smsSender, err := utils.NewSMSSender(ctx, cfg.AWS)
 	if err != nil {
-		log.Fatal("Error creating client for amazonSqs", err)
+		logging.Fatal("Error creating SMS sender", logging.Err(err))
 	}
-	queueURL := cfg.SQSQueueURL
-	go queue.StartWorkerPool(ctx, sqsClient, queueURL, 5)
+	notifier := notifications.NewNotifier(sqsClient, queueURL, mailer, smsSender)
+	go queue.StartWorkerPool(ctx, sqsClient, queueURL, cfg.AWS.WorkerCount, notifier.Handlers())
 
 	//! Image uploading
-	imageUploader, err := helpers.NewImageUploader()
+	imageUploader, err := helpers.NewImageUploader(cfg.Cloudinary)
 	if err != nil {
-		log.Fatal("Cloudinary init failed:", err)
+		logging.Fatal("Cloudinary init failed", logging.Err(err))
 	}
 
+	//! Payment provider
+	paymentProvider, err := payments.NewProvider(cfg.Payments)
+	if err != nil {
+		logging.Fatal("Payment provider init failed", logging.Err(err))
+	}
+
+	//! Geocoding and OAuth providers
+	geocoder := helpers.NewGeocoder(cfg.LocationIQ)
+	facebook := oauth.NewFacebook(cfg.Facebook)
+
 	//! Services
-	userService := services.NewUserService(userRepo, locationRepo, cfg.JWTSecret)
+	userService := services.NewUserService(userRepo, locationRepo, loginHistoryRepo, notifier, mailer, otpStore, loginFailures, cfg.Auth)
 	sportsService := services.NewSportsService(sportsRepo, imageUploader)
-	turfService := services.NewTurfService(turfRepo, imageUploader)
-
-	router := gin.Default()
-
-	// CORS - allow all origins; reflect request origin so credentials (cookies) work
-	router.Use(cors.New(cors.Config{
-		AllowOriginFunc:  func(origin string) bool { return true },
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		AllowCredentials: true,
-		ExposeHeaders:    []string{"Content-Length"},
-	}))
-
-	// Global rate limiter
-	limiter := rate.NewLimiter(rate.Limit(100), 50)
-	router.Use(func(c *gin.Context) {
-		if !limiter.Allow() {
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
-			return
-		}
-		c.Next()
-	})
+	turfService := services.NewTurfService(turfRepo, imageUploader, geocoder, cfg.Turfs)
+	teamService := services.NewTeamService(teamRepo, sportsRepo, userRepo, notifier)
+	savedSearchService := services.NewSavedSearchService(savedSearchRepo, locationRepo, sportsRepo, notifier)
+	bookingService := services.NewBookingService(bookingRepo, turfRepo, teamRepo, savedSearchService)
+	matchService := services.NewMatchService(matchRepo, bookingRepo, sportsRepo, locationRepo, notifier, cfg.Matches.DefaultCutoff)
+	go matchService.RunCutoffSweeper(ctx, cfg.Matches.SweepInterval)
+	paymentService := services.NewPaymentService(paymentRepo, bookingService, pricingRepo, userRepo, paymentProvider, notifier, cfg.Payments)
+	go paymentService.RunSweeper(ctx, cfg.Payments.SweepInterval)
+	tournamentService := services.NewTournamentService(tournamentRepo, turfRepo, sportsRepo, teamRepo, bookingRepo, calendarRepo)
+	statsService := services.NewStatsService(resultRepo, matchRepo, userRepo, sportsRepo, leaderboards)
+	matchmakingService := services.NewMatchmakingService(ratingRepo, userRepo, matchRepo, matchService)
+	reviewService := services.NewReviewService(reviewRepo, turfRepo, bookingRepo, imageUploader)
+	favouriteService := services.NewFavouriteService(favouriteRepo, turfRepo, bookingRepo, calendarRepo)
+	seriesService := services.NewBookingSeriesService(seriesRepo, pricingRepo, teamRepo, bookingService, cfg.Payments)
+	pricingService := services.NewPricingService(pricingRepo, turfRepo)
+	calendarService := services.NewCalendarService(calendarRepo, turfRepo, notifier)
+
+	//! Health checks - Postgres is required to serve traffic; the rest degrade gracefully
+	checker := health.NewChecker(cfg.Server.HealthCheckTimeout)
+	checker.Register("postgres", true, func(ctx context.Context) error { return database.Ping(ctx, db) })
+	checker.Register("redis", false, redisClient.Check)
+	checker.Register("queue", false, func(ctx context.Context) error { return queue.Ping(ctx, sqsClient, queueURL) })
+	checker.Register("storage", false, imageUploader.Ping)
+
+	// Per-client, per-route rate limiting shared across instances via Redis
+	limiter, err := ratelimit.NewLimiter(redisClient, cfg.RateLimit.Allowlist)
+	if err != nil {
+		logging.Fatal("Rate limiter init failed", logging.Err(err))
+	}
 
-	api := router.Group("/api/v1")
+	router, err := NewRouter(cfg, Dependencies{
+		Checker:            checker,
+		Limiter:            limiter,
+		Facebook:           facebook,
+		UserService:        userService,
+		SportsService:      sportsService,
+		TurfService:        turfService,
+		TeamService:        teamService,
+		BookingService:     bookingService,
+		MatchService:       matchService,
+		PaymentService:     paymentService,
+		TournamentService:  tournamentService,
+		StatsService:       statsService,
+		MatchmakingService: matchmakingService,
+		ReviewService:      reviewService,
+		FavouriteService:   favouriteService,
+		SavedSearchService: savedSearchService,
+		SeriesService:      seriesService,
+		PricingService:     pricingService,
+		CalendarService:    calendarService,
+	})
+	if err != nil {
+		logging.Fatal("Router init failed", logging.Err(err))
+	}
 
-	routes.SetupUserRoutes(api, userService)
-	routes.SetupSportsRoutes(api, sportsService)
-	routes.SetupTurfRoutes(api, turfService)
+	// Requests derive from requestCtx, which is cancelled if they outlive the shutdown
+	// grace period so their queries and uploads are abandoned rather than leaked.
+	requestCtx, cancelRequests := context.WithCancel(logging.WithLogger(context.Background(), logger))
+	defer cancelRequests()
 
 	server := &http.Server{
-		Addr:         ":" + cfg.ServerPort,
+		Addr:         ":" + cfg.Server.Port,
 		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		BaseContext:  func(net.Listener) context.Context { return requestCtx },
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
 	// Run server in goroutine so we can block on shutdown
 	go func() {
-		log.Printf("Server running on port %s", cfg.ServerPort)
+		logger.Info("Server running", slog.String("port", cfg.Server.Port), slog.String("environment", cfg.Environment))
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server failed: %v", err)
+			logging.Fatal("Server failed", logging.Err(err))
 		}
 	}()
 
@@ -108,25 +204,39 @@ func StartServer() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	logger.Info("Shutting down server...")
+
+	// Fail readiness first so load balancers drain traffic before connections close
+	checker.StartDraining()
+	time.Sleep(cfg.Server.DrainDelay)
 
 	// Cancel context to stop SQS worker pool and other background work
 	cancel()
 
-	// Give server up to 30 seconds to finish in-flight requests
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Give server time to finish in-flight requests
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer shutdownCancel()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		logger.Error("Server forced to shutdown, cancelling in-flight requests", logging.Err(err))
+		cancelRequests()
 	}
 
 	// Close database connection
 	if sqlDB, err := db.DB(); err == nil {
 		if err := sqlDB.Close(); err != nil {
-			log.Printf("Error closing database: %v", err)
+			logger.Error("Error closing database", logging.Err(err))
 		}
 	}
 
-	log.Println("Server exited gracefully")
+	if err := redisClient.Close(); err != nil {
+		logger.Error("Error closing Redis", logging.Err(err))
+	}
+
+	// Flush buffered spans before exiting
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("Error flushing traces", logging.Err(err))
+	}
+
+	logger.Info("Server exited gracefully")
 }
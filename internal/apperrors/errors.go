@@ -0,0 +1,234 @@
+// Package apperrors defines the typed domain errors shared by repositories, services
+// and handlers. Every error that reaches a client is rendered from an *Error by the
+// error middleware, so the HTTP status and JSON envelope stay consistent.
+package apperrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Code is a stable, machine-readable error identifier returned to clients.
+type Code string
+
+const (
+	CodeValidation   Code = "validation_failed"
+	CodeUnauthorized Code = "unauthorized"
+	CodeForbidden    Code = "forbidden"
+	CodeNotFound     Code = "not_found"
+	CodeConflict     Code = "conflict"
+	CodeRateLimited  Code = "rate_limited"
+	CodeDeclined     Code = "payment_declined"
+	CodeInternal     Code = "internal_error"
+	CodeUnavailable  Code = "service_unavailable"
+	CodeTimeout      Code = "timeout"
+	CodeCanceled     Code = "request_cancelled"
+)
+
+// StatusClientClosedRequest is the non-standard status (popularised by nginx) recorded
+// when the client goes away before the response is written.
+const StatusClientClosedRequest = 499
+
+var statusByCode = map[Code]int{
+	CodeValidation:   http.StatusBadRequest,
+	CodeUnauthorized: http.StatusUnauthorized,
+	CodeForbidden:    http.StatusForbidden,
+	CodeNotFound:     http.StatusNotFound,
+	CodeConflict:     http.StatusConflict,
+	CodeRateLimited:  http.StatusTooManyRequests,
+	CodeDeclined:     http.StatusPaymentRequired,
+	CodeInternal:     http.StatusInternalServerError,
+	CodeUnavailable:  http.StatusServiceUnavailable,
+	CodeTimeout:      http.StatusGatewayTimeout,
+	CodeCanceled:     StatusClientClosedRequest,
+}
+
+// Sentinels for errors.Is checks, e.g. errors.Is(err, apperrors.ErrNotFound).
+var (
+	ErrValidation   = &Error{Code: CodeValidation}
+	ErrUnauthorized = &Error{Code: CodeUnauthorized}
+	ErrForbidden    = &Error{Code: CodeForbidden}
+	ErrNotFound     = &Error{Code: CodeNotFound}
+	ErrConflict     = &Error{Code: CodeConflict}
+	ErrRateLimited  = &Error{Code: CodeRateLimited}
+	ErrDeclined     = &Error{Code: CodeDeclined}
+	ErrInternal     = &Error{Code: CodeInternal}
+	ErrUnavailable  = &Error{Code: CodeUnavailable}
+	ErrTimeout      = &Error{Code: CodeTimeout}
+	ErrCanceled     = &Error{Code: CodeCanceled}
+)
+
+// FieldError describes a problem with a single request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is a typed domain error. Message is safe to show to clients; Err is the
+// underlying cause and is only logged.
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []FieldError
+	Err     error
+
+	// RetryAfter, when set, is sent as a Retry-After header (rate limits, lockouts).
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil && e.Message != "" {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return string(e.Code)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an *Error with the same code, so the sentinels above
+// match any error of that kind.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Status returns the HTTP status code for the error.
+func (e *Error) Status() int {
+	if status, ok := statusByCode[e.Code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// WithCause returns a copy of the error with an underlying error attached for logging.
+// The receiver is left alone, so a shared error can be decorated per request.
+func (e *Error) WithCause(err error) *Error {
+	c := *e
+	c.Err = err
+	return &c
+}
+
+// WithRetryAfter returns a copy of the error telling the client how long to wait
+// before retrying.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	c := *e
+	c.RetryAfter = d
+	return &c
+}
+
+// Field builds a FieldError.
+func Field(field, message string) FieldError {
+	return FieldError{Field: field, Message: message}
+}
+
+// Validation reports invalid input, optionally with per-field details.
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Code: CodeValidation, Message: message, Fields: fields}
+}
+
+// InvalidField reports a single invalid field; the message doubles as the field detail.
+func InvalidField(field, message string) *Error {
+	return Validation(message, Field(field, message))
+}
+
+// Unauthorized reports missing or invalid credentials.
+func Unauthorized(message string) *Error {
+	return &Error{Code: CodeUnauthorized, Message: message}
+}
+
+// Forbidden reports an authenticated caller that may not perform the action.
+func Forbidden(message string) *Error {
+	return &Error{Code: CodeForbidden, Message: message}
+}
+
+// NotFound reports a missing resource, e.g. NotFound("turf") -> "turf not found".
+func NotFound(resource string) *Error {
+	return &Error{Code: CodeNotFound, Message: resource + " not found"}
+}
+
+// Conflict reports a request that clashes with existing state.
+func Conflict(message string) *Error {
+	return &Error{Code: CodeConflict, Message: message}
+}
+
+// RateLimited reports a caller that exceeded a rate limit or lockout policy.
+func RateLimited(message string) *Error {
+	return &Error{Code: CodeRateLimited, Message: message}
+}
+
+// Declined reports a payment the provider refused; retrying with another payment
+// method may succeed.
+func Declined(message string) *Error {
+	return &Error{Code: CodeDeclined, Message: message}
+}
+
+// Internal wraps an unexpected error; clients only see a generic message.
+func Internal(err error) *Error {
+	return &Error{Code: CodeInternal, Message: "internal server error", Err: err}
+}
+
+// Unavailable reports that a dependency the request needs is temporarily down.
+func Unavailable(message string) *Error {
+	return &Error{Code: CodeUnavailable, Message: message}
+}
+
+// Timeout reports an operation that did not finish within its deadline.
+func Timeout(message string) *Error {
+	return &Error{Code: CodeTimeout, Message: message}
+}
+
+// FromContext converts a context error into a domain error: deadline expiry becomes
+// Timeout and cancellation becomes Canceled. Other errors pass through.
+func FromContext(err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return Timeout("the request took too long to complete").WithCause(err)
+	case errors.Is(err, context.Canceled):
+		return &Error{Code: CodeCanceled, Message: "request cancelled", Err: err}
+	default:
+		return err
+	}
+}
+
+// FromDB converts database errors into domain errors: gorm.ErrRecordNotFound becomes
+// NotFound(resource) and duplicate keys become Conflict. Other errors pass through.
+func FromDB(err error, resource string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NotFound(resource).WithCause(err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return Conflict(resource + " already exists").WithCause(err)
+	default:
+		return err
+	}
+}
+
+// From returns err as an *Error, treating anything untyped as an internal error.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NotFound("resource").WithCause(err)
+	}
+	if ctxErr := FromContext(err); ctxErr != err {
+		return ctxErr.(*Error)
+	}
+	return Internal(err)
+}
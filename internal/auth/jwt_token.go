@@ -13,6 +13,7 @@ type UserClaims struct {
 	UserID uuid.UUID `json:"user_id"`
 	Email  string    `json:"email"`
 	Name   string    `json:"name"`
+	Role   string    `json:"role"`
 
 	jwt.RegisteredClaims
 }
@@ -21,15 +22,18 @@ func GenerateJWT(
 	userID uuid.UUID,
 	email string,
 	name string,
+	role string,
 	secret string,
+	ttl time.Duration,
 ) (string, error) {
 
 	claims := UserClaims{
 		UserID: userID,
 		Email:  email,
 		Name:   name,
+		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
@@ -1,14 +1,22 @@
 package auth
 
 import (
-	"errors"
+	"sync"
 
+	"github.com/musishere/sportsApp/internal/apperrors"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// dummyHash is compared against when an account does not exist, so a login for an
+// unknown email costs the same bcrypt work as a wrong password.
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("timing-equaliser"), bcrypt.DefaultCost)
+	return hash
+})
+
 func HashPassword(password string) (string, error) {
 	if len(password) < 8 {
-		return "", errors.New("password must be at least 8 characters")
+		return "", apperrors.InvalidField("password", "password must be at least 8 characters")
 	}
 
 	hashedBytes, err := bcrypt.GenerateFromPassword(
@@ -28,3 +36,8 @@ func VerifyPassword(hashedPassword, plainPassword string) error {
 		[]byte(plainPassword),
 	)
 }
+
+// VerifyDummyPassword burns the same time as VerifyPassword for a non-existent account.
+func VerifyDummyPassword(plainPassword string) {
+	_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(plainPassword))
+}
@@ -1,27 +1,109 @@
+// Package cache owns the Redis connection. The client is built from config and injected
+// into its consumers; a background monitor tracks availability so callers can degrade
+// gracefully instead of failing when Redis is down.
 package cache
 
 import (
 	"context"
-	"log"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/musishere/sportsApp/config"
 )
 
-var Rdb *redis.Client
-var Ctx = context.Background()
+// Client wraps a standalone, sentinel or cluster Redis client with availability tracking.
+type Client struct {
+	redis.UniversalClient
 
-func ConnectRedis() {
-	Rdb = redis.NewClient(&redis.Options{
-		Addr:     "localhost:6379", // Redis server address
-		Password: "",               // no password for local
-		DB:       0,                // default DB
-	})
+	available     atomic.Bool
+	checkInterval time.Duration
+	checkTimeout  time.Duration
+}
+
+// NewClient builds a Redis client for the configured mode. It does not require Redis to
+// be reachable: connections are dialled lazily and re-established by the pool, and the
+// initial state is determined by the first Check.
+func NewClient(cfg config.RedisConfig) (*Client, error) {
+	opts := &redis.UniversalOptions{
+		Addrs:            cfg.Addrs,
+		MasterName:       cfg.MasterName,
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		SentinelPassword: cfg.SentinelPassword,
+		DB:               cfg.DB,
+		PoolSize:         cfg.PoolSize,
+		MinIdleConns:     cfg.MinIdleConns,
+		MaxRetries:       cfg.MaxRetries,
+		DialTimeout:      cfg.DialTimeout,
+		ReadTimeout:      cfg.ReadTimeout,
+		WriteTimeout:     cfg.WriteTimeout,
+	}
+	if cfg.TLS {
+		opts.TLSConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			ServerName:         cfg.TLSServerName,
+			InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+		}
+	}
 
-	// Test connection
-	_, err := Rdb.Ping(Ctx).Result()
-	if err != nil {
-		log.Fatalf("Failed to connect to Redis: %v", err)
+	var rdb redis.UniversalClient
+	switch cfg.Mode {
+	case config.RedisStandalone:
+		rdb = redis.NewClient(opts.Simple())
+	case config.RedisSentinel:
+		rdb = redis.NewFailoverClient(opts.Failover())
+	case config.RedisCluster:
+		rdb = redis.NewClusterClient(opts.Cluster())
+	default:
+		return nil, fmt.Errorf("unsupported redis mode %q", cfg.Mode)
 	}
 
-	log.Println("Connected to Redis successfully")
+	rdb.AddHook(newTracingHook())
+
+	return &Client{
+		UniversalClient: rdb,
+		checkInterval:   cfg.HealthCheckInterval,
+		checkTimeout:    cfg.DialTimeout,
+	}, nil
+}
+
+// Available reports whether the last health check succeeded.
+func (c *Client) Available() bool {
+	return c.available.Load()
+}
+
+// Check pings Redis and records the result, logging when availability changes.
+func (c *Client) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.checkTimeout)
+	defer cancel()
+
+	err := c.Ping(ctx).Err()
+	was := c.available.Swap(err == nil)
+	switch {
+	case err != nil && was:
+		slog.Warn("Redis unavailable, running in degraded mode", slog.Any("error", err))
+	case err == nil && !was:
+		slog.Info("Connected to Redis successfully")
+	}
+	return err
+}
+
+// Monitor re-checks Redis every health check interval until ctx is cancelled, so the
+// client recovers from degraded mode once Redis comes back.
+func (c *Client) Monitor(ctx context.Context) {
+	ticker := time.NewTicker(c.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = c.Check(ctx)
+		}
+	}
 }
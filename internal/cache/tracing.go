@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/musishere/sportsApp/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingHook wraps Redis commands (and pipelines) in client spans when they run as part
+// of a traced operation; background health pings do not start traces of their own. Only
+// command names are recorded, never arguments, so OTP values stay out of traces.
+type tracingHook struct {
+	tracer trace.Tracer
+}
+
+func newTracingHook() tracingHook {
+	return tracingHook{tracer: tracing.Tracer("cache")}
+}
+
+var _ redis.Hook = tracingHook{}
+
+func (h tracingHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, nil
+	}
+	ctx, _ = h.tracer.Start(ctx, "redis "+cmd.Name(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "redis"),
+			attribute.String("db.operation", cmd.Name()),
+		),
+	)
+	return ctx, nil
+}
+
+func (h tracingHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	span := trace.SpanFromContext(ctx)
+	recordCmdError(span, cmd.Err())
+	span.End()
+	return nil
+}
+
+func (h tracingHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, nil
+	}
+	names := make([]string, len(cmds))
+	for i, cmd := range cmds {
+		names[i] = cmd.Name()
+	}
+	ctx, _ = h.tracer.Start(ctx, "redis pipeline",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "redis"),
+			attribute.String("db.operation", strings.Join(names, " ")),
+			attribute.Int("db.redis.num_cmd", len(cmds)),
+		),
+	)
+	return ctx, nil
+}
+
+func (h tracingHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	span := trace.SpanFromContext(ctx)
+	for _, cmd := range cmds {
+		if err := cmd.Err(); err != nil && !errors.Is(err, redis.Nil) {
+			recordCmdError(span, err)
+			break
+		}
+	}
+	span.End()
+	return nil
+}
+
+// recordCmdError marks the span failed; a missing key (redis.Nil) is not a failure.
+func recordCmdError(span trace.Span, err error) {
+	if errors.Is(err, redis.Nil) {
+		return
+	}
+	tracing.RecordError(span, err)
+}
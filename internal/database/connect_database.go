@@ -1,53 +1,57 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"time"
 
 	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/logging"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
 )
 
-func ConnectDatabase(cfg *config.Config) *gorm.DB {
-	if cfg.DBPort == "" {
-		cfg.DBPort = "5432"
-	}
-
-	sslMode := "disable"
-
+func ConnectDatabase(cfg config.DatabaseConfig) *gorm.DB {
 	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBPass, cfg.DBName, sslMode,
-	)
-
-	newLogger := logger.New(
-		log.New(log.Writer(), "\r\n", log.LstdFlags),
-		logger.Config{
-			SlowThreshold: time.Second,
-			LogLevel:      logger.Error, // only log errors
-			Colorful:      false,
-		},
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode,
 	)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: newLogger,
+		// Failed and slow (>1s) queries are logged with the request's logger.
+		Logger: logging.NewGormLogger(time.Second),
+		// Translate driver errors (e.g. unique violations) into gorm.ErrDuplicatedKey.
+		TranslateError: true,
 	})
 	if err != nil {
-		log.Fatal("failed to connect to database:", err)
+		logging.Fatal("failed to connect to database", logging.Err(err))
+	}
+
+	// Trace every query; statements are recorded with placeholders, never bound values.
+	if err := db.Use(gormtracing.NewPlugin(gormtracing.WithoutQueryVariables(), gormtracing.WithoutMetrics())); err != nil {
+		logging.Fatal("failed to install database tracing", logging.Err(err))
 	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
-		log.Fatal("failed to get sql.DB from gorm.DB:", err)
+		logging.Fatal("failed to get sql.DB from gorm.DB", logging.Err(err))
 	}
 
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(50)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
-	log.Println("Database connected successfully")
+	slog.Info("Database connected successfully")
 	return db
 }
+
+// Ping checks that a connection from the pool can reach Postgres.
+func Ping(ctx context.Context, db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// Audience decides how much of a resource a caller may see. Every response type has a
+// public projection; owners additionally see their own private fields, and admins see
+// operational fields such as lockout state.
+type Audience int
+
+const (
+	AudiencePublic Audience = iota
+	AudienceOwner
+	AudienceAdmin
+)
+
+// AudienceFor classifies a caller against the owner of a resource. viewerID is
+// uuid.Nil for anonymous callers.
+func AudienceFor(viewerID uuid.UUID, viewerRole string, ownerID uuid.UUID) Audience {
+	switch {
+	case viewerRole == models.RoleAdmin:
+		return AudienceAdmin
+	case viewerID != uuid.Nil && viewerID == ownerID:
+		return AudienceOwner
+	default:
+		return AudiencePublic
+	}
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// Booking is a reserved field. Team is set when a team is the booking party, and
+// SeriesID when the booking is an occurrence of a recurring series. ClosureID flags a
+// booking the turf has since closed its field for.
+type Booking struct {
+	ID          uuid.UUID  `json:"id"`
+	Turf        TurfRef    `json:"turf"`
+	FieldNumber int        `json:"fieldNumber"`
+	StartsAt    time.Time  `json:"startsAt"`
+	EndsAt      time.Time  `json:"endsAt"`
+	BookedByID  uuid.UUID  `json:"bookedById"`
+	Team        *TeamRef   `json:"team,omitempty"`
+	SeriesID    *uuid.UUID `json:"seriesId,omitempty"`
+	ClosureID   *uuid.UUID `json:"closureId,omitempty"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// TurfRef names a turf from another resource.
+type TurfRef struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+func NewBooking(b *models.Booking) Booking {
+	booking := Booking{
+		ID:          b.ID,
+		Turf:        TurfRef{ID: b.TurfID, Name: b.Turf.Name},
+		FieldNumber: b.FieldNumber,
+		StartsAt:    b.StartsAt,
+		EndsAt:      b.EndsAt,
+		BookedByID:  b.BookedByID,
+		SeriesID:    b.SeriesID,
+		ClosureID:   b.ClosureID,
+		Status:      b.Status,
+		CreatedAt:   b.CreatedAt,
+	}
+	if b.TeamID != nil {
+		booking.Team = &TeamRef{ID: *b.TeamID}
+		if b.Team != nil {
+			booking.Team.Name = b.Team.Name
+		}
+	}
+	return booking
+}
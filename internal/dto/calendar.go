@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// OpeningHours are a turf's hours on one weekday (0 is Sunday) where they differ from
+// its startTime and endTime.
+type OpeningHours struct {
+	Weekday  int  `json:"weekday"`
+	OpensAt  int  `json:"opensAt"`
+	ClosesAt int  `json:"closesAt"`
+	Closed   bool `json:"closed"`
+}
+
+func NewOpeningHours(h *models.TurfHours) OpeningHours {
+	return OpeningHours{
+		Weekday:  h.Weekday,
+		OpensAt:  h.OpensAt,
+		ClosesAt: h.ClosesAt,
+		Closed:   h.Closed,
+	}
+}
+
+// TurfClosure blocks one field, or the whole turf when FieldNumber is null, for a time
+// range. AffectedBookings, the bookings it flagged, is only shown to the turf owner.
+type TurfClosure struct {
+	ID               uuid.UUID `json:"id"`
+	Turf             TurfRef   `json:"turf"`
+	FieldNumber      *int      `json:"fieldNumber"`
+	Reason           string    `json:"reason"`
+	Note             string    `json:"note"`
+	StartsAt         time.Time `json:"startsAt"`
+	EndsAt           time.Time `json:"endsAt"`
+	AffectedBookings []Booking `json:"affectedBookings,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+func NewTurfClosure(c *models.TurfClosure) TurfClosure {
+	return TurfClosure{
+		ID:          c.ID,
+		Turf:        TurfRef{ID: c.TurfID, Name: c.Turf.Name},
+		FieldNumber: c.FieldNumber,
+		Reason:      c.Reason,
+		Note:        c.Note,
+		StartsAt:    c.StartsAt,
+		EndsAt:      c.EndsAt,
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+// NewOwnedTurfClosure projects a closure for the turf's owner, with the bookings it
+// flagged.
+func NewOwnedTurfClosure(c *models.TurfClosure) TurfClosure {
+	closure := NewTurfClosure(c)
+	closure.AffectedBookings = make([]Booking, 0, len(c.Bookings))
+	for i := range c.Bookings {
+		b := &c.Bookings[i]
+		b.Turf = c.Turf
+		closure.AffectedBookings = append(closure.AffectedBookings, NewBooking(b))
+	}
+	return closure
+}
@@ -0,0 +1,52 @@
+// Package dto defines the API v2 response bodies. They are decoupled from the GORM
+// models so storage changes never leak into the wire format, and every response is
+// wrapped in the same envelope.
+package dto
+
+// Envelope wraps a single resource: {"data": {...}}.
+type Envelope[T any] struct {
+	Data T `json:"data"`
+}
+
+// List wraps a collection: {"data": [...], "meta": {...}}. Data is never null.
+type List[T any] struct {
+	Data []T  `json:"data"`
+	Meta Meta `json:"meta"`
+}
+
+// Meta describes a collection. Page and PageSize are set only for paginated lists.
+type Meta struct {
+	Total    int64 `json:"total"`
+	Page     int   `json:"page,omitempty"`
+	PageSize int   `json:"pageSize,omitempty"`
+}
+
+// Data wraps v in an Envelope.
+func Data[T any](v T) Envelope[T] {
+	return Envelope[T]{Data: v}
+}
+
+// Collection wraps a complete, unpaginated collection.
+func Collection[T any](items []T) List[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return List[T]{Data: items, Meta: Meta{Total: int64(len(items))}}
+}
+
+// Page wraps one page of a paginated collection.
+func Page[T any](items []T, page, pageSize int, total int64) List[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return List[T]{Data: items, Meta: Meta{Total: total, Page: page, PageSize: pageSize}}
+}
+
+// Map converts each model with fn.
+func Map[M, D any](models []M, fn func(*M) D) []D {
+	out := make([]D, len(models))
+	for i := range models {
+		out[i] = fn(&models[i])
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/services"
+)
+
+// FavouriteTurf is a favourited turf with its free slots over the next day.
+type FavouriteTurf struct {
+	Turf         Turf         `json:"turf"`
+	AddedAt      time.Time    `json:"addedAt"`
+	Availability Availability `json:"availability"`
+}
+
+// Availability counts a turf's free one-hour field slots between From and To.
+type Availability struct {
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	FreeSlots    int       `json:"freeSlots"`
+	NextFreeSlot *Slot     `json:"nextFreeSlot"`
+}
+
+type Slot struct {
+	FieldNumber int       `json:"fieldNumber"`
+	StartsAt    time.Time `json:"startsAt"`
+	EndsAt      time.Time `json:"endsAt"`
+}
+
+// SavedSearch is a slot alert. Its hours are read in the time zone of each turf.
+type SavedSearch struct {
+	ID             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	Sport          *SportRef  `json:"sport"`
+	RadiusKm       float64    `json:"radiusKm"`
+	WindowStart    time.Time  `json:"windowStart"`
+	WindowEnd      time.Time  `json:"windowEnd"`
+	FromHour       int        `json:"fromHour"`
+	ToHour         int        `json:"toHour"`
+	Active         bool       `json:"active"`
+	LastNotifiedAt *time.Time `json:"lastNotifiedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+func NewFavouriteTurf(f *services.FavouriteTurf) FavouriteTurf {
+	availability := Availability{
+		From:      f.Availability.From,
+		To:        f.Availability.To,
+		FreeSlots: f.Availability.FreeSlots,
+	}
+	if next := f.Availability.NextFree; next != nil {
+		availability.NextFreeSlot = &Slot{FieldNumber: next.FieldNumber, StartsAt: next.StartsAt, EndsAt: next.EndsAt}
+	}
+	return FavouriteTurf{
+		Turf:         NewPublicTurf(&f.Turf),
+		AddedAt:      f.AddedAt,
+		Availability: availability,
+	}
+}
+
+func NewSavedSearch(s *models.SavedSearch) SavedSearch {
+	search := SavedSearch{
+		ID:             s.ID,
+		Name:           s.Name,
+		RadiusKm:       s.RadiusKm,
+		WindowStart:    s.WindowStart,
+		WindowEnd:      s.WindowEnd,
+		FromHour:       s.FromHour,
+		ToHour:         s.ToHour,
+		Active:         s.WindowEnd.After(time.Now()),
+		LastNotifiedAt: s.LastNotifiedAt,
+		CreatedAt:      s.CreatedAt,
+	}
+	if s.Sport != nil {
+		sport := newSportRef(s.Sport)
+		search.Sport = &sport
+	}
+	return search
+}
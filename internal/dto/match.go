@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// Match is an open game as seen by anyone signed in. SpotsLeft counts the open spots
+// not yet taken; further joins go to the waitlist.
+type Match struct {
+	ID            uuid.UUID     `json:"id"`
+	Booking       MatchBooking  `json:"booking"`
+	Sport         SportRef      `json:"sport"`
+	Organizer     PublicUser    `json:"organizer"`
+	SkillLevel    string        `json:"skillLevel"`
+	PartySize     int           `json:"partySize"`
+	OpenSpots     int           `json:"openSpots"`
+	SpotsLeft     int           `json:"spotsLeft"`
+	WaitlistCount int           `json:"waitlistCount"`
+	Players       []MatchPlayer `json:"players"`
+	Notes         string        `json:"notes"`
+	CutoffAt      time.Time     `json:"cutoffAt"`
+	Status        string        `json:"status"`
+	CreatedAt     time.Time     `json:"createdAt"`
+}
+
+// MatchBooking is where and when a match is played.
+type MatchBooking struct {
+	ID          uuid.UUID `json:"id"`
+	Turf        TurfRef   `json:"turf"`
+	FieldNumber int       `json:"fieldNumber"`
+	StartsAt    time.Time `json:"startsAt"`
+	EndsAt      time.Time `json:"endsAt"`
+}
+
+type MatchPlayer struct {
+	User     PublicUser `json:"user"`
+	Status   string     `json:"status"`
+	JoinedAt time.Time  `json:"joinedAt"`
+}
+
+// NearbyMatch is a match found by a nearby search, with its distance from the searcher.
+type NearbyMatch struct {
+	Match
+	DistanceKm float64 `json:"distanceKm"`
+}
+
+func NewMatch(m *models.Match) Match {
+	players := make([]MatchPlayer, 0, len(m.Players))
+	joined, waitlisted := 0, 0
+	for _, p := range m.Players {
+		players = append(players, MatchPlayer{User: NewPublicUser(&p.User), Status: p.Status, JoinedAt: p.CreatedAt})
+		if p.Status == models.MatchPlayerJoined {
+			joined++
+		} else {
+			waitlisted++
+		}
+	}
+	return Match{
+		ID: m.ID,
+		Booking: MatchBooking{
+			ID:          m.BookingID,
+			Turf:        TurfRef{ID: m.Booking.TurfID, Name: m.Booking.Turf.Name},
+			FieldNumber: m.Booking.FieldNumber,
+			StartsAt:    m.Booking.StartsAt,
+			EndsAt:      m.Booking.EndsAt,
+		},
+		Sport:         newSportRef(&m.Sport),
+		Organizer:     NewPublicUser(&m.Organizer),
+		SkillLevel:    m.SkillLevel,
+		PartySize:     m.PartySize,
+		OpenSpots:     m.OpenSpots,
+		SpotsLeft:     max(m.OpenSpots-joined, 0),
+		WaitlistCount: waitlisted,
+		Players:       players,
+		Notes:         m.Notes,
+		CutoffAt:      m.CutoffAt,
+		Status:        m.Status,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+// NewNearbyMatch rounds the distance to ten metres.
+func NewNearbyMatch(m *models.Match, distanceKm float64) NearbyMatch {
+	return NearbyMatch{Match: NewMatch(m), DistanceKm: math.Round(distanceKm*100) / 100}
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// PaymentSplit is a booking's cost shared between its players, with who has paid.
+// Amounts are in the currency's minor unit.
+type PaymentSplit struct {
+	Booking     Booking        `json:"booking"`
+	OrganizerID uuid.UUID      `json:"organizerId"`
+	TotalAmount int64          `json:"totalAmount"`
+	PaidAmount  int64          `json:"paidAmount"`
+	Currency    string         `json:"currency"`
+	Deadline    time.Time      `json:"deadline"`
+	Status      string         `json:"status"`
+	Shares      []PaymentShare `json:"shares"`
+}
+
+type PaymentShare struct {
+	User   PublicUser `json:"user"`
+	Amount int64      `json:"amount"`
+	Paid   bool       `json:"paid"`
+	PaidAt *time.Time `json:"paidAt"`
+}
+
+func NewPaymentSplit(s *models.PaymentSplit) PaymentSplit {
+	shares := make([]PaymentShare, 0, len(s.Shares))
+	var paid int64
+	for _, share := range s.Shares {
+		shares = append(shares, PaymentShare{
+			User:   NewPublicUser(&share.User),
+			Amount: share.Amount,
+			Paid:   share.PaidAt != nil,
+			PaidAt: share.PaidAt,
+		})
+		if share.PaidAt != nil {
+			paid += share.Amount
+		}
+	}
+	return PaymentSplit{
+		Booking:     NewBooking(&s.Booking),
+		OrganizerID: s.OrganizerID,
+		TotalAmount: s.TotalAmount,
+		PaidAmount:  paid,
+		Currency:    s.Currency,
+		Deadline:    s.Deadline,
+		Status:      s.Status,
+		Shares:      shares,
+	}
+}
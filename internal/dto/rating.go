@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"math"
+	"time"
+
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/services"
+)
+
+// PlayerRating is a player's skill rating in one sport. Deviation is how uncertain the
+// rating is: high for new or long-idle players, shrinking as they play.
+type PlayerRating struct {
+	Sport        SportRef  `json:"sport"`
+	Rating       int       `json:"rating"`
+	Deviation    int       `json:"deviation"`
+	Games        int       `json:"games"`
+	LastPlayedAt time.Time `json:"lastPlayedAt"`
+}
+
+type RatedPlayer struct {
+	User      PublicUser `json:"user"`
+	Rating    int        `json:"rating"`
+	Deviation int        `json:"deviation"`
+	Games     int        `json:"games"`
+}
+
+// TeamSplit is a suggested division of a match's players into two balanced sides.
+type TeamSplit struct {
+	Home               []RatedPlayer `json:"home"`
+	Away               []RatedPlayer `json:"away"`
+	HomeRating         int           `json:"homeRating"`
+	AwayRating         int           `json:"awayRating"`
+	HomeWinProbability float64       `json:"homeWinProbability"`
+}
+
+// RecommendedMatch is a nearby match close to the searcher's rating.
+type RecommendedMatch struct {
+	NearbyMatch
+	AverageRating int `json:"averageRating"`
+	RatingGap     int `json:"ratingGap"`
+}
+
+func NewPlayerRating(r *models.PlayerRating) PlayerRating {
+	return PlayerRating{
+		Sport:        newSportRef(&r.Sport),
+		Rating:       int(math.Round(r.Rating)),
+		Deviation:    int(math.Round(r.Deviation)),
+		Games:        r.Games,
+		LastPlayedAt: r.LastPlayedAt,
+	}
+}
+
+func newRatedPlayer(p *services.RatedPlayer) RatedPlayer {
+	return RatedPlayer{
+		User:      NewPublicUser(&p.User),
+		Rating:    int(math.Round(p.Rating.Value)),
+		Deviation: int(math.Round(p.Rating.Deviation)),
+		Games:     p.Games,
+	}
+}
+
+func NewTeamSplit(s *services.TeamSplit) TeamSplit {
+	return TeamSplit{
+		Home:               Map(s.Home, newRatedPlayer),
+		Away:               Map(s.Away, newRatedPlayer),
+		HomeRating:         int(math.Round(s.HomeRating.Value)),
+		AwayRating:         int(math.Round(s.AwayRating.Value)),
+		HomeWinProbability: math.Round(s.HomeWinProbability*100) / 100,
+	}
+}
+
+func NewRecommendedMatch(m *services.RecommendedMatch) RecommendedMatch {
+	return RecommendedMatch{
+		NearbyMatch:   NewNearbyMatch(&m.Match, m.DistanceKm),
+		AverageRating: int(math.Round(m.AverageRating)),
+		RatingGap:     int(math.Round(m.RatingGap)),
+	}
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// Review is a player's rating of a turf with the owner's reply, if any.
+type Review struct {
+	ID        uuid.UUID    `json:"id"`
+	TurfID    uuid.UUID    `json:"turfId"`
+	Author    PublicUser   `json:"author"`
+	Rating    int          `json:"rating"`
+	Body      string       `json:"body"`
+	Photos    []string     `json:"photos"`
+	Reply     *ReviewReply `json:"reply"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+// ReviewReply is the turf owner's public reply to a review.
+type ReviewReply struct {
+	Body      string    `json:"body"`
+	RepliedAt time.Time `json:"repliedAt"`
+}
+
+// ModeratedReview is a review as admins see it when moderating.
+type ModeratedReview struct {
+	Review
+	Status       string `json:"status"`
+	ReportCount  int    `json:"reportCount"`
+	HiddenReason string `json:"hiddenReason,omitempty"`
+}
+
+func NewReview(r *models.TurfReview) Review {
+	review := Review{
+		ID:        r.ID,
+		TurfID:    r.TurfID,
+		Author:    NewPublicUser(&r.User),
+		Rating:    r.Rating,
+		Body:      r.Body,
+		Photos:    r.Photos,
+		CreatedAt: r.CreatedAt,
+	}
+	if review.Photos == nil {
+		review.Photos = []string{}
+	}
+	if r.RepliedAt != nil {
+		review.Reply = &ReviewReply{Body: r.OwnerReply, RepliedAt: *r.RepliedAt}
+	}
+	return review
+}
+
+func NewModeratedReview(r *models.TurfReview) ModeratedReview {
+	return ModeratedReview{
+		Review:       NewReview(r),
+		Status:       r.Status,
+		ReportCount:  r.ReportCount,
+		HiddenReason: r.HiddenReason,
+	}
+}
@@ -0,0 +1,147 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/services"
+)
+
+// BookingSeries is a recurring booking with its occurrences in date order. Amounts are
+// in the currency's minor unit; TotalAmount sums the confirmed occurrences. Times are
+// in the turf's time zone.
+type BookingSeries struct {
+	ID              uuid.UUID          `json:"id"`
+	Turf            TurfRef            `json:"turf"`
+	FieldNumber     int                `json:"fieldNumber"`
+	BookedByID      uuid.UUID          `json:"bookedById"`
+	Team            *TeamRef           `json:"team,omitempty"`
+	RRule           string             `json:"rrule"`
+	StartsAt        time.Time          `json:"startsAt"`
+	DurationMinutes int                `json:"durationMinutes"`
+	Currency        string             `json:"currency"`
+	TotalAmount     int64              `json:"totalAmount"`
+	Status          string             `json:"status"`
+	Occurrences     []SeriesOccurrence `json:"occurrences"`
+	CreatedAt       time.Time          `json:"createdAt"`
+}
+
+// SeriesOccurrence is one booking of a series.
+type SeriesOccurrence struct {
+	ID          uuid.UUID `json:"id"`
+	FieldNumber int       `json:"fieldNumber"`
+	StartsAt    time.Time `json:"startsAt"`
+	EndsAt      time.Time `json:"endsAt"`
+	Amount      int64     `json:"amount"`
+	Status      string    `json:"status"`
+}
+
+func NewBookingSeries(s *models.BookingSeries) BookingSeries {
+	zone := s.Turf.Location()
+	occurrences := make([]SeriesOccurrence, 0, len(s.Bookings))
+	var total int64
+	for _, b := range s.Bookings {
+		occurrences = append(occurrences, SeriesOccurrence{
+			ID:          b.ID,
+			FieldNumber: b.FieldNumber,
+			StartsAt:    b.StartsAt.In(zone),
+			EndsAt:      b.EndsAt.In(zone),
+			Amount:      b.Amount,
+			Status:      b.Status,
+		})
+		if b.Status == models.BookingConfirmed {
+			total += b.Amount
+		}
+	}
+	return BookingSeries{
+		ID:              s.ID,
+		Turf:            TurfRef{ID: s.TurfID, Name: s.Turf.Name},
+		FieldNumber:     s.FieldNumber,
+		BookedByID:      s.BookedByID,
+		Team:            newTeamRef(s.TeamID, s.Team),
+		RRule:           s.RRule,
+		StartsAt:        s.StartsAt.In(zone),
+		DurationMinutes: s.DurationMinutes,
+		Currency:        s.Currency,
+		TotalAmount:     total,
+		Status:          s.Status,
+		Occurrences:     occurrences,
+		CreatedAt:       s.CreatedAt,
+	}
+}
+
+// SeriesQuote prices a series before it is booked. TotalAmount sums the available
+// occurrences.
+type SeriesQuote struct {
+	Turf        TurfRef            `json:"turf"`
+	FieldNumber int                `json:"fieldNumber"`
+	RRule       string             `json:"rrule"`
+	Currency    string             `json:"currency"`
+	TotalAmount int64              `json:"totalAmount"`
+	Conflicts   int                `json:"conflicts"`
+	Occurrences []QuotedOccurrence `json:"occurrences"`
+}
+
+type QuotedOccurrence struct {
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	Amount    int64     `json:"amount"`
+	Available bool      `json:"available"`
+}
+
+func NewSeriesQuote(q *services.SeriesQuote) SeriesQuote {
+	s := q.Series
+	occurrences := make([]QuotedOccurrence, 0, len(s.Bookings))
+	var total int64
+	for i := range s.Bookings {
+		b := &s.Bookings[i]
+		available := q.Available(b)
+		occurrences = append(occurrences, QuotedOccurrence{
+			StartsAt:  b.StartsAt,
+			EndsAt:    b.EndsAt,
+			Amount:    b.Amount,
+			Available: available,
+		})
+		if available {
+			total += b.Amount
+		}
+	}
+	return SeriesQuote{
+		Turf:        TurfRef{ID: s.TurfID, Name: s.Turf.Name},
+		FieldNumber: s.FieldNumber,
+		RRule:       s.RRule,
+		Currency:    s.Currency,
+		TotalAmount: total,
+		Conflicts:   len(q.Conflicts),
+		Occurrences: occurrences,
+	}
+}
+
+// PricingRule is a turf's hourly rate, in the payments currency's minor unit, for
+// FromHour to ToHour on Days (0 is Sunday; empty means every day).
+type PricingRule struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Days       []int     `json:"days"`
+	FromHour   int       `json:"fromHour"`
+	ToHour     int       `json:"toHour"`
+	HourlyRate int64     `json:"hourlyRate"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func NewPricingRule(r *models.PricingRule) PricingRule {
+	days := r.Days
+	if days == nil {
+		days = []int{}
+	}
+	return PricingRule{
+		ID:         r.ID,
+		Name:       r.Name,
+		Days:       days,
+		FromHour:   r.FromHour,
+		ToHour:     r.ToHour,
+		HourlyRate: r.HourlyRate,
+		CreatedAt:  r.CreatedAt,
+	}
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// Sport is a catalogue entry. It has no private fields, so every audience sees the
+// same projection.
+type Sport struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	IconURL    string    `json:"iconUrl"`
+	MinPlayers int       `json:"minPlayers"`
+	MaxPlayers int       `json:"maxPlayers"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func NewSport(s *models.Sports) Sport {
+	return Sport{
+		ID:         s.ID,
+		Name:       s.Name,
+		IconURL:    s.IconUrl,
+		MinPlayers: s.MinPlayers,
+		MaxPlayers: s.MaxPlayers,
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+	}
+}
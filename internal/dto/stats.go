@@ -0,0 +1,103 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/services"
+)
+
+// MatchResult is the final score of a played match and how each player did.
+type MatchResult struct {
+	MatchID    uuid.UUID      `json:"matchId"`
+	HomeScore  int            `json:"homeScore"`
+	AwayScore  int            `json:"awayScore"`
+	Players    []PlayerResult `json:"players"`
+	RecordedAt time.Time      `json:"recordedAt"`
+}
+
+type PlayerResult struct {
+	User    PublicUser     `json:"user"`
+	Side    string         `json:"side"`
+	Outcome string         `json:"outcome"`
+	Stats   map[string]int `json:"stats"`
+}
+
+// PlayerProfile is a player's record over every sport, then per sport.
+type PlayerProfile struct {
+	User   PublicUser    `json:"user"`
+	Played int           `json:"played"`
+	Won    int           `json:"won"`
+	Drawn  int           `json:"drawn"`
+	Lost   int           `json:"lost"`
+	Sports []SportRecord `json:"sports"`
+}
+
+// SportRecord is a player's record in one sport with their stat totals, e.g. goals.
+type SportRecord struct {
+	Sport  SportRef       `json:"sport"`
+	Played int            `json:"played"`
+	Won    int            `json:"won"`
+	Drawn  int            `json:"drawn"`
+	Lost   int            `json:"lost"`
+	Points int            `json:"points"`
+	Stats  map[string]int `json:"stats"`
+}
+
+// LeaderboardEntry is a player's place on a leaderboard. Tied players share a rank.
+type LeaderboardEntry struct {
+	Rank   int        `json:"rank"`
+	User   PublicUser `json:"user"`
+	Points int        `json:"points"`
+	Played int        `json:"played"`
+}
+
+// LeaderboardRebuild reports what a rebuild wrote.
+type LeaderboardRebuild struct {
+	Boards  int `json:"boards"`
+	Entries int `json:"entries"`
+}
+
+func NewMatchResult(r *models.MatchResult) MatchResult {
+	players := make([]PlayerResult, 0, len(r.Players))
+	for _, p := range r.Players {
+		players = append(players, PlayerResult{
+			User:    NewPublicUser(&p.User),
+			Side:    p.Side,
+			Outcome: p.Outcome,
+			Stats:   p.Stats,
+		})
+	}
+	return MatchResult{
+		MatchID:    r.MatchID,
+		HomeScore:  r.HomeScore,
+		AwayScore:  r.AwayScore,
+		Players:    players,
+		RecordedAt: r.CreatedAt,
+	}
+}
+
+func NewPlayerProfile(p *services.PlayerProfile) PlayerProfile {
+	profile := PlayerProfile{User: NewPublicUser(&p.User), Sports: make([]SportRecord, 0, len(p.Sports))}
+	for _, sp := range p.Sports {
+		profile.Played += sp.Record.Played
+		profile.Won += sp.Record.Won
+		profile.Drawn += sp.Record.Drawn
+		profile.Lost += sp.Record.Lost
+		profile.Sports = append(profile.Sports, SportRecord{
+			Sport:  newSportRef(&sp.Sport),
+			Played: sp.Record.Played,
+			Won:    sp.Record.Won,
+			Drawn:  sp.Record.Drawn,
+			Lost:   sp.Record.Lost,
+			Points: sp.Record.Points,
+			Stats:  sp.Stats,
+		})
+	}
+	return profile
+}
+
+func NewLeaderboardEntry(r *services.Ranking) LeaderboardEntry {
+	return LeaderboardEntry{Rank: r.Rank, User: NewPublicUser(&r.User), Points: r.Points, Played: r.Played}
+}
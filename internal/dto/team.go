@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// Team is a squad as seen by its members. Ready reports whether it has enough players
+// to book a field for its sport.
+type Team struct {
+	ID        uuid.UUID    `json:"id"`
+	Name      string       `json:"name"`
+	Sport     SportRef     `json:"sport"`
+	CaptainID uuid.UUID    `json:"captainId"`
+	Members   []TeamMember `json:"members"`
+	Ready     bool         `json:"ready"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+// SportRef names a sport from another resource, with the squad size it allows.
+type SportRef struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	MinPlayers int       `json:"minPlayers"`
+	MaxPlayers int       `json:"maxPlayers"`
+}
+
+type TeamMember struct {
+	User     PublicUser `json:"user"`
+	Role     string     `json:"role"`
+	JoinedAt time.Time  `json:"joinedAt"`
+}
+
+// TeamRef names a team from another resource.
+type TeamRef struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// Invitation is a team invitation as seen by the captain who sent it or the invitee.
+type Invitation struct {
+	ID          uuid.UUID  `json:"id"`
+	Team        TeamRef    `json:"team"`
+	Sport       SportRef   `json:"sport"`
+	InvitedByID uuid.UUID  `json:"invitedById"`
+	Email       string     `json:"email,omitempty"`
+	Phone       string     `json:"phone,omitempty"`
+	Status      string     `json:"status"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	RespondedAt *time.Time `json:"respondedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+func NewTeam(t *models.Team) Team {
+	members := make([]TeamMember, 0, len(t.Members))
+	for _, m := range t.Members {
+		members = append(members, TeamMember{User: NewPublicUser(&m.User), Role: m.Role, JoinedAt: m.CreatedAt})
+	}
+	return Team{
+		ID:        t.ID,
+		Name:      t.Name,
+		Sport:     newSportRef(&t.Sport),
+		CaptainID: t.CaptainID,
+		Members:   members,
+		Ready:     len(members) >= t.Sport.MinPlayers,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func newSportRef(s *models.Sports) SportRef {
+	return SportRef{ID: s.ID, Name: s.Name, MinPlayers: s.MinPlayers, MaxPlayers: s.MaxPlayers}
+}
+
+func NewInvitation(i *models.TeamInvitation) Invitation {
+	return Invitation{
+		ID:          i.ID,
+		Team:        TeamRef{ID: i.TeamID, Name: i.Team.Name},
+		Sport:       newSportRef(&i.Team.Sport),
+		InvitedByID: i.InvitedByID,
+		Email:       i.Email,
+		Phone:       i.Phone,
+		Status:      i.Status,
+		ExpiresAt:   i.ExpiresAt,
+		RespondedAt: i.RespondedAt,
+		CreatedAt:   i.CreatedAt,
+	}
+}
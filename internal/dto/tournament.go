@@ -0,0 +1,190 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/services"
+)
+
+// Tournament is a competition with its registered teams. Champion is set once it is
+// completed.
+type Tournament struct {
+	ID              uuid.UUID        `json:"id"`
+	Name            string           `json:"name"`
+	Sport           SportRef         `json:"sport"`
+	Turf            TurfRef          `json:"turf"`
+	OrganizerID     uuid.UUID        `json:"organizerId"`
+	Format          string           `json:"format"`
+	Status          string           `json:"status"`
+	StartsAt        time.Time        `json:"startsAt"`
+	MatchMinutes    int              `json:"matchMinutes"`
+	BreakMinutes    int              `json:"breakMinutes"`
+	MaxTeams        int              `json:"maxTeams"`
+	GroupCount      int              `json:"groupCount"`
+	AdvancePerGroup int              `json:"advancePerGroup"`
+	Teams           []TournamentTeam `json:"teams"`
+	Champion        *TeamRef         `json:"champion"`
+	CreatedAt       time.Time        `json:"createdAt"`
+}
+
+// TournamentTeam is a registered team. Group is 0 until groups are drawn, and always
+// for formats without groups.
+type TournamentTeam struct {
+	Team  TeamRef `json:"team"`
+	Seed  int     `json:"seed"`
+	Group int     `json:"group"`
+}
+
+// Fixture is one tournament match. A knockout side is null until the team that will
+// play it is known.
+type Fixture struct {
+	ID          uuid.UUID  `json:"id"`
+	Stage       string     `json:"stage"`
+	Round       int        `json:"round"`
+	Group       int        `json:"group"`
+	Home        *TeamRef   `json:"home"`
+	Away        *TeamRef   `json:"away"`
+	HomeScore   *int       `json:"homeScore"`
+	AwayScore   *int       `json:"awayScore"`
+	WinnerID    *uuid.UUID `json:"winnerId"`
+	FieldNumber int        `json:"fieldNumber"`
+	StartsAt    time.Time  `json:"startsAt"`
+	EndsAt      time.Time  `json:"endsAt"`
+	Status      string     `json:"status"`
+}
+
+// StandingsTable ranks the teams of a league, or of one group (Group > 0).
+type StandingsTable struct {
+	Group int           `json:"group"`
+	Rows  []StandingRow `json:"rows"`
+}
+
+type StandingRow struct {
+	Position       int     `json:"position"`
+	Team           TeamRef `json:"team"`
+	Played         int     `json:"played"`
+	Won            int     `json:"won"`
+	Drawn          int     `json:"drawn"`
+	Lost           int     `json:"lost"`
+	GoalsFor       int     `json:"goalsFor"`
+	GoalsAgainst   int     `json:"goalsAgainst"`
+	GoalDifference int     `json:"goalDifference"`
+	Points         int     `json:"points"`
+}
+
+// BracketRound is one knockout round, e.g. the semi-finals.
+type BracketRound struct {
+	Round    int       `json:"round"`
+	Name     string    `json:"name"`
+	Fixtures []Fixture `json:"fixtures"`
+}
+
+func NewTournament(t *models.Tournament) Tournament {
+	teams := make([]TournamentTeam, 0, len(t.Teams))
+	for _, entry := range t.Teams {
+		teams = append(teams, TournamentTeam{
+			Team:  TeamRef{ID: entry.TeamID, Name: entry.Team.Name},
+			Seed:  entry.Seed,
+			Group: entry.GroupNumber,
+		})
+	}
+	tournament := Tournament{
+		ID:              t.ID,
+		Name:            t.Name,
+		Sport:           newSportRef(&t.Sport),
+		Turf:            TurfRef{ID: t.TurfID, Name: t.Turf.Name},
+		OrganizerID:     t.OrganizerID,
+		Format:          t.Format,
+		Status:          t.Status,
+		StartsAt:        t.StartsAt,
+		MatchMinutes:    t.MatchMinutes,
+		BreakMinutes:    t.BreakMinutes,
+		MaxTeams:        t.MaxTeams,
+		GroupCount:      t.GroupCount,
+		AdvancePerGroup: t.AdvancePerGroup,
+		Teams:           teams,
+		CreatedAt:       t.CreatedAt,
+	}
+	if t.ChampionID != nil {
+		tournament.Champion = newTeamRef(t.ChampionID, t.Champion)
+	}
+	return tournament
+}
+
+// newTeamRef names a team that may not be loaded; a nil id gives nil.
+func newTeamRef(id *uuid.UUID, team *models.Team) *TeamRef {
+	if id == nil {
+		return nil
+	}
+	ref := &TeamRef{ID: *id}
+	if team != nil {
+		ref.Name = team.Name
+	}
+	return ref
+}
+
+func NewFixture(f *models.Fixture) Fixture {
+	return Fixture{
+		ID:          f.ID,
+		Stage:       f.Stage,
+		Round:       f.Round,
+		Group:       f.GroupNumber,
+		Home:        newTeamRef(f.HomeTeamID, f.HomeTeam),
+		Away:        newTeamRef(f.AwayTeamID, f.AwayTeam),
+		HomeScore:   f.HomeScore,
+		AwayScore:   f.AwayScore,
+		WinnerID:    f.WinnerID,
+		FieldNumber: f.FieldNumber,
+		StartsAt:    f.StartsAt,
+		EndsAt:      f.EndsAt,
+		Status:      f.Status,
+	}
+}
+
+func NewStandingsTable(t *services.GroupTable) StandingsTable {
+	rows := make([]StandingRow, 0, len(t.Rows))
+	for i, st := range t.Rows {
+		rows = append(rows, StandingRow{
+			Position:       i + 1,
+			Team:           TeamRef{ID: st.Team.ID, Name: st.Team.Name},
+			Played:         st.Played,
+			Won:            st.Won,
+			Drawn:          st.Drawn,
+			Lost:           st.Lost,
+			GoalsFor:       st.GoalsFor,
+			GoalsAgainst:   st.GoalsAgainst,
+			GoalDifference: st.GoalsFor - st.GoalsAgainst,
+			Points:         st.Points,
+		})
+	}
+	return StandingsTable{Group: t.Group, Rows: rows}
+}
+
+// NewBracket groups knockout fixtures, ordered by slot, into named rounds.
+func NewBracket(fixtures []models.Fixture, rounds int) []BracketRound {
+	bracket := make([]BracketRound, rounds)
+	for r := range bracket {
+		bracket[r] = BracketRound{Round: r + 1, Name: roundName(r+1, rounds), Fixtures: []Fixture{}}
+	}
+	for i := range fixtures {
+		f := &fixtures[i]
+		bracket[f.Round-1].Fixtures = append(bracket[f.Round-1].Fixtures, NewFixture(f))
+	}
+	return bracket
+}
+
+func roundName(round, rounds int) string {
+	switch rounds - round {
+	case 0:
+		return "Final"
+	case 1:
+		return "Semi-finals"
+	case 2:
+		return "Quarter-finals"
+	default:
+		return fmt.Sprintf("Round of %d", 1<<(rounds-round+1))
+	}
+}
@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// Turf is a venue. Its owner is embedded as a profile rather than a full account.
+type Turf struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	StartTime int       `json:"startTime"`
+	EndTime   int       `json:"endTime"`
+	// TimeZone is the IANA zone the turf's hours and prices are in.
+	TimeZone   string       `json:"timeZone"`
+	NoOfFields int          `json:"noOfFields"`
+	Images     []string     `json:"images"`
+	Location   TurfLocation `json:"location"`
+	Rating     TurfRating   `json:"rating"`
+	// OpeningHours lists the weekdays whose hours differ from StartTime and EndTime.
+	OpeningHours []OpeningHours `json:"openingHours"`
+	Owner        TurfOwner      `json:"owner"`
+	CreatedAt    time.Time      `json:"createdAt"`
+	UpdatedAt    time.Time      `json:"updatedAt"`
+}
+
+// TurfLocation groups a turf's address with its coordinates. Source is "manual" for
+// an owner's pin-drop and "geocoded" when resolved from the address.
+type TurfLocation struct {
+	Address   string  `json:"address"`
+	City      string  `json:"city"`
+	State     string  `json:"state"`
+	Postcode  string  `json:"postcode"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Source    string  `json:"source"`
+}
+
+// TurfRating summarises a turf's visible reviews; Average is 0 until it has one.
+type TurfRating struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+// TurfOwner is the owner's public profile. Contact details are added only for the
+// owner themselves and for admins.
+type TurfOwner struct {
+	PublicUser
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// NewTurf projects t for audience. t.Owner may be unloaded, in which case only the
+// owner's ID is set.
+func NewTurf(t *models.Turf, audience Audience) Turf {
+	images := t.TurfImages
+	if images == nil {
+		images = []string{}
+	}
+	return Turf{
+		ID:         t.ID,
+		Name:       t.Name,
+		Status:     t.Status,
+		StartTime:  t.StartTime,
+		EndTime:    t.EndTime,
+		TimeZone:   t.TimeZone,
+		NoOfFields: t.NoOfFields,
+		Images:     images,
+		Location: TurfLocation{
+			Address:   t.Address,
+			City:      t.City,
+			State:     t.State,
+			Postcode:  t.Postcode,
+			Latitude:  t.Latitude,
+			Longitude: t.Longitude,
+			Source:    t.CoordinateSource,
+		},
+		Rating:       TurfRating{Average: math.Round(t.RatingAverage*10) / 10, Count: t.RatingCount},
+		OpeningHours: Map(t.Hours, NewOpeningHours),
+		Owner:        newTurfOwner(t, audience),
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	}
+}
+
+// NewPublicTurf projects t for anonymous callers and lists.
+func NewPublicTurf(t *models.Turf) Turf {
+	return NewTurf(t, AudiencePublic)
+}
+
+func newTurfOwner(t *models.Turf, audience Audience) TurfOwner {
+	owner := TurfOwner{PublicUser: PublicUser{ID: t.OwnerID}}
+	if t.Owner.ID != t.OwnerID {
+		return owner
+	}
+	owner.Name = t.Owner.Name
+	if audience != AudiencePublic {
+		owner.Email = t.Owner.Email
+		owner.Phone = t.Owner.Phone
+	}
+	return owner
+}
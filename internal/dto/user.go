@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// PublicUser is the profile anyone may see: never contact details, the hashed CNIC,
+// account state or location.
+type PublicUser struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// User is an account as returned to its owner.
+type User struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Email      string    `json:"email"`
+	Phone      string    `json:"phone"`
+	Gender     string    `json:"gender"`
+	Role       string    `json:"role"`
+	IsActive   bool      `json:"isActive"`
+	IsVerified bool      `json:"isVerified"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// AdminUser is an account as returned to an admin: the owner's view plus account
+// state. Whether a CNIC is on file is reported, never the hash itself.
+type AdminUser struct {
+	User
+	HasCnic             bool       `json:"hasCnic"`
+	FailedLoginAttempts int        `json:"failedLoginAttempts"`
+	LockedUntil         *time.Time `json:"lockedUntil"`
+}
+
+// Session is returned when a user signs in: the account and its bearer token, which is
+// also set as the Jwt-Token cookie.
+type Session struct {
+	User  User   `json:"user"`
+	Token string `json:"token"`
+}
+
+// LoginAttempt is one entry of a user's login history.
+type LoginAttempt struct {
+	ID            uuid.UUID `json:"id"`
+	Success       bool      `json:"success"`
+	FailureReason string    `json:"failureReason,omitempty"`
+	IP            string    `json:"ip"`
+	UserAgent     string    `json:"userAgent"`
+	Latitude      float64   `json:"latitude"`
+	Longitude     float64   `json:"longitude"`
+	DistanceKm    float64   `json:"distanceKm"`
+	Suspicious    bool      `json:"suspicious"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+func NewPublicUser(u *models.User) PublicUser {
+	return PublicUser{ID: u.ID, Name: u.Name}
+}
+
+func NewUser(u *models.User) User {
+	return User{
+		ID:         u.ID,
+		Name:       u.Name,
+		Email:      u.Email,
+		Phone:      u.Phone,
+		Gender:     u.Gender,
+		Role:       u.Role,
+		IsActive:   u.IsActive,
+		IsVerified: u.IsVerified,
+		CreatedAt:  u.CreatedAt,
+		UpdatedAt:  u.UpdatedAt,
+	}
+}
+
+func NewAdminUser(u *models.User) AdminUser {
+	return AdminUser{
+		User: NewUser(u),
+		// Signup stores a single space until a CNIC is provided.
+		HasCnic:             strings.TrimSpace(u.Cnic) != "",
+		FailedLoginAttempts: u.FailedLoginAttempts,
+		LockedUntil:         u.LockedUntil,
+	}
+}
+
+func NewSession(u *models.User, token string) Session {
+	return Session{User: NewUser(u), Token: token}
+}
+
+func NewLoginAttempt(h *models.LoginHistory) LoginAttempt {
+	return LoginAttempt{
+		ID:            h.ID,
+		Success:       h.Success,
+		FailureReason: h.FailureReason,
+		IP:            h.IP,
+		UserAgent:     h.UserAgent,
+		Latitude:      h.Latitude,
+		Longitude:     h.Longitude,
+		DistanceKm:    h.DistanceKm,
+		Suspicious:    h.Suspicious,
+		CreatedAt:     h.CreatedAt,
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/middleware"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// audienceFor classifies the caller against the owner of the resource being returned.
+func audienceFor(c *gin.Context, ownerID uuid.UUID) dto.Audience {
+	claims, ok := middleware.CurrentUser(c)
+	if !ok {
+		return dto.AudiencePublic
+	}
+	return dto.AudienceFor(claims.UserID, claims.Role, ownerID)
+}
+
+// callerID returns the signed-in user's ID, or uuid.Nil for anonymous callers. Routes
+// that need a caller are registered behind middleware.RequireAuth.
+func callerID(c *gin.Context) uuid.UUID {
+	if claims, ok := middleware.CurrentUser(c); ok {
+		return claims.UserID
+	}
+	return uuid.Nil
+}
+
+// requireAdmin aborts with 403 unless the caller is an admin, and reports whether
+// they are.
+func requireAdmin(c *gin.Context) bool {
+	if claims, ok := middleware.CurrentUser(c); !ok || claims.Role != models.RoleAdmin {
+		abortWithError(c, apperrors.Forbidden("admin role required"))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// BookingHandler serves field bookings. Every route requires a signed-in caller.
+type BookingHandler struct {
+	bookingService *services.BookingService
+}
+
+func NewBookingHandler(bookingService *services.BookingService) *BookingHandler {
+	return &BookingHandler{bookingService: bookingService}
+}
+
+// CreateBooking reserves a field for the caller or for a team they captain.
+func (h *BookingHandler) CreateBooking(c *gin.Context) {
+	var req types.CreateBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	booking, err := h.bookingService.CreateBooking(c.Request.Context(), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/bookings/"+booking.ID.String())
+	c.JSON(http.StatusCreated, dto.Data(dto.NewBooking(booking)))
+}
+
+// ListMyBookings lists the caller's own and team bookings, soonest first.
+func (h *BookingHandler) ListMyBookings(c *gin.Context) {
+	bookings, err := h.bookingService.ListBookings(c.Request.Context(), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(bookings, dto.NewBooking)))
+}
+
+func (h *BookingHandler) GetBooking(c *gin.Context) {
+	booking, err := h.bookingService.GetBooking(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewBooking(booking)))
+}
+
+// CancelBooking frees the field and returns the cancelled booking.
+func (h *BookingHandler) CancelBooking(c *gin.Context) {
+	booking, err := h.bookingService.CancelBooking(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewBooking(booking)))
+}
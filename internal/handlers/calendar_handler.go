@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// CalendarHandler serves a turf's weekday opening hours and closures.
+type CalendarHandler struct {
+	calendarService *services.CalendarService
+}
+
+func NewCalendarHandler(calendarService *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: calendarService}
+}
+
+// SetTurfOpeningHours replaces the turf's weekday opening hours; owner only.
+func (h *CalendarHandler) SetTurfOpeningHours(c *gin.Context) {
+	var req types.SetOpeningHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	turf, err := h.calendarService.SetOpeningHours(c.Request.Context(), c.Param("id"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewTurf(turf, audienceFor(c, turf.OwnerID))))
+}
+
+// CreateTurfClosure blocks a field or the whole turf for a time range; owner only.
+// The response lists the existing bookings it flagged.
+func (h *CalendarHandler) CreateTurfClosure(c *gin.Context) {
+	var req types.CreateClosureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	closure, err := h.calendarService.CreateClosure(c.Request.Context(), c.Param("id"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/turfs/"+closure.TurfID.String()+"/closures/"+closure.ID.String())
+	c.JSON(http.StatusCreated, dto.Data(dto.NewOwnedTurfClosure(closure)))
+}
+
+// ListTurfClosures returns the turf's closures between the from and to query
+// parameters (default the next 90 days), optionally filtered by reason.
+func (h *CalendarHandler) ListTurfClosures(c *gin.Context) {
+	from, err := optionalTime(c.Query("from"), "from")
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	to, err := optionalTime(c.Query("to"), "to")
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	closures, err := h.calendarService.ListClosures(c.Request.Context(), c.Param("id"), from, to, c.Query("reason"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(closures, dto.NewTurfClosure)))
+}
+
+// GetTurfClosure returns a closure with the bookings it flagged; owner only.
+func (h *CalendarHandler) GetTurfClosure(c *gin.Context) {
+	closure, err := h.calendarService.GetClosure(c.Request.Context(), c.Param("id"), c.Param("closureId"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewOwnedTurfClosure(closure)))
+}
+
+// DeleteTurfClosure reopens the time a closure blocked; owner only.
+func (h *CalendarHandler) DeleteTurfClosure(c *gin.Context) {
+	if err := h.calendarService.DeleteClosure(c.Request.Context(), c.Param("id"), c.Param("closureId"), callerID(c)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// optionalTime parses an RFC 3339 query parameter, or returns the zero time when it
+// is empty.
+func optionalTime(raw, name string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, apperrors.InvalidField(name, name+" must be an RFC 3339 date-time")
+	}
+	return t, nil
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/middleware"
+)
+
+// setAuthCookie issues the JWT cookie using the environment's cookie settings.
+// Every endpoint that signs a user in or out goes through here.
+func setAuthCookie(c *gin.Context, cfg config.CookieConfig, token string) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     middleware.AuthCookieName,
+		Value:    token,
+		Path:     cfg.Path,
+		Domain:   cfg.Domain,
+		MaxAge:   cfg.MaxAge,
+		Secure:   cfg.Secure,
+		HttpOnly: true,
+		SameSite: cfg.SameSiteMode(),
+	})
+}
+
+// clearAuthCookie expires the JWT cookie on logout.
+func clearAuthCookie(c *gin.Context, cfg config.CookieConfig) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     middleware.AuthCookieName,
+		Value:    "",
+		Path:     cfg.Path,
+		Domain:   cfg.Domain,
+		MaxAge:   -1,
+		Secure:   cfg.Secure,
+		HttpOnly: true,
+		SameSite: cfg.SameSiteMode(),
+	})
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/musishere/sportsApp/internal/apperrors"
+)
+
+// abortWithError attaches err for the error middleware to render and stops the chain.
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// invalidRequestBody converts a binding error into a validation error with per-field details.
+func invalidRequestBody(err error) error {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return apperrors.Validation("invalid request body").WithCause(err)
+	}
+
+	fields := make([]apperrors.FieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		name := lowerFirst(fe.Field())
+		fields = append(fields, apperrors.Field(name, bindingMessage(name, fe)))
+	}
+	return apperrors.Validation("invalid request body", fields...)
+}
+
+func bindingMessage(field string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return field + " is required"
+	case "email":
+		return field + " must be a valid email"
+	case "min":
+		return fmt.Sprintf("%s must be at least %s%s", field, fe.Param(), boundUnit(fe.Kind()))
+	case "max":
+		return fmt.Sprintf("%s must be at most %s%s", field, fe.Param(), boundUnit(fe.Kind()))
+	default:
+		return fmt.Sprintf("%s failed %s validation", field, fe.Tag())
+	}
+}
+
+// boundUnit is what a min or max counts for a field of kind: characters of a string,
+// items of a list, or nothing for a number.
+func boundUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return strings.TrimSpace(string(r))
+}
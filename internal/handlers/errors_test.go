@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestBindingMessageBounds(t *testing.T) {
+	type request struct {
+		Name        string   `validate:"min=3"`
+		FieldNumber int      `validate:"min=1"`
+		RadiusKm    float64  `validate:"max=50"`
+		Days        []string `validate:"max=2"`
+	}
+	err := validator.New().Struct(request{Name: "ab", FieldNumber: 0, RadiusKm: 60, Days: []string{"a", "b", "c"}})
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected validation errors, got %v", err)
+	}
+	want := map[string]string{
+		"name":        "name must be at least 3 characters",
+		"fieldNumber": "fieldNumber must be at least 1",
+		"radiusKm":    "radiusKm must be at most 50",
+		"days":        "days must be at most 2 items",
+	}
+	for _, fe := range verrs {
+		name := lowerFirst(fe.Field())
+		if got := bindingMessage(name, fe); got != want[name] {
+			t.Errorf("%s: got %q, want %q", name, got, want[name])
+		}
+	}
+}
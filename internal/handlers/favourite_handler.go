@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// FavouriteHandler serves a player's favourite turfs and saved searches.
+type FavouriteHandler struct {
+	favouriteService   *services.FavouriteService
+	savedSearchService *services.SavedSearchService
+}
+
+func NewFavouriteHandler(favouriteService *services.FavouriteService, savedSearchService *services.SavedSearchService) *FavouriteHandler {
+	return &FavouriteHandler{favouriteService: favouriteService, savedSearchService: savedSearchService}
+}
+
+// AddFavouriteTurf favourites a turf; favouriting it again is a no-op.
+func (h *FavouriteHandler) AddFavouriteTurf(c *gin.Context) {
+	if err := h.favouriteService.AddFavourite(c.Request.Context(), callerID(c), c.Param("id")); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FavouriteHandler) RemoveFavouriteTurf(c *gin.Context) {
+	if err := h.favouriteService.RemoveFavourite(c.Request.Context(), callerID(c), c.Param("id")); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListFavouriteTurfs returns the caller's favourite turfs with their free slots over
+// the day from the from query parameter (default now). Opening hours are read in each
+// turf's time zone.
+func (h *FavouriteHandler) ListFavouriteTurfs(c *gin.Context) {
+	from := time.Now().UTC()
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			abortWithError(c, apperrors.InvalidField("from", "from must be an RFC 3339 date-time"))
+			return
+		}
+		from = parsed
+	}
+
+	favourites, err := h.favouriteService.ListFavourites(c.Request.Context(), callerID(c), from)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(favourites, dto.NewFavouriteTurf)))
+}
+
+// CreateSavedSearch saves a slot alert around the caller's saved location.
+func (h *FavouriteHandler) CreateSavedSearch(c *gin.Context) {
+	var req types.CreateSavedSearchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	search, err := h.savedSearchService.CreateSearch(c.Request.Context(), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/me/saved-searches/"+search.ID.String())
+	c.JSON(http.StatusCreated, dto.Data(dto.NewSavedSearch(search)))
+}
+
+func (h *FavouriteHandler) ListSavedSearches(c *gin.Context) {
+	searches, err := h.savedSearchService.ListSearches(c.Request.Context(), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(searches, dto.NewSavedSearch)))
+}
+
+func (h *FavouriteHandler) GetSavedSearch(c *gin.Context) {
+	search, err := h.savedSearchService.GetSearch(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewSavedSearch(search)))
+}
+
+func (h *FavouriteHandler) DeleteSavedSearch(c *gin.Context) {
+	if err := h.savedSearchService.DeleteSearch(c.Request.Context(), c.Param("id"), callerID(c)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/health"
+)
+
+// LivenessResponse is the body of a successful liveness probe.
+type LivenessResponse struct {
+	Status string `json:"status"`
+}
+
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// Liveness reports that the process is up and serving HTTP. It deliberately does not
+// check dependencies, so an outage elsewhere never gets healthy instances restarted.
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, LivenessResponse{Status: health.StatusOK})
+}
+
+// Readiness reports whether this instance should receive traffic, with the status and
+// latency of each dependency.
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	ready, report := h.checker.Ready(c.Request.Context())
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, report)
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// maxNearbyRadiusKm caps how far a nearby match search reaches.
+const maxNearbyRadiusKm = 100
+
+// MatchHandler serves open matches. Every route requires a signed-in caller.
+type MatchHandler struct {
+	matchService *services.MatchService
+}
+
+func NewMatchHandler(matchService *services.MatchService) *MatchHandler {
+	return &MatchHandler{matchService: matchService}
+}
+
+// CreateMatch opens one of the caller's bookings to other players.
+func (h *MatchHandler) CreateMatch(c *gin.Context) {
+	var req types.CreateMatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	match, err := h.matchService.CreateMatch(c.Request.Context(), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/matches/"+match.ID.String())
+	c.JSON(http.StatusCreated, dto.Data(dto.NewMatch(match)))
+}
+
+// FindNearbyMatches lists open matches around the caller's saved location, nearest first.
+func (h *MatchHandler) FindNearbyMatches(c *gin.Context) {
+	radius, err := strconv.ParseFloat(c.DefaultQuery("radiusKm", "10"), 64)
+	if err != nil || radius <= 0 || radius > maxNearbyRadiusKm {
+		abortWithError(c, apperrors.InvalidField("radiusKm", "radiusKm must be a number between 0 and 100"))
+		return
+	}
+	skill := c.Query("skillLevel")
+	switch skill {
+	case "", models.SkillAny, models.SkillBeginner, models.SkillIntermediate, models.SkillAdvanced:
+	default:
+		abortWithError(c, apperrors.InvalidField("skillLevel", "skillLevel must be one of any, beginner, intermediate, advanced"))
+		return
+	}
+
+	nearby, err := h.matchService.FindNearby(c.Request.Context(), callerID(c), types.NearbyMatchesQuery{
+		RadiusKm:   radius,
+		SportID:    c.Query("sportId"),
+		SkillLevel: skill,
+	})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	matches := make([]dto.NearbyMatch, 0, len(nearby))
+	for i := range nearby {
+		matches = append(matches, dto.NewNearbyMatch(&nearby[i].Match, nearby[i].DistanceKm))
+	}
+	c.JSON(http.StatusOK, dto.Collection(matches))
+}
+
+// ListMyMatches lists the matches the caller organises or plays in, soonest first.
+func (h *MatchHandler) ListMyMatches(c *gin.Context) {
+	matches, err := h.matchService.ListMatches(c.Request.Context(), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(matches, dto.NewMatch)))
+}
+
+func (h *MatchHandler) GetMatch(c *gin.Context) {
+	match, err := h.matchService.GetMatch(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewMatch(match)))
+}
+
+// JoinMatch takes an open spot, or a waitlist place when the match is full.
+func (h *MatchHandler) JoinMatch(c *gin.Context) {
+	match, err := h.matchService.Join(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewMatch(match)))
+}
+
+// LeaveMatch gives up the caller's spot or waitlist place.
+func (h *MatchHandler) LeaveMatch(c *gin.Context) {
+	if err := h.matchService.Leave(c.Request.Context(), c.Param("id"), callerID(c)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CancelMatch calls the match off; the booking itself is kept.
+func (h *MatchHandler) CancelMatch(c *gin.Context) {
+	match, err := h.matchService.Cancel(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewMatch(match)))
+}
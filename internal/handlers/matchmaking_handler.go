@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// maxRatingBand caps how far from the caller's rating a recommendation search reaches.
+const maxRatingBand = 1000
+
+// MatchmakingHandler serves skill ratings, balanced team splits and match
+// recommendations.
+type MatchmakingHandler struct {
+	matchmakingService *services.MatchmakingService
+}
+
+func NewMatchmakingHandler(matchmakingService *services.MatchmakingService) *MatchmakingHandler {
+	return &MatchmakingHandler{matchmakingService: matchmakingService}
+}
+
+// GetPlayerRatings lists a player's rating in each sport they have played.
+func (h *MatchmakingHandler) GetPlayerRatings(c *gin.Context) {
+	ratings, err := h.matchmakingService.Ratings(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(ratings, dto.NewPlayerRating)))
+}
+
+// SuggestMatchTeams splits a match's players into two sides of similar strength.
+func (h *MatchmakingHandler) SuggestMatchTeams(c *gin.Context) {
+	split, err := h.matchmakingService.SuggestTeams(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewTeamSplit(split)))
+}
+
+// RecommendMatches lists nearby open matches close to the caller's rating.
+func (h *MatchmakingHandler) RecommendMatches(c *gin.Context) {
+	radius, err := strconv.ParseFloat(c.DefaultQuery("radiusKm", "10"), 64)
+	if err != nil || radius <= 0 || radius > maxNearbyRadiusKm {
+		abortWithError(c, apperrors.InvalidField("radiusKm", "radiusKm must be a number between 0 and 100"))
+		return
+	}
+	band, err := strconv.ParseFloat(c.DefaultQuery("band", "200"), 64)
+	if err != nil || band < 0 || band > maxRatingBand {
+		abortWithError(c, apperrors.InvalidField("band", "band must be a number between 0 and 1000"))
+		return
+	}
+
+	recommended, err := h.matchmakingService.Recommend(c.Request.Context(), callerID(c), types.RecommendedMatchesQuery{
+		RadiusKm: radius,
+		SportID:  c.Query("sportId"),
+		Band:     band,
+	})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(recommended, dto.NewRecommendedMatch)))
+}
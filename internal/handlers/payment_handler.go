@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// PaymentHandler serves split-payment bookings. Every route requires a signed-in caller.
+type PaymentHandler struct {
+	paymentService *services.PaymentService
+}
+
+func NewPaymentHandler(paymentService *services.PaymentService) *PaymentHandler {
+	return &PaymentHandler{paymentService: paymentService}
+}
+
+// CreateSplitBooking holds a field while the caller and the participants pay their shares.
+func (h *PaymentHandler) CreateSplitBooking(c *gin.Context) {
+	var req types.CreateSplitBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	split, err := h.paymentService.CreateSplitBooking(c.Request.Context(), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/bookings/"+split.BookingID.String()+"/payments")
+	c.JSON(http.StatusCreated, dto.Data(dto.NewPaymentSplit(split)))
+}
+
+// GetBookingPayments shows each player's share and who has paid.
+func (h *PaymentHandler) GetBookingPayments(c *gin.Context) {
+	split, err := h.paymentService.GetSplit(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewPaymentSplit(split)))
+}
+
+// PayBookingShare charges the caller's share; the last payment confirms the booking.
+func (h *PaymentHandler) PayBookingShare(c *gin.Context) {
+	var req types.PayShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	split, err := h.paymentService.PayShare(c.Request.Context(), c.Param("id"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewPaymentSplit(split)))
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// maxReviewPageSize caps how many reviews one list request can return.
+const maxReviewPageSize = 50
+
+// ReviewHandler serves turf reviews, owner replies and review moderation.
+type ReviewHandler struct {
+	reviewService *services.ReviewService
+}
+
+func NewReviewHandler(reviewService *services.ReviewService) *ReviewHandler {
+	return &ReviewHandler{reviewService: reviewService}
+}
+
+// reviewPage reads the page and pageSize query parameters.
+func reviewPage(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	return max(page, 1), min(max(pageSize, 1), maxReviewPageSize)
+}
+
+// CreateTurfReview rates a turf from a multipart form with up to three optional
+// photos.
+func (h *ReviewHandler) CreateTurfReview(c *gin.Context) {
+	c.Request.ParseMultipartForm(32 << 20) // 32MB
+
+	rating, err := strconv.Atoi(c.PostForm("rating"))
+	if err != nil {
+		abortWithError(c, apperrors.InvalidField("rating", "rating is required and must be a whole number from 1 to 5"))
+		return
+	}
+	req := types.CreateReviewRequest{Rating: rating, Body: c.PostForm("body")}
+	for _, field := range []string{"photo1", "photo2", "photo3"} {
+		photo, err := readFormFile(c, field)
+		if errors.Is(err, http.ErrMissingFile) {
+			continue
+		}
+		if err != nil {
+			abortWithError(c, apperrors.InvalidField(field, field+" could not be read").WithCause(err))
+			return
+		}
+		req.Photos = append(req.Photos, types.ReviewPhoto{FileBytes: photo.data, Filename: photo.filename})
+	}
+
+	review, err := h.reviewService.CreateReview(c.Request.Context(), c.Param("id"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/turfs/"+review.TurfID.String()+"/reviews")
+	c.JSON(http.StatusCreated, dto.Data(dto.NewReview(review)))
+}
+
+// ListTurfReviews returns a page of the turf's visible reviews, newest first.
+func (h *ReviewHandler) ListTurfReviews(c *gin.Context) {
+	page, pageSize := reviewPage(c)
+	reviews, total, err := h.reviewService.ListTurfReviews(c.Request.Context(), c.Param("id"), page, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Page(dto.Map(reviews, dto.NewReview), page, pageSize, total))
+}
+
+// ReplyToReview sets the turf owner's reply to a review; owner only.
+func (h *ReviewHandler) ReplyToReview(c *gin.Context) {
+	var req types.ReplyToReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	review, err := h.reviewService.Reply(c.Request.Context(), c.Param("id"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewReview(review)))
+}
+
+// ReportReview flags a review for moderation.
+func (h *ReviewHandler) ReportReview(c *gin.Context) {
+	var req types.ReportReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	if err := h.reviewService.Report(c.Request.Context(), c.Param("id"), callerID(c), req); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListReportedReviews returns the reviews awaiting moderation, most reported first;
+// admin only.
+func (h *ReviewHandler) ListReportedReviews(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	page, pageSize := reviewPage(c)
+	reviews, total, err := h.reviewService.ListReported(c.Request.Context(), page, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Page(dto.Map(reviews, dto.NewModeratedReview), page, pageSize, total))
+}
+
+// HideReview takes a review out of the turf's listing and rating; admin only.
+func (h *ReviewHandler) HideReview(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	var req types.HideReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	review, err := h.reviewService.Hide(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewModeratedReview(review)))
+}
+
+// RestoreReview puts a hidden review back in the turf's listing and rating; admin
+// only.
+func (h *ReviewHandler) RestoreReview(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	review, err := h.reviewService.Restore(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewModeratedReview(review)))
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// SeriesHandler serves recurring booking series and the turf pricing rules that price
+// them.
+type SeriesHandler struct {
+	seriesService  *services.BookingSeriesService
+	pricingService *services.PricingService
+}
+
+func NewSeriesHandler(seriesService *services.BookingSeriesService, pricingService *services.PricingService) *SeriesHandler {
+	return &SeriesHandler{
+		seriesService:  seriesService,
+		pricingService: pricingService,
+	}
+}
+
+// CreateBookingSeries books a field on every date of a weekly recurrence rule, or
+// nothing if any date is taken.
+func (h *SeriesHandler) CreateBookingSeries(c *gin.Context) {
+	var req types.CreateBookingSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	series, err := h.seriesService.CreateSeries(c.Request.Context(), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/booking-series/"+series.ID.String())
+	c.JSON(http.StatusCreated, dto.Data(dto.NewBookingSeries(series)))
+}
+
+// QuoteBookingSeries prices a series and flags its taken dates without booking it.
+func (h *SeriesHandler) QuoteBookingSeries(c *gin.Context) {
+	var req types.CreateBookingSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	quote, err := h.seriesService.QuoteSeries(c.Request.Context(), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewSeriesQuote(quote)))
+}
+
+// ListMyBookingSeries lists the caller's own and team series, newest first.
+func (h *SeriesHandler) ListMyBookingSeries(c *gin.Context) {
+	series, err := h.seriesService.ListSeries(c.Request.Context(), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(series, dto.NewBookingSeries)))
+}
+
+func (h *SeriesHandler) GetBookingSeries(c *gin.Context) {
+	series, err := h.seriesService.GetSeries(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewBookingSeries(series)))
+}
+
+// CancelBookingSeries cancels the series and its occurrences yet to start.
+func (h *SeriesHandler) CancelBookingSeries(c *gin.Context) {
+	series, err := h.seriesService.CancelSeries(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewBookingSeries(series)))
+}
+
+func (h *SeriesHandler) CancelSeriesOccurrence(c *gin.Context) {
+	series, err := h.seriesService.CancelOccurrence(c.Request.Context(), c.Param("id"), c.Param("bookingId"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewBookingSeries(series)))
+}
+
+// RescheduleSeriesOccurrence moves one occurrence to another time or field and
+// reprices it.
+func (h *SeriesHandler) RescheduleSeriesOccurrence(c *gin.Context) {
+	var req types.RescheduleOccurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	series, err := h.seriesService.RescheduleOccurrence(c.Request.Context(), c.Param("id"), c.Param("bookingId"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewBookingSeries(series)))
+}
+
+func (h *SeriesHandler) ListPricingRules(c *gin.Context) {
+	rules, err := h.pricingService.ListRules(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(rules, dto.NewPricingRule)))
+}
+
+// CreatePricingRule adds an hourly rate to the turf; owner only.
+func (h *SeriesHandler) CreatePricingRule(c *gin.Context) {
+	var req types.CreatePricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	rule, err := h.pricingService.CreateRule(c.Request.Context(), c.Param("id"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/turfs/"+rule.TurfID.String()+"/pricing-rules")
+	c.JSON(http.StatusCreated, dto.Data(dto.NewPricingRule(rule)))
+}
+
+// DeletePricingRule removes one of the turf's rates; owner only.
+func (h *SeriesHandler) DeletePricingRule(c *gin.Context) {
+	if err := h.pricingService.DeleteRule(c.Request.Context(), c.Param("id"), c.Param("ruleId"), callerID(c)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
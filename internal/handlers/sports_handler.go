@@ -1,15 +1,14 @@
 package handlers
 
 import (
-	"errors"
 	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
 	"github.com/musishere/sportsApp/internal/services"
 	"github.com/musishere/sportsApp/types"
-	"gorm.io/gorm"
 )
 
 type SportsHandler struct {
@@ -32,21 +31,21 @@ func (s *SportsHandler) RegisterNewSports(c *gin.Context) {
 	// Get file header
 	fileHeader, err := c.FormFile("iconUrl")
 	if err != nil {
-		c.JSON(400, gin.H{"error": "iconUrl file is required"})
+		abortWithError(c, apperrors.InvalidField("iconUrl", "iconUrl file is required"))
 		return
 	}
 
 	// OPEN FILE HERE (critical fix!)
 	file, err := fileHeader.Open()
 	if err != nil {
-		c.JSON(400, gin.H{"error": "cannot read file"})
+		abortWithError(c, apperrors.InvalidField("iconUrl", "cannot read file").WithCause(err))
 		return
 	}
 	defer file.Close()
 
 	fileBytes, err := io.ReadAll(file)
 	if err != nil {
-		c.JSON(400, gin.H{"error": "cannot read file data"})
+		abortWithError(c, apperrors.InvalidField("iconUrl", "cannot read file data").WithCause(err))
 		return
 	}
 
@@ -58,22 +57,19 @@ func (s *SportsHandler) RegisterNewSports(c *gin.Context) {
 		Filename:   fileHeader.Filename,
 	}
 
-	sport, err := s.SportsService.CreateNewSport(req)
+	sport, err := s.SportsService.CreateNewSport(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
-	c.JSON(201, sport)
+	c.JSON(http.StatusCreated, sport)
 }
 
 func (s *SportsHandler) GetAllRegisteredSports(c *gin.Context) {
 
-	sports, err := s.SportsService.GetAllSports()
+	sports, err := s.SportsService.GetAllSports(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Error Finding sports",
-			"details": err.Error(),
-		})
+		abortWithError(c, err)
 		return
 	}
 
@@ -87,18 +83,13 @@ func (s *SportsHandler) GetAllRegisteredSports(c *gin.Context) {
 func (s *SportsHandler) GetRegisteredSportsByID(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Empty ID",
-		})
+		abortWithError(c, apperrors.InvalidField("id", "Empty ID"))
 		return
 	}
 
-	sports, err := s.SportsService.GetSportsByID(id)
+	sports, err := s.SportsService.GetSportsByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Not Found",
-			"details": err.Error(),
-		})
+		abortWithError(c, err)
 		return
 	}
 
@@ -111,28 +102,24 @@ func (s *SportsHandler) GetRegisteredSportsByID(c *gin.Context) {
 func (s *SportsHandler) UpdateRegisteredSports(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Empty ID"})
+		abortWithError(c, apperrors.InvalidField("id", "Empty ID"))
 		return
 	}
 
 	var req types.UpdateSportRequest
 	if err := c.ShouldBind(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		abortWithError(c, invalidRequestBody(err))
 		return
 	}
 	// At least one field must be sent
 	if req.Name == nil && req.MinPlayers == nil && req.MaxPlayers == nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "send at least one field to update: name, minPlayers, or maxPlayers"})
+		abortWithError(c, apperrors.Validation("send at least one field to update: name, minPlayers, or maxPlayers"))
 		return
 	}
 
-	sport, err := s.SportsService.UpdateSports(id, req)
+	sport, err := s.SportsService.UpdateSports(c.Request.Context(), id, req)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Not Found", "details": "sport not found"})
-			return
-		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, types.SportsResponse{Sport: sport, Message: "Sport updated successfully"})
@@ -141,18 +128,14 @@ func (s *SportsHandler) UpdateRegisteredSports(c *gin.Context) {
 func (s *SportsHandler) DeleteRegisteredSport(c *gin.Context) {
 	id := c.Param("id")
 	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Empty ID"})
+		abortWithError(c, apperrors.InvalidField("id", "Empty ID"))
 		return
 	}
 
-	err := s.SportsService.DeleteSports(id)
+	err := s.SportsService.DeleteSports(c.Request.Context(), id)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Not Found", "details": "sport not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete sport", "details": err.Error()})
+		abortWithError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Sport deleted successfully"})
+	c.JSON(http.StatusOK, MessageResponse{Message: "Sport deleted successfully"})
 }
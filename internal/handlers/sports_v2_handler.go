@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// SportsV2Handler serves the API v2 sports endpoints.
+type SportsV2Handler struct {
+	sportsService *services.SportsService
+}
+
+func NewSportsV2Handler(sportsService *services.SportsService) *SportsV2Handler {
+	return &SportsV2Handler{sportsService: sportsService}
+}
+
+// CreateSport registers a sport from a multipart form with its icon file.
+func (h *SportsV2Handler) CreateSport(c *gin.Context) {
+	c.Request.ParseMultipartForm(32 << 20) // 32MB
+
+	minPlayers, _ := strconv.Atoi(c.PostForm("minPlayers"))
+	maxPlayers, _ := strconv.Atoi(c.PostForm("maxPlayers"))
+	icon, err := readFormFile(c, "icon")
+	if err != nil {
+		abortWithError(c, apperrors.InvalidField("icon", "icon file is required").WithCause(err))
+		return
+	}
+
+	sport, err := h.sportsService.CreateNewSport(c.Request.Context(), types.CreateSportRequest{
+		Name:       c.PostForm("name"),
+		MinPlayers: minPlayers,
+		MaxPlayers: maxPlayers,
+		FileBytes:  icon.data,
+		Filename:   icon.filename,
+	})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/sports/"+sport.ID.String())
+	c.JSON(http.StatusCreated, dto.Data(dto.NewSport(sport)))
+}
+
+func (h *SportsV2Handler) ListSports(c *gin.Context) {
+	sports, err := h.sportsService.GetAllSports(c.Request.Context())
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(*sports, dto.NewSport)))
+}
+
+func (h *SportsV2Handler) GetSport(c *gin.Context) {
+	sport, err := h.sportsService.GetSportsByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewSport(sport)))
+}
+
+// UpdateSport changes only the fields sent.
+func (h *SportsV2Handler) UpdateSport(c *gin.Context) {
+	var req types.UpdateSportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+	if req.Name == nil && req.MinPlayers == nil && req.MaxPlayers == nil {
+		abortWithError(c, apperrors.Validation("send at least one field to update: name, minPlayers, or maxPlayers"))
+		return
+	}
+
+	sport, err := h.sportsService.UpdateSports(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewSport(sport)))
+}
+
+func (h *SportsV2Handler) DeleteSport(c *gin.Context) {
+	if err := h.sportsService.DeleteSports(c.Request.Context(), c.Param("id")); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
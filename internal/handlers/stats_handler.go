@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// maxLeaderboardSize caps how many players one leaderboard request returns.
+const maxLeaderboardSize = 100
+
+// StatsHandler serves match results, player profiles and leaderboards.
+type StatsHandler struct {
+	statsService *services.StatsService
+}
+
+func NewStatsHandler(statsService *services.StatsService) *StatsHandler {
+	return &StatsHandler{statsService: statsService}
+}
+
+// RecordMatchResult records the score and player stats of a finished match; organiser
+// only.
+func (h *StatsHandler) RecordMatchResult(c *gin.Context) {
+	var req types.RecordMatchResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	result, err := h.statsService.RecordResult(c.Request.Context(), c.Param("id"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/matches/"+result.MatchID.String()+"/result")
+	c.JSON(http.StatusCreated, dto.Data(dto.NewMatchResult(result)))
+}
+
+func (h *StatsHandler) GetMatchResult(c *gin.Context) {
+	result, err := h.statsService.GetResult(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewMatchResult(result)))
+}
+
+// GetPlayerStats returns a player's games played, won, drawn and lost, per sport.
+func (h *StatsHandler) GetPlayerStats(c *gin.Context) {
+	profile, err := h.statsService.Profile(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewPlayerProfile(profile)))
+}
+
+// GetLeaderboard ranks a sport's players, overall or within one turf or city.
+func (h *StatsHandler) GetLeaderboard(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	limit = min(max(limit, 1), maxLeaderboardSize)
+
+	rankings, err := h.statsService.Leaderboard(c.Request.Context(), c.Param("id"), types.LeaderboardQuery{
+		TurfID: c.Query("turfId"),
+		City:   c.Query("city"),
+		Limit:  limit,
+	})
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(rankings, dto.NewLeaderboardEntry)))
+}
+
+// RebuildLeaderboards recomputes every leaderboard from the recorded results; admins
+// only.
+func (h *StatsHandler) RebuildLeaderboards(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	boards, entries, err := h.statsService.RebuildLeaderboards(c.Request.Context())
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.LeaderboardRebuild{Boards: boards, Entries: entries}))
+}
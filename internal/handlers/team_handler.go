@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// TeamHandler serves teams and their invitations. Every route requires a signed-in
+// caller.
+type TeamHandler struct {
+	teamService *services.TeamService
+}
+
+func NewTeamHandler(teamService *services.TeamService) *TeamHandler {
+	return &TeamHandler{teamService: teamService}
+}
+
+// CreateTeam creates a team with the caller as captain.
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	var req types.CreateTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	team, err := h.teamService.CreateTeam(c.Request.Context(), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/teams/"+team.ID.String())
+	c.JSON(http.StatusCreated, dto.Data(dto.NewTeam(team)))
+}
+
+// ListMyTeams lists the teams the caller belongs to.
+func (h *TeamHandler) ListMyTeams(c *gin.Context) {
+	teams, err := h.teamService.ListTeams(c.Request.Context(), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(teams, dto.NewTeam)))
+}
+
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	team, err := h.teamService.GetTeam(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewTeam(team)))
+}
+
+// DeleteTeam disbands the team; captain only.
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	if err := h.teamService.DeleteTeam(c.Request.Context(), c.Param("id"), callerID(c)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// InviteToTeam invites someone by email or phone; captain only.
+func (h *TeamHandler) InviteToTeam(c *gin.Context) {
+	var req types.InviteToTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	invitation, err := h.teamService.Invite(c.Request.Context(), c.Param("id"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, dto.Data(dto.NewInvitation(invitation)))
+}
+
+// ListMyInvitations lists pending invitations addressed to the caller's email or phone.
+func (h *TeamHandler) ListMyInvitations(c *gin.Context) {
+	invitations, err := h.teamService.ListInvitations(c.Request.Context(), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(invitations, dto.NewInvitation)))
+}
+
+// AcceptInvitation joins the caller to the team and returns it.
+func (h *TeamHandler) AcceptInvitation(c *gin.Context) {
+	team, err := h.teamService.AcceptInvitation(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewTeam(team)))
+}
+
+func (h *TeamHandler) DeclineInvitation(c *gin.Context) {
+	invitation, err := h.teamService.DeclineInvitation(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewInvitation(invitation)))
+}
+
+// LeaveTeam removes the caller from the team.
+func (h *TeamHandler) LeaveTeam(c *gin.Context) {
+	if err := h.teamService.Leave(c.Request.Context(), c.Param("id"), callerID(c)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveTeamMember kicks a member; captain only.
+func (h *TeamHandler) RemoveTeamMember(c *gin.Context) {
+	if err := h.teamService.RemoveMember(c.Request.Context(), c.Param("id"), callerID(c), c.Param("userId")); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// maxTournamentPageSize caps how many tournaments one list request can return.
+const maxTournamentPageSize = 100
+
+// TournamentHandler serves tournaments. Reads are public; everything else requires a
+// signed-in caller.
+type TournamentHandler struct {
+	tournamentService *services.TournamentService
+}
+
+func NewTournamentHandler(tournamentService *services.TournamentService) *TournamentHandler {
+	return &TournamentHandler{tournamentService: tournamentService}
+}
+
+// CreateTournament opens registration for a tournament on one of the caller's turfs.
+func (h *TournamentHandler) CreateTournament(c *gin.Context) {
+	var req types.CreateTournamentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	tournament, err := h.tournamentService.CreateTournament(c.Request.Context(), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/tournaments/"+tournament.ID.String())
+	c.JSON(http.StatusCreated, dto.Data(dto.NewTournament(tournament)))
+}
+
+// ListTournaments returns a page of tournaments, soonest first, optionally filtered by
+// sport, turf and status.
+func (h *TournamentHandler) ListTournaments(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	page = max(page, 1)
+	pageSize = min(max(pageSize, 1), maxTournamentPageSize)
+
+	status := c.Query("status")
+	switch status {
+	case "", models.TournamentRegistration, models.TournamentScheduled, models.TournamentInProgress,
+		models.TournamentCompleted, models.TournamentCancelled:
+	default:
+		abortWithError(c, apperrors.InvalidField("status", "status must be one of registration, scheduled, in_progress, completed, cancelled"))
+		return
+	}
+
+	tournaments, total, err := h.tournamentService.ListTournaments(c.Request.Context(), types.TournamentListQuery{
+		SportID: c.Query("sportId"),
+		TurfID:  c.Query("turfId"),
+		Status:  status,
+	}, page, pageSize)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Page(dto.Map(tournaments, dto.NewTournament), page, pageSize, total))
+}
+
+func (h *TournamentHandler) GetTournament(c *gin.Context) {
+	tournament, err := h.tournamentService.GetTournament(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewTournament(tournament)))
+}
+
+// RegisterTournamentTeam enters a team the caller captains.
+func (h *TournamentHandler) RegisterTournamentTeam(c *gin.Context) {
+	var req types.RegisterTournamentTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	tournament, err := h.tournamentService.RegisterTeam(c.Request.Context(), c.Param("id"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewTournament(tournament)))
+}
+
+// WithdrawTournamentTeam takes a team out before the tournament is scheduled; its
+// captain or the organiser may do this.
+func (h *TournamentHandler) WithdrawTournamentTeam(c *gin.Context) {
+	if err := h.tournamentService.WithdrawTeam(c.Request.Context(), c.Param("id"), c.Param("teamId"), callerID(c)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ScheduleTournament closes registration, draws fixtures and books the turf for them.
+func (h *TournamentHandler) ScheduleTournament(c *gin.Context) {
+	tournament, err := h.tournamentService.Schedule(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewTournament(tournament)))
+}
+
+// CancelTournament calls the tournament off and releases its unplayed bookings.
+func (h *TournamentHandler) CancelTournament(c *gin.Context) {
+	tournament, err := h.tournamentService.Cancel(c.Request.Context(), c.Param("id"), callerID(c))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewTournament(tournament)))
+}
+
+// ListTournamentFixtures lists every fixture in kick-off order.
+func (h *TournamentHandler) ListTournamentFixtures(c *gin.Context) {
+	fixtures, err := h.tournamentService.ListFixtures(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(fixtures, dto.NewFixture)))
+}
+
+// RecordFixtureResult records a score; the organiser only. Winners advance through the
+// bracket and the tournament completes after its last fixture.
+func (h *TournamentHandler) RecordFixtureResult(c *gin.Context) {
+	var req types.FixtureResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	fixture, err := h.tournamentService.RecordResult(c.Request.Context(), c.Param("id"), c.Param("fixtureId"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewFixture(fixture)))
+}
+
+// GetTournamentStandings returns the league table, or one table per group.
+func (h *TournamentHandler) GetTournamentStandings(c *gin.Context) {
+	tables, err := h.tournamentService.Standings(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(tables, dto.NewStandingsTable)))
+}
+
+// GetTournamentBracket returns the knockout rounds, empty until they are drawn.
+func (h *TournamentHandler) GetTournamentBracket(c *gin.Context) {
+	fixtures, rounds, err := h.tournamentService.Bracket(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.NewBracket(fixtures, rounds)))
+}
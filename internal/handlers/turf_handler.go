@@ -1,16 +1,26 @@
 package handlers
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/models"
 	"github.com/musishere/sportsApp/internal/services"
 	"github.com/musishere/sportsApp/types"
 )
 
+// TurfListResponse is one page of turfs with the total across all pages.
+type TurfListResponse struct {
+	Turfs []dto.Turf `json:"turfs"`
+	Total int64      `json:"total"`
+}
+
 type TurfHandler struct {
 	turfService services.TurfService
 }
@@ -21,106 +31,152 @@ func NewTurfHandler(turfService *services.TurfService) *TurfHandler {
 	}
 }
 
-func (h *TurfHandler) RegisterTurf(c *gin.Context) {
-	c.Request.ParseMultipartForm(32 << 20) // 32MB
+// optionalFloatForm parses an optional numeric form field; a missing or empty field returns nil.
+func optionalFloatForm(c *gin.Context, field string) (*float64, error) {
+	raw := c.PostForm(field)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// formFile is an uploaded file read into memory.
+type formFile struct {
+	data     []byte
+	filename string
+}
 
-	name := c.PostForm("name")
-	startTime, _ := strconv.Atoi(c.PostForm("startTime"))
-	endTime, _ := strconv.Atoi(c.PostForm("endTime"))
-	status := c.PostForm("status")
-	noOfFields, _ := strconv.Atoi(c.PostForm("noOfFields"))
-	address := c.PostForm("address")
-	ownerID, err := uuid.Parse(c.PostForm("ownerId"))
+// readFormFile reads the multipart file field in full.
+func readFormFile(c *gin.Context, field string) (formFile, error) {
+	fileHeader, err := c.FormFile(field)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ownerId is required and must be a valid UUID"})
-		return
+		return formFile{}, err
 	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return formFile{}, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return formFile{}, err
+	}
+	return formFile{data: data, filename: fileHeader.Filename}, nil
+}
 
-	readFile := func(field string) ([]byte, string, error) {
-		fileHeader, err := c.FormFile(field)
-		if err != nil {
-			return nil, "", err
-		}
-		file, err := fileHeader.Open()
-		if err != nil {
-			return nil, "", err
-		}
-		defer file.Close()
-		data, err := io.ReadAll(file)
-		if err != nil {
-			return nil, "", err
-		}
-		return data, fileHeader.Filename, nil
+// turfForm is the multipart body accepted when registering a turf. The owner is set by
+// the caller of parseTurfForm.
+type turfForm struct {
+	name                string
+	startTime, endTime  int
+	status              string
+	noOfFields          int
+	address             string
+	latitude, longitude *float64
+	timeZone            string
+	ownerID             uuid.UUID
+	images              [3]formFile
+}
+
+func parseTurfForm(c *gin.Context) (*turfForm, error) {
+	c.Request.ParseMultipartForm(32 << 20) // 32MB
+
+	f := &turfForm{
+		name:     c.PostForm("name"),
+		status:   c.PostForm("status"),
+		address:  c.PostForm("address"),
+		timeZone: c.PostForm("timeZone"),
 	}
+	f.startTime, _ = strconv.Atoi(c.PostForm("startTime"))
+	f.endTime, _ = strconv.Atoi(c.PostForm("endTime"))
+	f.noOfFields, _ = strconv.Atoi(c.PostForm("noOfFields"))
 
-	img1, fn1, err := readFile("image1")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "image1 is required (form-data key 'image1', type: File)", "details": err.Error()})
-		return
+	var err error
+	if f.latitude, err = optionalFloatForm(c, "latitude"); err != nil {
+		return nil, apperrors.InvalidField("latitude", "latitude must be a number")
 	}
-	img2, fn2, err := readFile("image2")
+	if f.longitude, err = optionalFloatForm(c, "longitude"); err != nil {
+		return nil, apperrors.InvalidField("longitude", "longitude must be a number")
+	}
+
+	for i, field := range []string{"image1", "image2", "image3"} {
+		if f.images[i], err = readFormFile(c, field); err != nil {
+			return nil, apperrors.InvalidField(field, field+" is required (form-data key '"+field+"', type: File)").WithCause(err)
+		}
+	}
+	return f, nil
+}
+
+// createTurf registers the turf described by f.
+func createTurf(ctx context.Context, svc *services.TurfService, f *turfForm) (*models.Turf, error) {
+	return svc.CreateTurf(ctx, f.name, f.startTime, f.endTime, f.status, f.noOfFields, f.address, f.latitude, f.longitude, f.timeZone, f.ownerID,
+		f.images[0].data, f.images[1].data, f.images[2].data, f.images[0].filename, f.images[1].filename, f.images[2].filename)
+}
+
+func (h *TurfHandler) RegisterTurf(c *gin.Context) {
+	form, err := parseTurfForm(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "image2 is required (form-data key 'image2', type: File)", "details": err.Error()})
+		abortWithError(c, err)
 		return
 	}
-	img3, fn3, err := readFile("image3")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "image3 is required (form-data key 'image3', type: File)", "details": err.Error()})
+	if form.ownerID, err = uuid.Parse(c.PostForm("ownerId")); err != nil {
+		abortWithError(c, apperrors.InvalidField("ownerId", "ownerId is required and must be a valid UUID"))
 		return
 	}
 
-	turf, err := h.turfService.CreateTurf(name, startTime, endTime, status, noOfFields, address, ownerID, img1, img2, img3, fn1, fn2, fn3)
+	turf, err := createTurf(c.Request.Context(), &h.turfService, form)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, turf)
+	c.JSON(http.StatusCreated, dto.NewTurf(turf, audienceFor(c, turf.OwnerID)))
 }
 
 func (h *TurfHandler) GetRegisteredTurfs(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
-	turfs, total, err := h.turfService.GetAllTurf(page, pageSize)
+	turfs, total, err := h.turfService.GetAllTurf(c.Request.Context(), page, pageSize, "")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{
-		"turfs": turfs,
-		"total": total,
-	})
+	c.JSON(http.StatusOK, TurfListResponse{Turfs: dto.Map(turfs, dto.NewPublicTurf), Total: total})
 }
 func (h *TurfHandler) GetRegisteredTurfByID(c *gin.Context) {
 	id := c.Param("id")
-	turf, err := h.turfService.GetTurfByID(id)
+	turf, err := h.turfService.GetTurfByID(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Turf not found"})
+		abortWithError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, turf)
+	c.JSON(http.StatusOK, dto.NewTurf(turf, audienceFor(c, turf.OwnerID)))
 }
 
 func (h *TurfHandler) UpdateRegisteredTurf(c *gin.Context) {
 	id := c.Param("id")
 	var req types.UpdateTurfRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		abortWithError(c, invalidRequestBody(err))
 		return
 	}
 
-	updatedTurf, err := h.turfService.UpdateTurf(id, req)
+	updatedTurf, err := h.turfService.UpdateTurf(c.Request.Context(), id, req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, updatedTurf)
+	c.JSON(http.StatusOK, dto.NewTurf(updatedTurf, audienceFor(c, updatedTurf.OwnerID)))
 }
 
 func (h *TurfHandler) DeleteRegisteredTurf(c *gin.Context) {
 	id := c.Param("id")
-	if err := h.turfService.DeleteTurf(id); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.turfService.DeleteTurf(c.Request.Context(), id); err != nil {
+		abortWithError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Turf deleted successfully"})
+	c.JSON(http.StatusOK, MessageResponse{Message: "Turf deleted successfully"})
 }
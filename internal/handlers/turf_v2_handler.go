@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// maxTurfPageSize caps how many turfs one v2 list request can return.
+const maxTurfPageSize = 100
+
+// TurfV2Handler serves the API v2 turf endpoints.
+type TurfV2Handler struct {
+	turfService *services.TurfService
+}
+
+func NewTurfV2Handler(turfService *services.TurfService) *TurfV2Handler {
+	return &TurfV2Handler{turfService: turfService}
+}
+
+// CreateTurf registers a turf owned by the caller from a multipart form with its three
+// images.
+func (h *TurfV2Handler) CreateTurf(c *gin.Context) {
+	form, err := parseTurfForm(c)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	form.ownerID = callerID(c)
+
+	turf, err := createTurf(c.Request.Context(), h.turfService, form)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v2/turfs/"+turf.ID.String())
+	c.JSON(http.StatusCreated, dto.Data(dto.NewTurf(turf, audienceFor(c, turf.OwnerID))))
+}
+
+// ListTurfs returns a page of turfs, optionally sorted by rating or newest first. Lists
+// only ever show the owner's public profile.
+func (h *TurfV2Handler) ListTurfs(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	page = max(page, 1)
+	pageSize = min(max(pageSize, 1), maxTurfPageSize)
+
+	sort := c.Query("sort")
+	switch sort {
+	case "", types.TurfSortRating, types.TurfSortNewest:
+	default:
+		abortWithError(c, apperrors.InvalidField("sort", "sort must be one of rating, newest"))
+		return
+	}
+
+	turfs, total, err := h.turfService.GetAllTurf(c.Request.Context(), page, pageSize, sort)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Page(dto.Map(turfs, dto.NewPublicTurf), page, pageSize, total))
+}
+
+func (h *TurfV2Handler) GetTurf(c *gin.Context) {
+	turf, err := h.turfService.GetTurfByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewTurf(turf, audienceFor(c, turf.OwnerID))))
+}
+
+// UpdateTurf changes only the fields sent; owner only.
+func (h *TurfV2Handler) UpdateTurf(c *gin.Context) {
+	var req types.UpdateTurfRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	turf, err := h.turfService.UpdateOwnedTurf(c.Request.Context(), c.Param("id"), callerID(c), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewTurf(turf, audienceFor(c, turf.OwnerID))))
+}
+
+// DeleteTurf deletes a turf; owner only.
+func (h *TurfV2Handler) DeleteTurf(c *gin.Context) {
+	if err := h.turfService.DeleteOwnedTurf(c.Request.Context(), c.Param("id"), callerID(c)); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
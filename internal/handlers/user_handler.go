@@ -1,32 +1,36 @@
 package handlers
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/musishere/sportsApp/config"
-	"github.com/musishere/sportsApp/internal/auth"
-	"github.com/musishere/sportsApp/internal/helpers"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/middleware"
+	"github.com/musishere/sportsApp/internal/models"
 	"github.com/musishere/sportsApp/internal/oauth"
 	"github.com/musishere/sportsApp/internal/services"
-	"github.com/musishere/sportsApp/internal/validators"
 	"github.com/musishere/sportsApp/types"
 )
 
 type UserHandler struct {
 	userService *services.UserService
+	facebook    *oauth.Facebook
+	cookies     config.CookieConfig
 }
 
-func NewUserHandler(userService *services.UserService) *UserHandler {
+func NewUserHandler(userService *services.UserService, facebook *oauth.Facebook, cookies config.CookieConfig) *UserHandler {
 	return &UserHandler{
 		userService: userService,
+		facebook:    facebook,
+		cookies:     cookies,
 	}
 }
 
 type CurrentUserResponse struct {
-	User interface{} `json:"user"`
+	User dto.User `json:"user"`
 }
 
 // SignupUserResponse contains only the user fields returned on signup (token is in cookie).
@@ -40,22 +44,40 @@ type SignupUserResponse struct {
 }
 
 type RegisterResponse struct {
-	User SignupUserResponse `json:"user"`
+	User    SignupUserResponse `json:"user"`
+	Message string             `json:"message"`
+}
+
+type VerifyEmailResponse struct {
+	User    SignupUserResponse `json:"user"`
+	Token   string             `json:"token"`
+	Message string             `json:"message"`
 }
 
 type LoginResponse struct {
-	User  interface{} `json:"user"`
-	Token string      `json:"token"`
+	User  dto.User `json:"user"`
+	Token string   `json:"token"`
+}
+
+// LoginHistoryResponse lists the caller's recent login attempts, newest first.
+type LoginHistoryResponse struct {
+	History []models.LoginHistory `json:"history"`
+}
+
+// MessageResponse is returned by endpoints that only confirm an action.
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+func signupUser(user *models.User) SignupUserResponse {
+	return SignupUserResponse{Name: user.Name, Email: user.Email, Role: user.Role, IsActive: user.IsActive, Gender: user.Gender, Phone: user.Phone}
 }
 
 func (h *UserHandler) RegisterUser(c *gin.Context) {
 	var req types.RegisterRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Invalid request data",
-			"details": err.Error(),
-		})
+		abortWithError(c, invalidRequestBody(err))
 		return
 	}
 
@@ -63,48 +85,24 @@ func (h *UserHandler) RegisterUser(c *gin.Context) {
 		req.Cnic = " " // store space until set later
 	}
 
-	user, token, err := h.userService.Register(req)
+	user, token, err := h.userService.Register(c.Request.Context(), req)
 
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if err.Error() == "email already registered" {
-			statusCode = http.StatusConflict
-		} else {
-			var ve *validators.ValidationError
-			if errors.As(err, &ve) {
-				statusCode = http.StatusBadRequest
-			}
-		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
-	// Generate OTP
-	otp := helpers.GenerateOTP()
-	otpStr := fmt.Sprintf("%d", otp)
-
-	// Store OTP temporarily (using email as identifier for email verification)
-	if err := helpers.StoreOTP(req.Email, otpStr); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to store OTP"})
-		return
-	}
-
-	// Send OTP via email
-	if err := helpers.SendEmail(req.Email, otpStr); err != nil {
-		// Log the actual error for debugging
-		fmt.Println("Email send error:", err.Error())
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "failed to send OTP email",
-			"details": err.Error(),
-		})
+	// Generate, store and email the verification OTP
+	if err := h.userService.IssueEmailOTP(c.Request.Context(), req.Email); err != nil {
+		abortWithError(c, err)
 		return
 	}
 
 	// Set cookie and return response instructing user to verify
-	c.SetCookie("Jwt-Token", token, 3600, "/", "localhost", false, true)
-	c.JSON(http.StatusCreated, gin.H{
-		"user":    SignupUserResponse{Name: user.Name, Email: user.Email, Role: user.Role, IsActive: user.IsActive, Gender: user.Gender, Phone: user.Phone},
-		"message": "Registration successful. OTP has been sent to your email. Please verify to activate your account.",
+	setAuthCookie(c, h.cookies, token)
+	c.JSON(http.StatusCreated, RegisterResponse{
+		User:    signupUser(user),
+		Message: "Registration successful. OTP has been sent to your email. Please verify to activate your account.",
 	})
 }
 
@@ -112,26 +110,28 @@ func (h *UserHandler) VerifyEmailOtp(c *gin.Context) {
 	// Accept OTP as either number or string in JSON payload.
 	var payload map[string]interface{}
 	if err := c.ShouldBindJSON(&payload); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "email and otp are required", "details": err.Error()})
+		abortWithError(c, apperrors.Validation("email and otp are required",
+			apperrors.Field("email", "email is required"),
+			apperrors.Field("otp", "otp is required")).WithCause(err))
 		return
 	}
 
 	// extract email
 	e, ok := payload["email"]
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "email is required"})
+		abortWithError(c, apperrors.InvalidField("email", "email is required"))
 		return
 	}
 	email, ok := e.(string)
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "email must be a string"})
+		abortWithError(c, apperrors.InvalidField("email", "email must be a string"))
 		return
 	}
 
 	// extract otp (may be number or string)
 	o, ok := payload["otp"]
 	if !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "otp is required"})
+		abortWithError(c, apperrors.InvalidField("otp", "otp is required"))
 		return
 	}
 	var otpStr string
@@ -144,27 +144,22 @@ func (h *UserHandler) VerifyEmailOtp(c *gin.Context) {
 		otpStr = fmt.Sprintf("%v", v)
 	}
 
-	ok, err := helpers.VerifyOTP(email, otpStr)
-	if err != nil || !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired OTP"})
+	if err := h.userService.VerifyOTP(c.Request.Context(), email, otpStr); err != nil {
+		abortWithError(c, err)
 		return
 	}
 
-	user, token, err := h.userService.ActivateUserByEmail(email)
+	user, token, err := h.userService.ActivateUserByEmail(c.Request.Context(), email)
 	if err != nil {
-		statusCode := http.StatusBadRequest
-		if err.Error() == "user not found for this email" {
-			statusCode = http.StatusNotFound
-		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
-	c.SetCookie("Jwt-Token", token, 3600, "/", "localhost", false, true)
-	c.JSON(http.StatusOK, gin.H{
-		"user":    SignupUserResponse{Name: user.Name, Email: user.Email, Role: user.Role, IsActive: user.IsActive, Gender: user.Gender, Phone: user.Phone},
-		"token":   token,
-		"message": "Email verified. Account is now active.",
+	setAuthCookie(c, h.cookies, token)
+	c.JSON(http.StatusOK, VerifyEmailResponse{
+		User:    signupUser(user),
+		Token:   token,
+		Message: "Email verified. Account is now active.",
 	})
 }
 
@@ -177,13 +172,12 @@ func (h *UserHandler) VerifyOtp(c *gin.Context) {
 		return
 	}
 
-	ok, err := helpers.VerifyOTP(req.Phone, req.Otp)
-	if err != nil || !ok {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired OTP"})
+	if err := h.userService.VerifyOTP(c.Request.Context(), req.Phone, req.Otp); err != nil {
+		abortWithError(c, err)
 		return
 	}
 
-	user, token, err := h.userService.ActivateUserByPhone(types.ActivateUserRequest{Phone: req.Phone})
+	user, token, err := h.userService.ActivateUserByPhone(c.Request.Context(), types.ActivateUserRequest{Phone: req.Phone})
 	if err != nil {
 		statusCode := http.StatusBadRequest
 		if err.Error() == "user not found for this phone" {
@@ -193,7 +187,7 @@ func (h *UserHandler) VerifyOtp(c *gin.Context) {
 		return
 	}
 
-	c.SetCookie("Jwt-Token", token, 3600, "/", "localhost", false, true)
+	setAuthCookie(c, h.cookies, token)
 	c.JSON(http.StatusOK, gin.H{
 		"user":    SignupUserResponse{Name: user.Name, Email: user.Email, Role: user.Role, IsActive: user.IsActive, Gender: user.Gender, Phone: user.Phone},
 		"message": "Phone verified. Account is now active.",
@@ -206,79 +200,82 @@ func (h *UserHandler) LoginUser(c *gin.Context) {
 	var requestBody types.LoginRequest
 
 	if err := c.ShouldBindJSON(&requestBody); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Bad request"})
+		abortWithError(c, invalidRequestBody(err))
 		return
 	}
 
-	user, token, err := h.userService.Login(requestBody)
+	requestBody.IP = c.ClientIP()
+	requestBody.UserAgent = c.Request.UserAgent()
+
+	user, token, err := h.userService.Login(c.Request.Context(), requestBody)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid username or password"})
+		abortWithError(c, err)
 		return
 	}
-	c.SetCookie("Jwt-Token", token, 3600, "/", "localhost", false, true)
+	setAuthCookie(c, h.cookies, token)
 	c.JSON(http.StatusOK, LoginResponse{
-		User:  user,
+		User:  dto.NewUser(user),
 		Token: token,
 	})
 
 }
 
 func (h *UserHandler) LogOutUser(c *gin.Context) {
-	c.SetCookie("Jwt-Token", "", -1, "/", "localhost", false, true)
-	c.JSON(http.StatusOK, gin.H{
-		"message": "User logged out",
-	})
+	clearAuthCookie(c, h.cookies)
+	c.JSON(http.StatusOK, MessageResponse{Message: "User logged out"})
 }
 
-// getTokenFromRequest returns JWT from cookie (web) or Authorization header (mobile).
-func getTokenFromRequest(c *gin.Context) (string, bool) {
-	if tok, err := c.Cookie("Jwt-Token"); err == nil && tok != "" {
-		return tok, true
+func (h *UserHandler) GetCurrentUser(c *gin.Context) {
+	if _, ok := middleware.TokenFromRequest(c); !ok {
+		abortWithError(c, apperrors.Unauthorized("No token received"))
+		return
 	}
-	auth := c.GetHeader("Authorization")
-	const prefix = "Bearer "
-	if len(auth) >= len(prefix) && auth[:len(prefix)] == prefix {
-		return auth[len(prefix):], true
+
+	// The token was verified by middleware.Authenticate; no claims means it was invalid.
+	existingUser, ok := middleware.CurrentUser(c)
+	if !ok {
+		abortWithError(c, apperrors.Unauthorized("Invalid Token"))
+		return
 	}
-	return "", false
-}
 
-func (h *UserHandler) GetCurrentUser(c *gin.Context) {
-	tokernString, ok := getTokenFromRequest(c)
-	if !ok || tokernString == "" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "No token received"})
+	user, err := h.userService.GetByID(c.Request.Context(), existingUser.UserID.String())
+	if err != nil {
+		abortWithError(c, err)
 		return
 	}
 
-	secret := config.LoadConfig()
+	c.JSON(http.StatusOK, CurrentUserResponse{User: dto.NewUser(user)})
 
-	existingUser, err := auth.VerifyJWT(tokernString, secret.JWTSecret)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, "Invalid Token")
+}
+
+// GetLoginHistory lists the signed-in user's recent login attempts.
+func (h *UserHandler) GetLoginHistory(c *gin.Context) {
+	claims, ok := middleware.CurrentUser(c)
+	if !ok {
+		abortWithError(c, apperrors.Unauthorized("No token received"))
 		return
 	}
 
-	user, err := h.userService.GetByID(existingUser.UserID.String())
+	history, err := h.userService.GetLoginHistory(c.Request.Context(), claims.UserID.String())
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"message": "User not found"})
+		abortWithError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, CurrentUserResponse{User: user})
-
+	c.JSON(http.StatusOK, LoginHistoryResponse{History: history})
 }
 
 func (h *UserHandler) SignUpOauth2Facebook(c *gin.Context) {
 	code := c.Query("code")
 	if code == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "missing code parameter"})
+		abortWithError(c, apperrors.InvalidField("code", "missing code parameter"))
 		return
 	}
 
-	userInfo, err := oauth.ConnectToFacebook(code)
+	userInfo, err := h.facebook.ConnectToFacebook(c.Request.Context(), code)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		abortWithError(c, err)
 		return
 	}
 
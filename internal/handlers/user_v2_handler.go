@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/dto"
+	"github.com/musishere/sportsApp/internal/middleware"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/services"
+	"github.com/musishere/sportsApp/types"
+)
+
+// UserV2Handler serves the API v2 account endpoints.
+type UserV2Handler struct {
+	userService *services.UserService
+	cookies     config.CookieConfig
+}
+
+func NewUserV2Handler(userService *services.UserService, cookies config.CookieConfig) *UserV2Handler {
+	return &UserV2Handler{userService: userService, cookies: cookies}
+}
+
+// Signup creates an inactive account and emails the OTP that activates it.
+func (h *UserV2Handler) Signup(c *gin.Context) {
+	var req types.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+	if req.Cnic == "" {
+		req.Cnic = " " // store space until set later
+	}
+
+	user, token, err := h.userService.Register(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	if err := h.userService.IssueEmailOTP(c.Request.Context(), req.Email); err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	setAuthCookie(c, h.cookies, token)
+	c.JSON(http.StatusCreated, dto.Data(dto.NewUser(user)))
+}
+
+// VerifyEmail checks the emailed OTP and activates the account.
+func (h *UserV2Handler) VerifyEmail(c *gin.Context) {
+	var req types.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+
+	if err := h.userService.VerifyOTP(c.Request.Context(), req.Email, req.Otp); err != nil {
+		abortWithError(c, err)
+		return
+	}
+	user, token, err := h.userService.ActivateUserByEmail(c.Request.Context(), req.Email)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	setAuthCookie(c, h.cookies, token)
+	c.JSON(http.StatusOK, dto.Data(dto.NewSession(user, token)))
+}
+
+func (h *UserV2Handler) Login(c *gin.Context) {
+	var req types.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		abortWithError(c, invalidRequestBody(err))
+		return
+	}
+	req.IP = c.ClientIP()
+	req.UserAgent = c.Request.UserAgent()
+
+	user, token, err := h.userService.Login(c.Request.Context(), req)
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+
+	setAuthCookie(c, h.cookies, token)
+	c.JSON(http.StatusOK, dto.Data(dto.NewSession(user, token)))
+}
+
+func (h *UserV2Handler) Logout(c *gin.Context) {
+	clearAuthCookie(c, h.cookies)
+	c.Status(http.StatusNoContent)
+}
+
+// Me returns the signed-in user.
+func (h *UserV2Handler) Me(c *gin.Context) {
+	claims, ok := middleware.CurrentUser(c)
+	if !ok {
+		abortWithError(c, apperrors.Unauthorized("authentication required"))
+		return
+	}
+
+	user, err := h.userService.GetByID(c.Request.Context(), claims.UserID.String())
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewUser(user)))
+}
+
+// LoginHistory lists the signed-in user's recent login attempts, newest first.
+func (h *UserV2Handler) LoginHistory(c *gin.Context) {
+	claims, ok := middleware.CurrentUser(c)
+	if !ok {
+		abortWithError(c, apperrors.Unauthorized("authentication required"))
+		return
+	}
+
+	history, err := h.userService.GetLoginHistory(c.Request.Context(), claims.UserID.String())
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Collection(dto.Map(history, dto.NewLoginAttempt)))
+}
+
+// GetUser returns a user's public profile.
+func (h *UserV2Handler) GetUser(c *gin.Context) {
+	user, err := h.userService.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewPublicUser(user)))
+}
+
+// AdminGetUser returns a user's full account, including lockout state, to an admin.
+func (h *UserV2Handler) AdminGetUser(c *gin.Context) {
+	claims, ok := middleware.CurrentUser(c)
+	if !ok {
+		abortWithError(c, apperrors.Unauthorized("authentication required"))
+		return
+	}
+	if claims.Role != models.RoleAdmin {
+		abortWithError(c, apperrors.Forbidden("admin role required"))
+		return
+	}
+
+	user, err := h.userService.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		abortWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.Data(dto.NewAdminUser(user)))
+}
@@ -0,0 +1,126 @@
+// Package health runs dependency checks for the liveness and readiness endpoints and
+// tracks whether the instance is draining ahead of shutdown.
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Status values reported per dependency and overall.
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusFailing  = "failing"
+	StatusDraining = "draining"
+)
+
+// CheckFunc probes a dependency and returns an error if it is unreachable.
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name     string
+	critical bool
+	fn       CheckFunc
+}
+
+// DependencyStatus is the outcome of one check.
+type DependencyStatus struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the readiness response body.
+type Report struct {
+	Status string                      `json:"status"`
+	Checks map[string]DependencyStatus `json:"checks,omitempty"`
+}
+
+// Checker holds the registered dependency checks.
+type Checker struct {
+	checks   []check
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// NewChecker returns a Checker that gives each dependency at most timeout to respond.
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Register adds a dependency check. A failing critical dependency makes the instance
+// not ready; a failing non-critical one only marks it degraded, since the API can
+// still serve most requests without it.
+func (c *Checker) Register(name string, critical bool, fn CheckFunc) {
+	c.checks = append(c.checks, check{name: name, critical: critical, fn: fn})
+}
+
+// StartDraining makes readiness fail so load balancers stop routing new traffic here.
+func (c *Checker) StartDraining() {
+	c.draining.Store(true)
+}
+
+// Draining reports whether shutdown has begun.
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Ready runs every check concurrently and reports whether the instance should receive
+// traffic along with the per-dependency results.
+func (c *Checker) Ready(ctx context.Context) (bool, Report) {
+	if c.Draining() {
+		return false, Report{Status: StatusDraining}
+	}
+
+	results := make([]DependencyStatus, len(c.checks))
+	var wg sync.WaitGroup
+	for i, chk := range c.checks {
+		wg.Add(1)
+		go func(i int, chk check) {
+			defer wg.Done()
+			results[i] = c.run(ctx, chk)
+		}(i, chk)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]DependencyStatus, len(c.checks))}
+	ready := true
+	for i, chk := range c.checks {
+		res := results[i]
+		report.Checks[chk.name] = res
+		if res.Status == StatusUp {
+			continue
+		}
+		if chk.critical {
+			ready = false
+			report.Status = StatusFailing
+		} else if report.Status == StatusOK {
+			report.Status = StatusDegraded
+		}
+	}
+	return ready, report
+}
+
+func (c *Checker) run(ctx context.Context, chk check) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := chk.fn(ctx)
+	res := DependencyStatus{
+		Status:    StatusUp,
+		Critical:  chk.critical,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
@@ -1,12 +1,23 @@
 package helpers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"os"
+	"strings"
+	"time"
+
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/metrics"
+	"github.com/musishere/sportsApp/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // LocationIQResponse matches the LocationIQ API JSON response.
@@ -29,29 +40,89 @@ type LocationIQResponse struct {
 	Country     string   `json:"country,omitempty"`
 	CountryCode string   `json:"country_code,omitempty"`
 	Boundingbox []string `json:"boundingbox,omitempty"`
+
+	// Address holds the structured breakdown returned when addressdetails=1.
+	Address *LocationIQAddress `json:"address,omitempty"`
+}
+
+// LocationIQAddress is the structured address breakdown of a LocationIQ result.
+type LocationIQAddress struct {
+	City     string `json:"city,omitempty"`
+	Town     string `json:"town,omitempty"`
+	Village  string `json:"village,omitempty"`
+	State    string `json:"state,omitempty"`
+	Postcode string `json:"postcode,omitempty"`
+	Country  string `json:"country,omitempty"`
+}
+
+// AddressParts returns city, state and postcode, preferring the structured address breakdown.
+func (r *LocationIQResponse) AddressParts() (city, state, postcode string) {
+	city, state, postcode = r.City, r.State, r.Postcode
+	if r.Address == nil {
+		return city, state, postcode
+	}
+	for _, c := range []string{r.Address.City, r.Address.Town, r.Address.Village} {
+		if c != "" {
+			city = c
+			break
+		}
+	}
+	if r.Address.State != "" {
+		state = r.Address.State
+	}
+	if r.Address.Postcode != "" {
+		postcode = r.Address.Postcode
+	}
+	return city, state, postcode
+}
+
+// Geocoder resolves addresses to coordinates with the LocationIQ API.
+type Geocoder struct {
+	apiKey  string
+	baseURL string
+	client  *http.Client
+}
+
+func NewGeocoder(cfg config.LocationIQConfig) *Geocoder {
+	return &Geocoder{
+		apiKey:  cfg.APIKey,
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+		client:  &http.Client{Timeout: cfg.Timeout},
+	}
 }
 
 // GeocodeAddress calls LocationIQ Geocoding API and returns the full location response for the given address.
-func GeocodeAddress(address string) (*LocationIQResponse, error) {
-	apiKey := os.Getenv("LOCATION_IQ_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("LOCATION_IQ_KEY environment variable is not set")
+func (g *Geocoder) GeocodeAddress(ctx context.Context, address string) (_ *LocationIQResponse, err error) {
+	if g.apiKey == "" {
+		return nil, fmt.Errorf("LOCATION_IQ_KEY is not configured")
 	}
+	defer metrics.ObserveExternal(metrics.ServiceLocationIQ, metrics.OpGeocode, time.Now(), &err)
+
+	// Traced by hand rather than with otelhttp, which would record the API key in the URL.
+	ctx, span := tracing.Tracer("helpers").Start(ctx, "locationiq geocode",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("server.address", "locationiq")),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 
 	u := fmt.Sprintf(
-		"https://us1.locationiq.com/v1/search?key=%s&q=%s&format=json",
-		apiKey,
+		"%s/search?key=%s&q=%s&format=json&addressdetails=1&normalizecity=1",
+		g.baseURL,
+		g.apiKey,
 		url.QueryEscape(address),
 	)
 
-	req, err := http.NewRequest("GET", u, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
 	req.Header.Add("accept", "application/json")
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := g.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("geocoding request: %w", err)
 	}
@@ -73,10 +144,11 @@ func GeocodeAddress(address string) (*LocationIQResponse, error) {
 	}
 
 	if len(results) == 0 {
-		return nil, fmt.Errorf("no results found for address: %s", address)
+		return nil, apperrors.InvalidField("address", fmt.Sprintf("no results found for address: %s", address))
 	}
 
-	fmt.Printf("Geocoding result for '%s': lat=%s, lon=%s\n", address, results[0].Lat, results[0].Lon)
+	logging.FromContext(ctx).DebugContext(ctx, "geocoded address",
+		slog.String("address", address), slog.String("lat", results[0].Lat), slog.String("lon", results[0].Lon))
 
 	return &results[0], nil
 }
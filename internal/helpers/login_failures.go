@@ -0,0 +1,94 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/musishere/sportsApp/internal/cache"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// loginFailuresTTL is how long the failures against an unknown email are remembered
+// after the last one.
+const loginFailuresTTL = 30 * 24 * time.Hour
+
+// maxLoginFailureRetries bounds how often an update is retried when a concurrent
+// attempt changes the same email first.
+const maxLoginFailureRetries = 10
+
+// LoginFailures counts failed logins against emails that have no account, so they are
+// delayed and locked exactly as an account's would be and a lockout does not reveal
+// which emails are registered.
+type LoginFailures struct {
+	client *cache.Client
+}
+
+func NewLoginFailures(client *cache.Client) *LoginFailures {
+	return &LoginFailures{client: client}
+}
+
+type loginFailureState struct {
+	Attempts int        `json:"attempts"`
+	LastAt   *time.Time `json:"lastAt,omitempty"`
+	Locked   *time.Time `json:"lockedUntil,omitempty"`
+}
+
+func loginFailuresKey(email string) string {
+	return fmt.Sprintf("login_failures:%s", strings.ToLower(strings.TrimSpace(email)))
+}
+
+// Update runs fn on the failed-login counters of email, carried on an otherwise empty
+// user, and saves what fn leaves. A concurrent update of the same email makes it
+// start over, so counts are never lost. When Redis is unavailable fn runs on a clean
+// slate and nothing is saved.
+func (f *LoginFailures) Update(ctx context.Context, email string, fn func(user *models.User) error) error {
+	if !f.client.Available() {
+		return fn(&models.User{Email: email})
+	}
+
+	key := loginFailuresKey(email)
+	update := func(tx *redis.Tx) error {
+		var state loginFailureState
+		data, err := tx.Get(ctx, key).Bytes()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, &state); err != nil {
+				return err
+			}
+		}
+
+		user := &models.User{Email: email, FailedLoginAttempts: state.Attempts, LastFailedLoginAt: state.LastAt, LockedUntil: state.Locked}
+		if err := fn(user); err != nil {
+			return err
+		}
+		state = loginFailureState{Attempts: user.FailedLoginAttempts, LastAt: user.LastFailedLoginAt, Locked: user.LockedUntil}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if state.Attempts == 0 && state.LastAt == nil && state.Locked == nil {
+				pipe.Del(ctx, key)
+				return nil
+			}
+			data, err := json.Marshal(state)
+			if err != nil {
+				return err
+			}
+			pipe.Set(ctx, key, data, loginFailuresTTL)
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < maxLoginFailureRetries; i++ {
+		err := f.client.Watch(ctx, update, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return fmt.Errorf("login failures for %s kept changing", key)
+}
@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/cache"
+)
+
+func otpUnavailable() *apperrors.Error {
+	return apperrors.Unavailable("verification codes are temporarily unavailable, please try again shortly")
+}
+
+// OTPStore keeps one-time passwords in Redis, keyed by the phone number or email they
+// were sent to, until they are verified or expire.
+type OTPStore struct {
+	client *cache.Client
+	ttl    time.Duration
+}
+
+func NewOTPStore(client *cache.Client, ttl time.Duration) *OTPStore {
+	return &OTPStore{client: client, ttl: ttl}
+}
+
+func otpKey(identifier string) string {
+	return fmt.Sprintf("otp:%s", identifier)
+}
+
+// Store saves otp for identifier, replacing any previous code.
+func (s *OTPStore) Store(ctx context.Context, identifier, otp string) error {
+	if !s.client.Available() {
+		return otpUnavailable()
+	}
+	if err := s.client.Set(ctx, otpKey(identifier), otp, s.ttl).Err(); err != nil {
+		return otpUnavailable().WithCause(err)
+	}
+	return nil
+}
+
+// Verify reports whether otp matches the stored code for identifier. A matching code is
+// consumed so it cannot be reused; expired or unknown codes simply don't match.
+func (s *OTPStore) Verify(ctx context.Context, identifier, otp string) (bool, error) {
+	if !s.client.Available() {
+		return false, otpUnavailable()
+	}
+
+	key := otpKey(identifier)
+	stored, err := s.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	} else if err != nil {
+		return false, otpUnavailable().WithCause(err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(stored), []byte(otp)) != 1 {
+		return false, nil
+	}
+
+	// OTP correct → delete
+	_ = s.client.Del(ctx, key).Err()
+	return true, nil
+}
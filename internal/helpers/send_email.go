@@ -1,40 +1,127 @@
 package helpers
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"net/smtp"
-	"os"
+	"strconv"
+	"time"
+
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/metrics"
+	"github.com/musishere/sportsApp/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// SendEmail sends an email with the OTP code to the specified email address.
-// Requires EMAIL_FROM and EMAIL_PASSWORD environment variables.
-func SendEmail(to, otp string) error {
-	from := os.Getenv("EMAIL_FROM")
-	if from == "" {
-		return fmt.Errorf("EMAIL_FROM environment variable is not set")
+// Mailer sends plain-text email through the configured SMTP server.
+type Mailer struct {
+	host     string
+	port     int
+	from     string
+	password string
+}
+
+func NewMailer(cfg config.SMTPConfig) *Mailer {
+	return &Mailer{
+		host:     cfg.Host,
+		port:     cfg.Port,
+		from:     cfg.From,
+		password: cfg.Password,
 	}
+}
+
+// SendOTP sends an email with the OTP code to the specified email address.
+func (m *Mailer) SendOTP(ctx context.Context, to, otp string) error {
+	subject := "Your OTP Code"
+	body := fmt.Sprintf("Your OTP (One-Time Password) is: %s\n\nThis code is valid for 10 minutes. Do not share this code with anyone.", otp)
+	return m.Send(ctx, to, subject, body)
+}
 
-	password := os.Getenv("EMAIL_PASSWORD")
-	if password == "" {
-		return fmt.Errorf("EMAIL_PASSWORD environment variable is not set")
+// Send sends a plain-text email. Requires EMAIL_FROM and EMAIL_PASSWORD to be configured.
+func (m *Mailer) Send(ctx context.Context, to, subject, body string) (err error) {
+	if m.from == "" {
+		return fmt.Errorf("EMAIL_FROM is not configured")
 	}
+	if m.password == "" {
+		return fmt.Errorf("EMAIL_PASSWORD is not configured")
+	}
+
+	defer metrics.ObserveExternal(metrics.ServiceSMTP, metrics.OpSend, time.Now(), &err)
 
-	smtpHost := "smtp.gmail.com"
-	smtpPort := "587"
+	ctx, span := tracing.Tracer("helpers").Start(ctx, "smtp send",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("server.address", m.host)),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 
 	// Compose the email message
-	subject := "Your OTP Code"
-	body := fmt.Sprintf("Your OTP (One-Time Password) is: %s\n\nThis code is valid for 10 minutes. Do not share this code with anyone.", otp)
-	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", from, to, subject, body)
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", m.from, to, subject, body)
 
 	// Create SMTP auth
-	auth := smtp.PlainAuth("", from, password, smtpHost)
+	auth := smtp.PlainAuth("", m.from, m.password, m.host)
 
 	// Send the email
-	err := smtp.SendMail(smtpHost+":"+smtpPort, auth, from, []string{to}, []byte(msg))
-	if err != nil {
+	addr := m.host + ":" + strconv.Itoa(m.port)
+	if err := sendMail(ctx, addr, m.host, auth, m.from, to, []byte(msg)); err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
 	return nil
 }
+
+// sendMail is smtp.SendMail with cancellation: the connection is dialled with ctx and
+// closed as soon as ctx is done, which aborts whichever SMTP command is in flight.
+func sendMail(ctx context.Context, addr, host string, auth smtp.Auth, from, to string, msg []byte) (err error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer func() {
+		if !stop() && ctx.Err() != nil {
+			err = ctx.Err()
+		}
+	}()
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if ok, _ := client.Extension("AUTH"); ok {
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
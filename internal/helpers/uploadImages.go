@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"mime/multipart"
-	"os"
+	"net/http"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -14,7 +14,15 @@ import (
 
 	"github.com/cloudinary/cloudinary-go/v2"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
-	"github.com/cloudinary/cloudinary-go/v2/config"
+	cldconfig "github.com/cloudinary/cloudinary-go/v2/config"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/metrics"
+	"github.com/musishere/sportsApp/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const maxFileSize = 5 * 1024 * 1024 // 5MB
@@ -30,25 +38,27 @@ type ImageUploader struct {
 	defaultFolder string
 }
 
-func NewImageUploader() (*ImageUploader, error) {
-	cloudName := os.Getenv("CLOUDINARY_CLOUD_NAME")
-	apiKey := os.Getenv("CLOUDINARY_API_KEY")
-	apiSecret := os.Getenv("CLOUDINARY_API_SECRET")
-	if cloudName == "" || apiKey == "" || apiSecret == "" {
+func NewImageUploader(cfg config.CloudinaryConfig) (*ImageUploader, error) {
+	cloudName := cfg.CloudName
+	if cloudName == "" || cfg.APIKey == "" || cfg.APISecret == "" {
 		return nil, fmt.Errorf("cloudinary credentials not configured")
 	}
 
-	cfg, err := config.NewFromParams(cloudName, apiKey, apiSecret)
+	cldCfg, err := cldconfig.NewFromParams(cloudName, cfg.APIKey, cfg.APISecret)
 	if err != nil {
 		return nil, fmt.Errorf("cloudinary config: %w", err)
 	}
-	cfg.API.Timeout = 120
-	cfg.API.UploadTimeout = 120
+	timeoutSeconds := int64(cfg.UploadTimeout.Seconds())
+	cldCfg.API.Timeout = timeoutSeconds
+	cldCfg.API.UploadTimeout = timeoutSeconds
 
-	cld, err := cloudinary.NewFromConfiguration(*cfg)
+	cld, err := cloudinary.NewFromConfiguration(*cldCfg)
 	if err != nil {
 		return nil, fmt.Errorf("cloudinary init: %w", err)
 	}
+	// Upload and admin URLs carry no credentials, so the HTTP calls can be traced as-is.
+	cld.Upload.Client.Transport = otelhttp.NewTransport(http.DefaultTransport)
+	cld.Admin.Client.Transport = otelhttp.NewTransport(http.DefaultTransport)
 
 	return &ImageUploader{
 		cld:           cld,
@@ -101,7 +111,18 @@ func (u *ImageUploader) urlFromResult(result *uploader.UploadResult) (string, er
 	return url, nil
 }
 
-func (u *ImageUploader) upload(ctx context.Context, reader interface{}, folder, publicID, transformation string) (string, error) {
+func (u *ImageUploader) upload(ctx context.Context, reader interface{}, folder, publicID, transformation string) (url string, err error) {
+	defer metrics.ObserveExternal(metrics.ServiceCloudinary, metrics.OpUpload, time.Now(), &err)
+
+	ctx, span := tracing.Tracer("helpers").Start(ctx, "cloudinary upload",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("cloudinary.folder", folder), attribute.String("cloudinary.public_id", publicID)),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	result, err := u.cld.Upload.Upload(ctx, reader, uploader.UploadParams{
 		Folder:         folder,
 		PublicID:       publicID,
@@ -116,11 +137,11 @@ func (u *ImageUploader) upload(ctx context.Context, reader interface{}, folder,
 
 func (u *ImageUploader) UploadImage(ctx context.Context, fileHeader *multipart.FileHeader) (string, error) {
 	if fileHeader.Size > maxFileSize {
-		return "", fmt.Errorf("file too large: max 5MB allowed")
+		return "", apperrors.Validation("file too large: max 5MB allowed")
 	}
 	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
 	if !allowedImageExts[ext] {
-		return "", fmt.Errorf("icon must be an image (jpg, jpeg, png, webp); got %s", ext)
+		return "", apperrors.Validation(fmt.Sprintf("icon must be an image (jpg, jpeg, png, webp); got %s", ext))
 	}
 
 	file, err := fileHeader.Open()
@@ -132,7 +153,7 @@ func (u *ImageUploader) UploadImage(ctx context.Context, fileHeader *multipart.F
 	publicID := u.makePublicID(fileHeader.Filename)
 	url, err := u.upload(ctx, file, u.defaultFolder, publicID, "q_auto,f_auto,w_1000")
 	if err != nil {
-		log.Printf("[Cloudinary] %s: %v", fileHeader.Filename, err)
+		logging.FromContext(ctx).ErrorContext(ctx, "cloudinary upload failed", slog.String("filename", fileHeader.Filename), logging.Err(err))
 		return "", err
 	}
 	return url, nil
@@ -147,21 +168,33 @@ func (u *ImageUploader) UploadImageWithFolder(ctx context.Context, fileHeader *m
 
 func (u *ImageUploader) UploadFromBytes(ctx context.Context, data []byte, filename, folder string) (string, error) {
 	if len(data) == 0 {
-		return "", fmt.Errorf("empty file data")
+		return "", apperrors.Validation("empty file data")
 	}
 	if len(data) > maxFileSize {
-		return "", fmt.Errorf("file too large: max 5MB allowed")
+		return "", apperrors.Validation("file too large: max 5MB allowed")
 	}
 	ext := strings.ToLower(filepath.Ext(filename))
 	if !allowedImageExts[ext] {
-		return "", fmt.Errorf("icon must be an image (jpg, jpeg, png, webp); got %s", ext)
+		return "", apperrors.Validation(fmt.Sprintf("icon must be an image (jpg, jpeg, png, webp); got %s", ext))
 	}
 
 	publicID := u.makePublicID(filename)
 	url, err := u.upload(ctx, bytes.NewReader(data), folder, publicID, "q_auto,f_auto")
 	if err != nil {
-		log.Printf("[Cloudinary] %s: %v", filename, err)
+		logging.FromContext(ctx).ErrorContext(ctx, "cloudinary upload failed", slog.String("filename", filename), logging.Err(err))
 		return "", err
 	}
 	return url, nil
 }
+
+// Ping checks that the Cloudinary API is reachable with the configured credentials.
+func (u *ImageUploader) Ping(ctx context.Context) error {
+	res, err := u.cld.Admin.Ping(ctx)
+	if err != nil {
+		return err
+	}
+	if res.Error.Message != "" {
+		return fmt.Errorf("cloudinary: %s", res.Error.Message)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Package leaderboard ranks players in Redis sorted sets: one board per sport, and per
+// sport within a turf or a city. Boards are derived data; Postgres keeps the results
+// they are built from, so any board can be replaced wholesale from there.
+package leaderboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/cache"
+)
+
+const keyPrefix = "leaderboard"
+
+func unavailable() *apperrors.Error {
+	return apperrors.Unavailable("leaderboards are temporarily unavailable")
+}
+
+// Scope selects a board: a sport overall, or a sport within one turf or one city.
+type Scope struct {
+	SportID uuid.UUID
+	TurfID  *uuid.UUID
+	City    string
+}
+
+// NormalizeCity is how city boards are keyed, so "Lahore" and " lahore" share one.
+func NormalizeCity(city string) string {
+	return strings.ToLower(strings.TrimSpace(city))
+}
+
+// keys returns the points and games-played sets for the scope. The braces are a
+// cluster hash tag that keeps both in one slot so they can be replaced in one
+// transaction.
+func (s Scope) keys() (points, played string) {
+	tag := "sport:" + s.SportID.String()
+	switch {
+	case s.TurfID != nil:
+		tag += ":turf:" + s.TurfID.String()
+	case s.City != "":
+		tag += ":city:" + NormalizeCity(s.City)
+	}
+	base := fmt.Sprintf("%s:{%s}", keyPrefix, tag)
+	return base + ":points", base + ":played"
+}
+
+// Standing is a player's place on a board.
+type Standing struct {
+	UserID uuid.UUID
+	Points int
+	Played int
+}
+
+type Board struct {
+	client *cache.Client
+}
+
+func NewBoard(client *cache.Client) *Board {
+	return &Board{client: client}
+}
+
+// Add credits one game and its points to userID on every scope given.
+func (b *Board) Add(ctx context.Context, userID uuid.UUID, points int, scopes ...Scope) error {
+	if !b.client.Available() {
+		return unavailable()
+	}
+	member := userID.String()
+	_, err := b.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, scope := range scopes {
+			pointsKey, playedKey := scope.keys()
+			pipe.ZIncrBy(ctx, pointsKey, float64(points), member)
+			pipe.ZIncrBy(ctx, playedKey, 1, member)
+		}
+		return nil
+	})
+	if err != nil {
+		return unavailable().WithCause(err)
+	}
+	return nil
+}
+
+// Top returns the first limit players on the scope's board, most points first.
+func (b *Board) Top(ctx context.Context, scope Scope, limit int) ([]Standing, error) {
+	if !b.client.Available() {
+		return nil, unavailable()
+	}
+	pointsKey, playedKey := scope.keys()
+	ranked, err := b.client.ZRevRangeWithScores(ctx, pointsKey, 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, unavailable().WithCause(err)
+	}
+	if len(ranked) == 0 {
+		return []Standing{}, nil
+	}
+
+	played := make([]*redis.FloatCmd, len(ranked))
+	if _, err := b.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, z := range ranked {
+			played[i] = pipe.ZScore(ctx, playedKey, z.Member.(string))
+		}
+		return nil
+	}); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, unavailable().WithCause(err)
+	}
+
+	standings := make([]Standing, 0, len(ranked))
+	for i, z := range ranked {
+		userID, err := uuid.Parse(z.Member.(string))
+		if err != nil {
+			continue
+		}
+		standings = append(standings, Standing{UserID: userID, Points: int(z.Score), Played: int(played[i].Val())})
+	}
+	return standings, nil
+}
+
+// Replace swaps the scope's board for standings in one transaction.
+func (b *Board) Replace(ctx context.Context, scope Scope, standings []Standing) error {
+	if !b.client.Available() {
+		return unavailable()
+	}
+	pointsKey, playedKey := scope.keys()
+	_, err := b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, pointsKey, playedKey)
+		for _, s := range standings {
+			member := s.UserID.String()
+			pipe.ZAdd(ctx, pointsKey, &redis.Z{Score: float64(s.Points), Member: member})
+			pipe.ZAdd(ctx, playedKey, &redis.Z{Score: float64(s.Played), Member: member})
+		}
+		return nil
+	})
+	if err != nil {
+		return unavailable().WithCause(err)
+	}
+	return nil
+}
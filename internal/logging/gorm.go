@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// GormLogger writes GORM query logs through the request-scoped slog logger. Queries are
+// logged with placeholders only, so bound values such as password hashes never reach
+// the logs.
+type GormLogger struct {
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewGormLogger logs failed queries and queries slower than slowThreshold.
+func NewGormLogger(slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{level: gormlogger.Warn, slowThreshold: slowThreshold}
+}
+
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		FromContext(ctx).InfoContext(ctx, fmt.Sprintf(msg, args...), slog.String("component", "gorm"))
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		FromContext(ctx).WarnContext(ctx, fmt.Sprintf(msg, args...), slog.String("component", "gorm"))
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		FromContext(ctx).ErrorContext(ctx, fmt.Sprintf(msg, args...), slog.String("component", "gorm"))
+	}
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		FromContext(ctx).ErrorContext(ctx, "query failed", slog.String("component", "gorm"),
+			slog.String("sql", sql), slog.Int64("rows", rows), slog.Duration("elapsed", elapsed), Err(err))
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		FromContext(ctx).WarnContext(ctx, "slow query", slog.String("component", "gorm"),
+			slog.String("sql", sql), slog.Int64("rows", rows), slog.Duration("elapsed", elapsed))
+	case l.level >= gormlogger.Info:
+		sql, rows := fc()
+		FromContext(ctx).DebugContext(ctx, "query", slog.String("component", "gorm"),
+			slog.String("sql", sql), slog.Int64("rows", rows), slog.Duration("elapsed", elapsed))
+	}
+}
+
+// ParamsFilter drops bound parameters so logged SQL keeps its placeholders.
+func (l *GormLogger) ParamsFilter(_ context.Context, sql string, _ ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
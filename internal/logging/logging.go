@@ -0,0 +1,102 @@
+// Package logging configures the structured slog logger and carries a request-scoped
+// logger (tagged with the request ID) through context.Context, so every log line from
+// handlers, services, repositories and queue jobs can be correlated with its request.
+package logging
+
+import (
+	"context"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/musishere/sportsApp/config"
+)
+
+type ctxKey int
+
+const (
+	loggerKey ctxKey = iota
+	requestIDKey
+)
+
+// New builds a logger writing to w in the configured format and level. Sensitive
+// attributes are redacted before they are written.
+func New(cfg config.LogConfig, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level:       parseLevel(cfg.Level),
+		ReplaceAttr: redactAttr,
+	}
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, "text") {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(traceHandler{handler})
+}
+
+// SetDefault installs logger as the slog default and routes the standard library
+// log package through it, so stray log.Printf calls from dependencies stay structured.
+func SetDefault(logger *slog.Logger) {
+	slog.SetDefault(logger)
+	log.SetFlags(0)
+	log.SetOutput(slogWriter{logger})
+}
+
+func parseLevel(level string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+	return l
+}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger.
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return slog.Default()
+}
+
+// WithRequestID stores the request ID in ctx and tags the context logger with it.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey, id)
+	return WithLogger(ctx, FromContext(ctx).With(slog.String("request_id", id)))
+}
+
+// RequestID returns the request ID stored by WithRequestID, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// Fatal logs msg at error level and exits. It is meant for startup failures only.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// Err is a shorthand for the conventional error attribute.
+func Err(err error) slog.Attr {
+	return slog.Any("error", err)
+}
+
+// slogWriter adapts the standard library logger to slog.
+type slogWriter struct {
+	logger *slog.Logger
+}
+
+func (w slogWriter) Write(p []byte) (int, error) {
+	w.logger.Info(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
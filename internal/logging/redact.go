@@ -0,0 +1,83 @@
+package logging
+
+import (
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveFragments match attribute keys (compared lowercased, without _ or -) whose
+// values must never be logged. "otp" is only matched at the start or end of a key so
+// words like "footprint" are left alone.
+var sensitiveFragments = []string{"password", "secret", "token", "cnic", "authorization", "cookie", "apikey"}
+
+func isSensitiveKey(key string) bool {
+	k := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	if strings.HasPrefix(k, "otp") || strings.HasSuffix(k, "otp") {
+		return true
+	}
+	for _, fragment := range sensitiveFragments {
+		if strings.Contains(k, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactAttr is the slog ReplaceAttr hook. It masks sensitive keys and walks structs and
+// maps logged with slog.Any, so a whole request DTO can be logged safely.
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	if isSensitiveKey(a.Key) {
+		return slog.String(a.Key, redacted)
+	}
+	if a.Value.Kind() != slog.KindAny {
+		return a
+	}
+
+	v := a.Value.Any()
+	if _, isErr := v.(error); isErr {
+		return a
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct && rv.Kind() != reflect.Map {
+		return a
+	}
+
+	// Round-trip through JSON so nested fields are visible under their wire names.
+	data, err := json.Marshal(v)
+	if err != nil {
+		return a
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return a
+	}
+	return slog.Any(a.Key, redactValue(generic))
+}
+
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, inner := range t {
+			if isSensitiveKey(k) {
+				t[k] = redacted
+			} else {
+				t[k] = redactValue(inner)
+			}
+		}
+		return t
+	case []any:
+		for i, inner := range t {
+			t[i] = redactValue(inner)
+		}
+		return t
+	default:
+		return v
+	}
+}
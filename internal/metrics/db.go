@@ -0,0 +1,13 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// RegisterDBStats exposes the connection pool statistics from sql.DB.Stats() under the
+// given database name (open, in-use and idle connections, wait counts and durations).
+func RegisterDBStats(db *sql.DB, name string) error {
+	return Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
@@ -0,0 +1,141 @@
+// Package metrics defines the Prometheus metrics exposed on /metrics. Labels are kept to
+// bounded sets (route templates, job types, dependency names) so cardinality stays flat
+// no matter how many distinct IDs clients request.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "sportsapp"
+
+// Registry holds every application metric plus the Go runtime and process collectors.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route template.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "route"})
+
+	httpInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "http_requests_in_flight",
+		Help:      "HTTP requests currently being served.",
+	})
+
+	queueJobs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_jobs_total",
+		Help:      "Queue jobs handled by workers, by job type and outcome (success, failure, dropped).",
+	}, []string{"job_type", "outcome"})
+
+	queueJobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "queue_job_duration_seconds",
+		Help:      "Time spent processing a queue job, by job type.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"job_type"})
+
+	queueEnqueued = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_jobs_enqueued_total",
+		Help:      "Jobs sent to the queue, by job type and outcome (success, failure).",
+	}, []string{"job_type", "outcome"})
+
+	queueReceiveErrors = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queue_receive_errors_total",
+		Help:      "Errors receiving messages from the queue.",
+	})
+
+	externalDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "external_call_duration_seconds",
+		Help:      "Latency of calls to external services, by service and operation.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"service", "operation"})
+
+	externalErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "external_call_errors_total",
+		Help:      "Failed calls to external services, by service and operation.",
+	}, []string{"service", "operation"})
+)
+
+// External service and operation labels.
+const (
+	ServiceCloudinary = "cloudinary"
+	ServiceLocationIQ = "locationiq"
+	ServiceSMTP       = "smtp"
+
+	OpUpload  = "upload"
+	OpGeocode = "geocode"
+	OpSend    = "send"
+)
+
+// Job outcome labels.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	OutcomeDropped = "dropped"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration, httpInFlight,
+		queueJobs, queueJobDuration, queueEnqueued, queueReceiveErrors,
+		externalDuration, externalErrors,
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// ObserveExternal records the duration and outcome of a call to an external service.
+// Use it as: defer metrics.ObserveExternal(metrics.ServiceSMTP, metrics.OpSend, time.Now(), &err)
+func ObserveExternal(service, operation string, start time.Time, err *error) {
+	externalDuration.WithLabelValues(service, operation).Observe(time.Since(start).Seconds())
+	if err != nil && *err != nil {
+		externalErrors.WithLabelValues(service, operation).Inc()
+	}
+}
+
+// ObserveJob records a processed queue job.
+func ObserveJob(jobType, outcome string, duration time.Duration) {
+	queueJobs.WithLabelValues(jobType, outcome).Inc()
+	if outcome != OutcomeDropped {
+		queueJobDuration.WithLabelValues(jobType).Observe(duration.Seconds())
+	}
+}
+
+// ObserveEnqueue records a job sent to the queue.
+func ObserveEnqueue(jobType string, err error) {
+	outcome := OutcomeSuccess
+	if err != nil {
+		outcome = OutcomeFailure
+	}
+	queueEnqueued.WithLabelValues(jobType, outcome).Inc()
+}
+
+// ObserveReceiveError records a failed queue receive.
+func ObserveReceiveError() {
+	queueReceiveErrors.Inc()
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/auth"
+)
+
+const (
+	// AuthCookieName is the cookie carrying the JWT for web clients.
+	AuthCookieName = "Jwt-Token"
+	claimsKey      = "authClaims"
+)
+
+// TokenFromRequest returns JWT from cookie (web) or Authorization header (mobile).
+func TokenFromRequest(c *gin.Context) (string, bool) {
+	if tok, err := c.Cookie(AuthCookieName); err == nil && tok != "" {
+		return tok, true
+	}
+	const prefix = "Bearer "
+	if header := c.GetHeader("Authorization"); strings.HasPrefix(header, prefix) && len(header) > len(prefix) {
+		return header[len(prefix):], true
+	}
+	return "", false
+}
+
+// Authenticate verifies the request JWT when present and stores its claims on the
+// context. Requests without a valid token continue anonymously.
+func Authenticate(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token, ok := TokenFromRequest(c); ok {
+			if claims, err := auth.VerifyJWT(token, jwtSecret); err == nil {
+				c.Set(claimsKey, claims)
+			}
+		}
+		c.Next()
+	}
+}
+
+// CurrentUser returns the claims stored by Authenticate, if the caller is signed in.
+func CurrentUser(c *gin.Context) (*auth.UserClaims, bool) {
+	v, ok := c.Get(claimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := v.(*auth.UserClaims)
+	return claims, ok
+}
+
+// RequireAuth rejects anonymous requests with 401. It runs after Authenticate.
+func RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := CurrentUser(c); !ok {
+			_ = c.Error(apperrors.Unauthorized("authentication required"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
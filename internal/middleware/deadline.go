@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/config"
+)
+
+// Deadlines bounds every request with a deadline chosen by operation type: reads,
+// multipart uploads (which wait on Cloudinary) and other writes. The deadline lives on
+// the request context, so it cancels the queries, uploads and outbound calls made
+// while serving the request.
+func Deadlines(cfg config.TimeoutConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), operationTimeout(c.Request, cfg))
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		// Not deferred: a panicking handler must reach Recovery with its context still
+		// live, or it would be reported as cancelled. The timer then expires on its own.
+		cancel()
+	}
+}
+
+func operationTimeout(r *http.Request, cfg config.TimeoutConfig) time.Duration {
+	switch {
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		return cfg.Read
+	case strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data"):
+		return cfg.Upload
+	default:
+		return cfg.Write
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecation announces that a route group is being retired. Deprecation (RFC 9745)
+// carries the date it was deprecated, Sunset (RFC 8594) the date it stops working, and
+// Link points at the successor version. Zero dates omit their header.
+func Deprecation(deprecatedAt, sunsetAt time.Time, successor string) gin.HandlerFunc {
+	var deprecation, sunset string
+	if !deprecatedAt.IsZero() {
+		deprecation = "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	}
+	if !sunsetAt.IsZero() {
+		sunset = sunsetAt.UTC().Format(http.TimeFormat)
+	}
+	link := "<" + successor + `>; rel="successor-version"`
+
+	return func(c *gin.Context) {
+		if deprecation != "" {
+			c.Header("Deprecation", deprecation)
+		}
+		if sunset != "" {
+			c.Header("Sunset", sunset)
+		}
+		c.Header("Link", link)
+		c.Next()
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/logging"
+)
+
+// ErrorBody is the JSON envelope returned for every failed request.
+type ErrorBody struct {
+	Code      apperrors.Code         `json:"code"`
+	Message   string                 `json:"message"`
+	Details   []apperrors.FieldError `json:"details,omitempty"`
+	RequestID string                 `json:"requestId,omitempty"`
+}
+
+// ErrorResponse wraps ErrorBody under an "error" key.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorHandler renders the last error attached with c.Error once the handler chain
+// finishes. Handlers should attach an *apperrors.Error and return without writing.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		renderError(c, c.Errors.Last().Err)
+	}
+}
+
+// Recovery converts panics into an internal error using the standard envelope.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		renderError(c, apperrors.Internal(fmt.Errorf("panic: %v", recovered)))
+	})
+}
+
+func renderError(c *gin.Context, err error) {
+	ctx := c.Request.Context()
+	appErr := apperrors.From(err)
+
+	// Dependencies don't always wrap context errors, so an internal error on a request
+	// whose deadline passed or whose client left is reported as such.
+	if appErr.Code == apperrors.CodeInternal && ctx.Err() != nil {
+		appErr = apperrors.From(apperrors.FromContext(ctx.Err())).WithCause(err)
+	}
+
+	switch appErr.Code {
+	case apperrors.CodeInternal, apperrors.CodeUnavailable, apperrors.CodeTimeout:
+		logging.FromContext(ctx).ErrorContext(ctx, "request failed",
+			slog.String("method", c.Request.Method), slog.String("path", c.Request.URL.Path), logging.Err(err))
+	case apperrors.CodeCanceled:
+		logging.FromContext(ctx).InfoContext(ctx, "request cancelled by client",
+			slog.String("method", c.Request.Method), slog.String("path", c.Request.URL.Path))
+	}
+
+	if appErr.RetryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(appErr.RetryAfter.Seconds()))))
+	}
+
+	c.AbortWithStatusJSON(appErr.Status(), ErrorResponse{Error: ErrorBody{
+		Code:      appErr.Code,
+		Message:   appErr.Message,
+		Details:   appErr.Fields,
+		RequestID: GetRequestID(c),
+	}})
+}
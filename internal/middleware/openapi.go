@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/apperrors"
+)
+
+// ValidateRequests rejects requests whose parameters or body don't match the OpenAPI
+// document before they reach a handler. Requests for paths the document doesn't
+// describe are passed through so the router can answer them. Authentication is left to
+// the handlers; the document's security requirements are informational only.
+func ValidateRequests(doc *openapi3.T) (gin.HandlerFunc, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("openapi router: %w", err)
+	}
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		// Handlers apply their own defaults; the request is left as the client sent it.
+		SkipSettingDefaults: true,
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := router.FindRoute(c.Request)
+		if err != nil {
+			if errors.Is(err, routers.ErrPathNotFound) || errors.Is(err, routers.ErrMethodNotAllowed) {
+				c.Next()
+				return
+			}
+			_ = c.Error(apperrors.Internal(fmt.Errorf("openapi route lookup: %w", err)))
+			c.Abort()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+		if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+			_ = c.Error(apperrors.Validation("request does not match the API specification",
+				specFieldErrors(err)...).WithCause(err))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}, nil
+}
+
+// specFieldErrors flattens a request validation error into per-field details. Only the
+// validator's reasons are reported, never the offending values.
+func specFieldErrors(err error) []apperrors.FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var fields []apperrors.FieldError
+		for _, inner := range e {
+			fields = append(fields, specFieldErrors(inner)...)
+		}
+		return fields
+
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			reason := e.Parameter.Name + " is invalid"
+			var schemaErr *openapi3.SchemaError
+			if errors.As(e.Err, &schemaErr) {
+				reason = schemaReason(schemaErr)
+			}
+			return []apperrors.FieldError{apperrors.Field(e.Parameter.Name, reason)}
+		}
+		var multi openapi3.MultiError
+		var schemaErr *openapi3.SchemaError
+		if errors.As(e.Err, &multi) || errors.As(e.Err, &schemaErr) {
+			return specFieldErrors(e.Err)
+		}
+		// A form field that can't be decoded, e.g. text where the spec expects a number.
+		var parseErr *openapi3filter.ParseError
+		if errors.As(e.Err, &parseErr) && len(parseErr.Path()) > 0 {
+			field := fmt.Sprint(parseErr.Path()...)
+			return []apperrors.FieldError{apperrors.Field(field, field+" is invalid")}
+		}
+		if e.Reason != "" {
+			return []apperrors.FieldError{apperrors.Field("body", e.Reason)}
+		}
+
+	case *openapi3.SchemaError:
+		field := strings.Join(e.JSONPointer(), ".")
+		if field == "" {
+			field = "body"
+		}
+		return []apperrors.FieldError{apperrors.Field(field, schemaReason(e))}
+	}
+	return []apperrors.FieldError{apperrors.Field("body", "request body is invalid")}
+}
+
+// schemaReason describes a schema violation without echoing format patterns.
+func schemaReason(err *openapi3.SchemaError) string {
+	if err.SchemaField == "format" && err.Schema != nil {
+		return "must be a valid " + err.Schema.Format
+	}
+	return err.Reason
+}
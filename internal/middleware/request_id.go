@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/logging"
+)
+
+const (
+	// RequestIDHeader is accepted from callers and echoed on every response.
+	RequestIDHeader = "X-Request-ID"
+	requestIDKey    = "requestID"
+	maxRequestIDLen = 128
+)
+
+// RequestID accepts an incoming X-Request-ID (or generates one) and exposes it on the
+// gin context and response headers. It also attaches a logger tagged with the ID to the
+// request context, which services and repositories retrieve via logging.FromContext.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// validRequestID accepts caller-supplied IDs made of printable ASCII without spaces, so
+// they cannot forge log lines or inject headers.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// GetRequestID returns the request ID set by RequestID, or "" if absent.
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
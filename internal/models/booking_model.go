@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Booking states. A split-payment booking holds its field while pending payment and
+// is confirmed once covered or expires at its payment deadline. Cancelled and expired
+// bookings keep their row but free the field.
+const (
+	BookingPendingPayment = "pending_payment"
+	BookingConfirmed      = "confirmed"
+	BookingCancelled      = "cancelled"
+	BookingExpired        = "expired"
+)
+
+// Booking reserves one field of a turf for a time range. The party is the user who
+// booked, or a team when TeamID is set. Occurrences of a booking series carry its
+// SeriesID and their price, in minor units of the series currency, in Amount.
+// ClosureID flags a booking made before a closure of its field was set; when several
+// closures flagged it, it is one of them.
+type Booking struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TurfID      uuid.UUID  `gorm:"type:uuid;not null;index:idx_bookings_turf_field_start" json:"turfId"`
+	Turf        Turf       `gorm:"foreignKey:TurfID;references:ID" json:"turf"`
+	FieldNumber int        `gorm:"type:int;not null;index:idx_bookings_turf_field_start" json:"fieldNumber"`
+	StartsAt    time.Time  `gorm:"not null;index:idx_bookings_turf_field_start" json:"startsAt"`
+	EndsAt      time.Time  `gorm:"not null" json:"endsAt"`
+	BookedByID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"bookedById"`
+	TeamID      *uuid.UUID `gorm:"type:uuid;index" json:"teamId"`
+	Team        *Team      `gorm:"foreignKey:TeamID;references:ID" json:"team,omitempty"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'confirmed'" json:"status"`
+	SeriesID    *uuid.UUID `gorm:"type:uuid;index" json:"seriesId"`
+	Amount      int64      `gorm:"type:bigint;not null;default:0" json:"amount"`
+	ClosureID   *uuid.UUID `gorm:"type:uuid;index" json:"closureId"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Closure reasons.
+const (
+	ClosureMaintenance  = "maintenance"
+	ClosurePrivateEvent = "private_event"
+	ClosureHoliday      = "holiday"
+)
+
+// TurfHours overrides a turf's StartTime and EndTime on one weekday (0 is Sunday).
+// A Closed day takes no bookings. Hours are read in the turf's time zone, like its
+// own.
+type TurfHours struct {
+	TurfID   uuid.UUID `gorm:"type:uuid;primaryKey" json:"turfId"`
+	Weekday  int       `gorm:"type:int;primaryKey" json:"weekday"`
+	OpensAt  int       `gorm:"type:int;not null" json:"opensAt"`
+	ClosesAt int       `gorm:"type:int;not null" json:"closesAt"`
+	Closed   bool      `gorm:"not null;default:false" json:"closed"`
+}
+
+// TurfClosure blocks one field of a turf, or the whole turf when FieldNumber is nil,
+// from StartsAt to EndsAt. Bookings already holding the time when it is created keep
+// their field but are flagged by it, and listed in Bookings.
+type TurfClosure struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TurfID      uuid.UUID `gorm:"type:uuid;not null;index:idx_turf_closures_turf_start" json:"turfId"`
+	Turf        Turf      `gorm:"foreignKey:TurfID;references:ID" json:"turf"`
+	FieldNumber *int      `gorm:"type:int" json:"fieldNumber"`
+	Reason      string    `gorm:"type:varchar(20);not null" json:"reason"`
+	Note        string    `gorm:"type:varchar(255);not null;default:''" json:"note"`
+	StartsAt    time.Time `gorm:"not null;index:idx_turf_closures_turf_start" json:"startsAt"`
+	EndsAt      time.Time `gorm:"not null" json:"endsAt"`
+	CreatedByID uuid.UUID `gorm:"type:uuid;not null" json:"createdById"`
+	Bookings    []Booking `gorm:"many2many:booking_closures" json:"bookings"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FavouriteTurf is a turf a player has saved for quick rebooking.
+type FavouriteTurf struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_favourite_turfs_user_turf" json:"userId"`
+	TurfID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_favourite_turfs_user_turf;index" json:"turfId"`
+	Turf      Turf      `gorm:"foreignKey:TurfID;references:ID" json:"turf"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SavedSearch is a player's standing request to hear about fields that free up within
+// RadiusKm of a point, between WindowStart and WindowEnd and within FromHour-ToHour
+// each day. Hours are read in the time zone of the turf a slot is at. The point is
+// copied from the player's saved location when the search is created.
+type SavedSearch struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index" json:"userId"`
+	User           User       `gorm:"foreignKey:UserID;references:ID" json:"user"`
+	Name           string     `gorm:"type:varchar(100);not null" json:"name"`
+	SportID        *uuid.UUID `gorm:"type:uuid" json:"sportId"`
+	Sport          *Sports    `gorm:"foreignKey:SportID;references:ID" json:"sport,omitempty"`
+	Latitude       float64    `gorm:"type:double precision;not null" json:"latitude"`
+	Longitude      float64    `gorm:"type:double precision;not null" json:"longitude"`
+	RadiusKm       float64    `gorm:"type:double precision;not null" json:"radiusKm"`
+	WindowStart    time.Time  `gorm:"not null" json:"windowStart"`
+	WindowEnd      time.Time  `gorm:"not null;index" json:"windowEnd"`
+	FromHour       int        `gorm:"type:int;not null;default:0" json:"fromHour"`
+	ToHour         int        `gorm:"type:int;not null;default:24" json:"toHour"`
+	LastNotifiedAt *time.Time `json:"lastNotifiedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginHistory records each login attempt against an existing account.
+// Coordinates are rounded to ~1km so the table never stores a precise position.
+type LoginHistory struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;index" json:"userId"`
+	IP            string    `gorm:"type:varchar(64);not null;default:''" json:"ip"`
+	UserAgent     string    `gorm:"type:varchar(512);not null;default:''" json:"userAgent"`
+	Latitude      float64   `gorm:"type:decimal(10,2);not null;default:0" json:"latitude"`
+	Longitude     float64   `gorm:"type:decimal(11,2);not null;default:0" json:"longitude"`
+	Success       bool      `gorm:"type:boolean;not null;default:false" json:"success"`
+	FailureReason string    `gorm:"type:varchar(50);not null;default:''" json:"failureReason,omitempty"`
+	DistanceKm    float64   `gorm:"type:double precision;not null;default:0" json:"distanceKm"`
+	Suspicious    bool      `gorm:"type:boolean;not null;default:false" json:"suspicious"`
+	CreatedAt     time.Time `gorm:"index" json:"createdAt"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Match states. An open match accepts players until its cutoff; it is then confirmed
+// if it reached the sport's MinPlayers and cancelled otherwise.
+const (
+	MatchOpen      = "open"
+	MatchConfirmed = "confirmed"
+	MatchCancelled = "cancelled"
+)
+
+// Skill levels an organiser can ask for.
+const (
+	SkillAny          = "any"
+	SkillBeginner     = "beginner"
+	SkillIntermediate = "intermediate"
+	SkillAdvanced     = "advanced"
+)
+
+// Match player states. Waitlisted players are promoted in join order when a spot frees.
+const (
+	MatchPlayerJoined     = "joined"
+	MatchPlayerWaitlisted = "waitlisted"
+)
+
+// Match is an open game on a booked field that other players can join. The organiser
+// brings PartySize players (themselves included) and offers OpenSpots to others.
+type Match struct {
+	ID          uuid.UUID     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	BookingID   uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex" json:"bookingId"`
+	Booking     Booking       `gorm:"foreignKey:BookingID;references:ID" json:"booking"`
+	SportID     uuid.UUID     `gorm:"type:uuid;not null;index" json:"sportId"`
+	Sport       Sports        `gorm:"foreignKey:SportID;references:ID" json:"sport"`
+	OrganizerID uuid.UUID     `gorm:"type:uuid;not null;index" json:"organizerId"`
+	Organizer   User          `gorm:"foreignKey:OrganizerID;references:ID" json:"organizer"`
+	SkillLevel  string        `gorm:"type:varchar(20);not null;default:'any'" json:"skillLevel"`
+	PartySize   int           `gorm:"type:int;not null;default:1" json:"partySize"`
+	OpenSpots   int           `gorm:"type:int;not null" json:"openSpots"`
+	Notes       string        `gorm:"type:varchar(500);not null;default:''" json:"notes"`
+	CutoffAt    time.Time     `gorm:"not null;index" json:"cutoffAt"`
+	Status      string        `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+	Players     []MatchPlayer `gorm:"constraint:OnDelete:CASCADE;" json:"players"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+type MatchPlayer struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	MatchID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_match_players_match_user" json:"matchId"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_match_players_match_user;index" json:"userId"`
+	User      User      `gorm:"foreignKey:UserID;references:ID" json:"user"`
+	Status    string    `gorm:"type:varchar(20);not null" json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+}
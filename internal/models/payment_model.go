@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Payment split states. A collecting split is covered once every share is paid, which
+// confirms its booking. It lapses if its deadline passes first and is cancelled with
+// its booking; either way the payments collected are refunded.
+const (
+	SplitCollecting = "collecting"
+	SplitCovered    = "covered"
+	SplitLapsed     = "lapsed"
+	SplitCancelled  = "cancelled"
+)
+
+// Payment states.
+const (
+	PaymentSucceeded = "succeeded"
+	PaymentRefunded  = "refunded"
+)
+
+// PaymentSplit divides a booking's cost between its players. Amounts are in the
+// currency's minor unit.
+type PaymentSplit struct {
+	ID          uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	BookingID   uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex" json:"bookingId"`
+	Booking     Booking        `gorm:"foreignKey:BookingID;references:ID" json:"booking"`
+	OrganizerID uuid.UUID      `gorm:"type:uuid;not null;index" json:"organizerId"`
+	TotalAmount int64          `gorm:"type:bigint;not null" json:"totalAmount"`
+	Currency    string         `gorm:"type:varchar(3);not null" json:"currency"`
+	Deadline    time.Time      `gorm:"not null;index" json:"deadline"`
+	Status      string         `gorm:"type:varchar(20);not null;default:'collecting';index" json:"status"`
+	Shares      []PaymentShare `gorm:"foreignKey:SplitID;constraint:OnDelete:CASCADE;" json:"shares"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// Collecting reports whether the split still takes payments at now.
+func (s *PaymentSplit) Collecting(now time.Time) bool {
+	return s.Status == SplitCollecting && now.Before(s.Deadline)
+}
+
+// PaymentShare is what one player owes towards a split.
+type PaymentShare struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	SplitID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_payment_shares_split_user" json:"splitId"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_payment_shares_split_user;index" json:"userId"`
+	User      User       `gorm:"foreignKey:UserID;references:ID" json:"user"`
+	Amount    int64      `gorm:"type:bigint;not null" json:"amount"`
+	PaidAt    *time.Time `json:"paidAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// Payment is a successful charge for a share. Refunded payments are kept as a record.
+type Payment struct {
+	ID         uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ShareID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"shareId"`
+	SplitID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"splitId"`
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"userId"`
+	Amount     int64      `gorm:"type:bigint;not null" json:"amount"`
+	Currency   string     `gorm:"type:varchar(3);not null" json:"currency"`
+	Provider   string     `gorm:"type:varchar(50);not null" json:"provider"`
+	ChargeID   string     `gorm:"type:varchar(255);not null;uniqueIndex" json:"chargeId"`
+	Status     string     `gorm:"type:varchar(20);not null;index" json:"status"`
+	RefundID   string     `gorm:"type:varchar(255);not null;default:''" json:"refundId"`
+	RefundedAt *time.Time `json:"refundedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
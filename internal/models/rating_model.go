@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PlayerRating is a player's Glicko skill rating in one sport, updated from each
+// recorded match result. Players without one are rated as newcomers.
+type PlayerRating struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_player_ratings_user_sport" json:"userId"`
+	SportID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_player_ratings_user_sport;index" json:"sportId"`
+	Sport        Sports    `gorm:"foreignKey:SportID;references:ID" json:"sport"`
+	Rating       float64   `gorm:"type:double precision;not null" json:"rating"`
+	Deviation    float64   `gorm:"type:double precision;not null" json:"deviation"`
+	Games        int       `gorm:"type:int;not null;default:0" json:"games"`
+	LastPlayedAt time.Time `gorm:"not null" json:"lastPlayedAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sides of a played match. The organiser splits the players into two.
+const (
+	SideHome = "home"
+	SideAway = "away"
+)
+
+// Player outcomes.
+const (
+	OutcomeWon   = "won"
+	OutcomeDrawn = "drawn"
+	OutcomeLost  = "lost"
+)
+
+// MatchResult is the final score of a played match.
+type MatchResult struct {
+	ID           uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	MatchID      uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex" json:"matchId"`
+	HomeScore    int            `gorm:"type:int;not null" json:"homeScore"`
+	AwayScore    int            `gorm:"type:int;not null" json:"awayScore"`
+	RecordedByID uuid.UUID      `gorm:"type:uuid;not null" json:"recordedById"`
+	Players      []PlayerResult `gorm:"foreignKey:ResultID;constraint:OnDelete:CASCADE;" json:"players"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+// PlayerResult is one player's side, outcome and sport stats in a match. The sport,
+// turf and city are copied from the match so profiles and leaderboards aggregate
+// without joins; Points is the leaderboard score the outcome earned.
+type PlayerResult struct {
+	ID       uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ResultID uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_player_results_result_user" json:"resultId"`
+	UserID   uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_player_results_result_user;index" json:"userId"`
+	User     User           `gorm:"foreignKey:UserID;references:ID" json:"user"`
+	SportID  uuid.UUID      `gorm:"type:uuid;not null;index" json:"sportId"`
+	TurfID   uuid.UUID      `gorm:"type:uuid;not null;index" json:"turfId"`
+	City     string         `gorm:"type:varchar(255);not null;default:''" json:"city"`
+	Side     string         `gorm:"type:varchar(10);not null" json:"side"`
+	Outcome  string         `gorm:"type:varchar(10);not null" json:"outcome"`
+	Points   int            `gorm:"type:int;not null" json:"points"`
+	Stats    map[string]int `gorm:"type:jsonb;serializer:json;not null" json:"stats"`
+	PlayedAt time.Time      `gorm:"not null" json:"playedAt"`
+}
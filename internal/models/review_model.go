@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Review states. Hidden reviews are kept for moderation but left out of listings and
+// the turf's rating.
+const (
+	ReviewVisible = "visible"
+	ReviewHidden  = "hidden"
+)
+
+// TurfReview is a player's 1-5 rating of a turf they played at, with the owner's
+// optional reply.
+type TurfReview struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TurfID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_turf_reviews_turf_user" json:"turfId"`
+	Turf         Turf       `gorm:"foreignKey:TurfID;references:ID" json:"turf"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_turf_reviews_turf_user" json:"userId"`
+	User         User       `gorm:"foreignKey:UserID;references:ID" json:"user"`
+	Rating       int        `gorm:"type:int;not null" json:"rating"`
+	Body         string     `gorm:"type:text;not null;default:''" json:"body"`
+	Photos       []string   `gorm:"type:jsonb;serializer:json;not null" json:"photos"`
+	OwnerReply   string     `gorm:"type:text;not null;default:''" json:"ownerReply"`
+	RepliedAt    *time.Time `json:"repliedAt"`
+	Status       string     `gorm:"type:varchar(20);not null;default:'visible'" json:"status"`
+	HiddenReason string     `gorm:"type:varchar(500);not null;default:''" json:"hiddenReason"`
+	ReportCount  int        `gorm:"type:int;not null;default:0" json:"reportCount"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+// ReviewReport is one user's report of a review for moderation.
+type ReviewReport struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ReviewID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_review_reports_review_user" json:"reviewId"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_review_reports_review_user" json:"userId"`
+	Reason    string    `gorm:"type:varchar(500);not null;default:''" json:"reason"`
+	CreatedAt time.Time `json:"createdAt"`
+}
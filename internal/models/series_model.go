@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Booking series states. Cancelling a series cancels its remaining occurrences.
+const (
+	SeriesActive    = "active"
+	SeriesCancelled = "cancelled"
+)
+
+// BookingSeries books one field of a turf on every date of a weekly recurrence rule,
+// e.g. every Tuesday at 8pm until March. Each occurrence is a Booking that can be
+// cancelled or rescheduled on its own. Occurrences keep the wall-clock time of the
+// first in the turf's time zone.
+type BookingSeries struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TurfID          uuid.UUID  `gorm:"type:uuid;not null;index" json:"turfId"`
+	Turf            Turf       `gorm:"foreignKey:TurfID;references:ID" json:"turf"`
+	FieldNumber     int        `gorm:"type:int;not null" json:"fieldNumber"`
+	BookedByID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"bookedById"`
+	TeamID          *uuid.UUID `gorm:"type:uuid;index" json:"teamId"`
+	Team            *Team      `gorm:"foreignKey:TeamID;references:ID" json:"team,omitempty"`
+	RRule           string     `gorm:"column:rrule;type:varchar(255);not null" json:"rrule"`
+	StartsAt        time.Time  `gorm:"not null" json:"startsAt"`
+	DurationMinutes int        `gorm:"type:int;not null" json:"durationMinutes"`
+	Currency        string     `gorm:"type:varchar(3);not null" json:"currency"`
+	Status          string     `gorm:"type:varchar(20);not null;default:'active'" json:"status"`
+	Bookings        []Booking  `gorm:"foreignKey:SeriesID" json:"bookings"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// PricingRule sets a turf's hourly rate, in minor units of the payments currency, for
+// FromHour to ToHour on Days. Days are time.Weekday numbers (0 is Sunday); none means
+// every day. A turf's rules never overlap, and days and hours are read in the turf's
+// time zone.
+type PricingRule struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TurfID     uuid.UUID `gorm:"type:uuid;not null;index" json:"turfId"`
+	Name       string    `gorm:"type:varchar(100);not null;default:''" json:"name"`
+	Days       []int     `gorm:"type:jsonb;serializer:json;not null" json:"days"`
+	FromHour   int       `gorm:"type:int;not null" json:"fromHour"`
+	ToHour     int       `gorm:"type:int;not null" json:"toHour"`
+	HourlyRate int64     `gorm:"type:bigint;not null" json:"hourlyRate"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
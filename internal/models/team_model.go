@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Team member roles. Every team has exactly one captain.
+const (
+	TeamRoleCaptain = "captain"
+	TeamRoleMember  = "member"
+)
+
+// Invitation states. Only pending invitations can be answered.
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+)
+
+// Team is a squad that plays one sport. Its size is bounded by the sport's
+// MaxPlayers, and it can book a field once it has at least MinPlayers members.
+type Team struct {
+	ID        uuid.UUID    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name      string       `gorm:"type:varchar(100);not null" json:"name"`
+	SportID   uuid.UUID    `gorm:"type:uuid;not null;index" json:"sportId"`
+	Sport     Sports       `gorm:"foreignKey:SportID;references:ID" json:"sport"`
+	CaptainID uuid.UUID    `gorm:"type:uuid;not null;index" json:"captainId"`
+	Members   []TeamMember `gorm:"constraint:OnDelete:CASCADE;" json:"members"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+type TeamMember struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TeamID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_team_members_team_user" json:"teamId"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_team_members_team_user;index" json:"userId"`
+	User      User      `gorm:"foreignKey:UserID;references:ID" json:"user"`
+	Role      string    `gorm:"type:varchar(20);not null;default:'member'" json:"role"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TeamInvitation invites someone to a team by email or phone. The invitee need not
+// have an account yet; they answer once signed in with the matching email or phone.
+type TeamInvitation struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TeamID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"teamId"`
+	Team        Team       `gorm:"foreignKey:TeamID;references:ID;constraint:OnDelete:CASCADE;" json:"team"`
+	InvitedByID uuid.UUID  `gorm:"type:uuid;not null" json:"invitedById"`
+	Email       string     `gorm:"type:varchar(255);not null;default:'';index" json:"email"`
+	Phone       string     `gorm:"type:varchar(15);not null;default:'';index" json:"phone"`
+	Status      string     `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	ExpiresAt   time.Time  `gorm:"not null" json:"expiresAt"`
+	RespondedAt *time.Time `json:"respondedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tournament formats. A group+knockout tournament plays a round robin within each group
+// and sends the top AdvancePerGroup teams of every group into a knockout bracket.
+const (
+	FormatKnockout      = "knockout"
+	FormatRoundRobin    = "round_robin"
+	FormatGroupKnockout = "group_knockout"
+)
+
+// Tournament states. Teams register until the organiser schedules the fixtures.
+const (
+	TournamentRegistration = "registration"
+	TournamentScheduled    = "scheduled"
+	TournamentInProgress   = "in_progress"
+	TournamentCompleted    = "completed"
+	TournamentCancelled    = "cancelled"
+)
+
+// Fixture stages: league fixtures make up a round robin, group fixtures the group stage
+// of a group+knockout tournament.
+const (
+	StageLeague   = "league"
+	StageGroup    = "group"
+	StageKnockout = "knockout"
+)
+
+// Fixture states.
+const (
+	FixtureScheduled = "scheduled"
+	FixtureCompleted = "completed"
+	FixtureCancelled = "cancelled"
+)
+
+// Tournament is a competition between teams of one sport, played on the fields of the
+// organiser's turf. Fixtures kick off no earlier than StartsAt and last MatchMinutes,
+// with BreakMinutes between matches on a field, within the turf's opening hours in its
+// time zone.
+type Tournament struct {
+	ID              uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name            string           `gorm:"type:varchar(100);not null" json:"name"`
+	SportID         uuid.UUID        `gorm:"type:uuid;not null;index" json:"sportId"`
+	Sport           Sports           `gorm:"foreignKey:SportID;references:ID" json:"sport"`
+	TurfID          uuid.UUID        `gorm:"type:uuid;not null;index" json:"turfId"`
+	Turf            Turf             `gorm:"foreignKey:TurfID;references:ID" json:"turf"`
+	OrganizerID     uuid.UUID        `gorm:"type:uuid;not null;index" json:"organizerId"`
+	Format          string           `gorm:"type:varchar(20);not null" json:"format"`
+	Status          string           `gorm:"type:varchar(20);not null;default:'registration';index" json:"status"`
+	StartsAt        time.Time        `gorm:"not null" json:"startsAt"`
+	MatchMinutes    int              `gorm:"type:int;not null" json:"matchMinutes"`
+	BreakMinutes    int              `gorm:"type:int;not null;default:0" json:"breakMinutes"`
+	MaxTeams        int              `gorm:"type:int;not null" json:"maxTeams"`
+	GroupCount      int              `gorm:"type:int;not null;default:0" json:"groupCount"`
+	AdvancePerGroup int              `gorm:"type:int;not null;default:0" json:"advancePerGroup"`
+	ChampionID      *uuid.UUID       `gorm:"type:uuid" json:"championId"`
+	Champion        *Team            `gorm:"foreignKey:ChampionID;references:ID" json:"champion,omitempty"`
+	Teams           []TournamentTeam `gorm:"constraint:OnDelete:CASCADE;" json:"teams"`
+	CreatedAt       time.Time        `json:"createdAt"`
+	UpdatedAt       time.Time        `json:"updatedAt"`
+}
+
+// TournamentTeam is a registered team. Seed follows registration order; GroupNumber is
+// assigned when a group+knockout tournament is scheduled.
+type TournamentTeam struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TournamentID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_tournament_teams_tournament_team" json:"tournamentId"`
+	TeamID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_tournament_teams_tournament_team;index" json:"teamId"`
+	Team         Team      `gorm:"foreignKey:TeamID;references:ID" json:"team"`
+	Seed         int       `gorm:"type:int;not null" json:"seed"`
+	GroupNumber  int       `gorm:"type:int;not null;default:0" json:"groupNumber"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Fixture is one tournament match on a booked field. Knockout fixtures are generated
+// for the whole bracket up front: a side is filled when the team feeding it is known,
+// either the winner of fixture Slot*2 or Slot*2+1 of the previous round or, when
+// HomeSeed/AwaySeed is set, the group qualifier with that seed.
+type Fixture struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TournamentID uuid.UUID  `gorm:"type:uuid;not null;index:idx_fixtures_tournament_stage_round" json:"tournamentId"`
+	Stage        string     `gorm:"type:varchar(20);not null;index:idx_fixtures_tournament_stage_round" json:"stage"`
+	Round        int        `gorm:"type:int;not null;index:idx_fixtures_tournament_stage_round" json:"round"`
+	GroupNumber  int        `gorm:"type:int;not null;default:0" json:"groupNumber"`
+	Slot         int        `gorm:"type:int;not null" json:"slot"`
+	HomeTeamID   *uuid.UUID `gorm:"type:uuid" json:"homeTeamId"`
+	HomeTeam     *Team      `gorm:"foreignKey:HomeTeamID;references:ID" json:"homeTeam,omitempty"`
+	AwayTeamID   *uuid.UUID `gorm:"type:uuid" json:"awayTeamId"`
+	AwayTeam     *Team      `gorm:"foreignKey:AwayTeamID;references:ID" json:"awayTeam,omitempty"`
+	HomeSeed     int        `gorm:"type:int;not null;default:0" json:"homeSeed"`
+	AwaySeed     int        `gorm:"type:int;not null;default:0" json:"awaySeed"`
+	HomeScore    *int       `gorm:"type:int" json:"homeScore"`
+	AwayScore    *int       `gorm:"type:int" json:"awayScore"`
+	WinnerID     *uuid.UUID `gorm:"type:uuid" json:"winnerId"`
+	BookingID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"bookingId"`
+	FieldNumber  int        `gorm:"type:int;not null" json:"fieldNumber"`
+	StartsAt     time.Time  `gorm:"not null" json:"startsAt"`
+	EndsAt       time.Time  `gorm:"not null" json:"endsAt"`
+	Status       string     `gorm:"type:varchar(20);not null;default:'scheduled'" json:"status"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
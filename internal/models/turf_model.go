@@ -1,11 +1,20 @@
 package models
 
 import (
+	"sync"
 	"time"
+	// Turf time zones must load wherever the binary runs, with or without tzdata.
+	_ "time/tzdata"
 
 	"github.com/google/uuid"
 )
 
+// Coordinate sources recorded on a turf.
+const (
+	CoordinateSourceManual   = "manual"
+	CoordinateSourceGeocoded = "geocoded"
+)
+
 type Turf struct {
 	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
 	Name       string    `gorm:"type:varchar(255);not null" json:"name"`
@@ -15,8 +24,25 @@ type Turf struct {
 	NoOfFields int       `gorm:"type:int;not null" json:"noOfFields"`
 	Address    string    `gorm:"type:varchar(255);not null;default:''" json:"address"`
 	TurfImages []string  `gorm:"column:turf_images;type:jsonb;serializer:json;not null" json:"turfImages"`
-	Longitude  float64   `gorm:"type:double precision;not null;default:0" json:"longitude"`
-	Latitude   float64   `gorm:"type:double precision;not null;default:0" json:"latitude"`
+	Longitude  float64   `gorm:"type:double precision;not null;default:0;index:idx_turves_latitude_longitude,priority:2" json:"longitude"`
+	Latitude   float64   `gorm:"type:double precision;not null;default:0;index:idx_turves_latitude_longitude,priority:1" json:"latitude"`
+
+	// CoordinateSource is "manual" when the owner dropped a pin, "geocoded" when resolved via LocationIQ.
+	CoordinateSource string `gorm:"type:varchar(20);not null;default:'geocoded'" json:"coordinateSource"`
+	City             string `gorm:"type:varchar(255);not null;default:''" json:"city"`
+	State            string `gorm:"type:varchar(255);not null;default:''" json:"state"`
+	Postcode         string `gorm:"type:varchar(20);not null;default:''" json:"postcode"`
+
+	// TimeZone is the IANA time zone the turf's opening hours, prices and recurring
+	// bookings are read in.
+	TimeZone string `gorm:"type:varchar(64);not null;default:'Asia/Karachi'" json:"timeZone"`
+
+	// RatingAverage and RatingCount summarise the turf's visible reviews.
+	RatingAverage float64 `gorm:"type:double precision;not null;default:0;index:idx_turves_rating,priority:1,sort:desc" json:"ratingAverage"`
+	RatingCount   int     `gorm:"type:int;not null;default:0;index:idx_turves_rating,priority:2,sort:desc" json:"ratingCount"`
+
+	// Hours overrides StartTime and EndTime on some weekdays.
+	Hours []TurfHours `gorm:"foreignKey:TurfID;constraint:OnDelete:CASCADE" json:"hours"`
 
 	// Relationship: Turf belongs to a User (Owner/Admin)
 	OwnerID uuid.UUID `gorm:"type:uuid;not null" json:"ownerId"`
@@ -25,3 +51,19 @@ type Turf struct {
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
+
+// locations caches loaded time zones by name.
+var locations sync.Map
+
+// Location returns the turf's time zone, or UTC if its name does not load.
+func (t *Turf) Location() *time.Location {
+	if loc, ok := locations.Load(t.TimeZone); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(t.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	locations.Store(t.TimeZone, loc)
+	return loc
+}
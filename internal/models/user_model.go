@@ -6,6 +6,13 @@ import (
 	"github.com/google/uuid"
 )
 
+// User roles. The role is copied into the JWT so handlers can authorise without a lookup.
+const (
+	RolePlayer = "player"
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+)
+
 type User struct {
 	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
 	Name       string    `gorm:"type:varchar(255);not null" json:"name"`
@@ -13,11 +20,16 @@ type User struct {
 	Password   string    `gorm:"type:varchar(255);not null" json:"-"`
 	Role       string    `gorm:"type:varchar(50);not null;default:player" json:"role"`
 	IsActive   bool      `gorm:"type:boolean;default:true" json:"is_active"`
-	Cnic       string    `gorm:"type:varchar(255)" json:"cnic"`
+	Cnic       string    `gorm:"type:varchar(255)" json:"-"`
 	Gender     string    `gorm:"type:varchar(10);not null" json:"gender"`
 	Phone      string    `gorm:"type:varchar(15);unique;not null" json:"phone"`
 	IsVerified bool      `gorm:"type:boolean;default:false" json:"is_verified"`
 
+	// Login protection: consecutive failures drive progressive delays and lockout.
+	FailedLoginAttempts int        `gorm:"type:int;not null;default:0" json:"-"`
+	LastFailedLoginAt   *time.Time `json:"-"`
+	LockedUntil         *time.Time `json:"-"`
+
 	// Relations
 	Location  Location  `gorm:"constraint:OnDelete:CASCADE;" json:"location"`
 	CreatedAt time.Time `json:"created_at"`
@@ -0,0 +1,111 @@
+// Package notifications delivers user-facing messages. Messages are enqueued on SQS and
+// sent by the worker pool, so request handlers never block on SMTP.
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/musishere/sportsApp/internal/helpers"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/queue"
+	"github.com/musishere/sportsApp/internal/utils"
+	"github.com/musishere/sportsApp/types"
+)
+
+type Notifier struct {
+	client   *sqs.Client
+	queueURL string
+	mailer   *helpers.Mailer
+	sms      *utils.SMSSender
+}
+
+// NewNotifier returns a notifier that enqueues on queueURL. With no queue configured,
+// messages are sent in the background from the calling process instead.
+func NewNotifier(client *sqs.Client, queueURL string, mailer *helpers.Mailer, sms *utils.SMSSender) *Notifier {
+	return &Notifier{
+		client:   client,
+		queueURL: queueURL,
+		mailer:   mailer,
+		sms:      sms,
+	}
+}
+
+// SendEmail queues a plain-text email for delivery.
+func (n *Notifier) SendEmail(ctx context.Context, to, subject, body string) error {
+	if n.client == nil || n.queueURL == "" {
+		logger := logging.FromContext(ctx)
+		// Detach from the request so the send is not cancelled when the response is written.
+		sendCtx := context.WithoutCancel(ctx)
+		go func() {
+			if err := n.mailer.Send(sendCtx, to, subject, body); err != nil {
+				logger.Error("notification email failed", slog.String("subject", subject), logging.Err(err))
+			}
+		}()
+		return nil
+	}
+
+	job := types.Job{
+		JobType: types.JobTypeSendEmail,
+		Payload: map[string]interface{}{"to": to, "subject": subject, "body": body},
+	}
+	if _, err := queue.Send(ctx, n.client, n.queueURL, job); err != nil {
+		return fmt.Errorf("enqueue email: %w", err)
+	}
+	return nil
+}
+
+// SendSMS queues a text message for delivery.
+func (n *Notifier) SendSMS(ctx context.Context, phone, message string) error {
+	if n.client == nil || n.queueURL == "" {
+		logger := logging.FromContext(ctx)
+		sendCtx := context.WithoutCancel(ctx)
+		go func() {
+			if err := n.sms.Send(sendCtx, phone, message); err != nil {
+				logger.Error("notification sms failed", logging.Err(err))
+			}
+		}()
+		return nil
+	}
+
+	job := types.Job{
+		JobType: types.JobTypeSendSMS,
+		Payload: map[string]interface{}{"phone": phone, "message": message},
+	}
+	if _, err := queue.Send(ctx, n.client, n.queueURL, job); err != nil {
+		return fmt.Errorf("enqueue sms: %w", err)
+	}
+	return nil
+}
+
+// Handlers returns the queue handlers that deliver notification jobs.
+func (n *Notifier) Handlers() map[string]queue.Handler {
+	return map[string]queue.Handler{
+		types.JobTypeSendEmail: n.handleSendEmail,
+		types.JobTypeSendSMS:   n.handleSendSMS,
+	}
+}
+
+func (n *Notifier) handleSendEmail(ctx context.Context, job types.Job) error {
+	to, _ := job.Payload["to"].(string)
+	subject, _ := job.Payload["subject"].(string)
+	body, _ := job.Payload["body"].(string)
+	if to == "" {
+		// Malformed jobs would fail forever; drop them instead of retrying.
+		logging.FromContext(ctx).WarnContext(ctx, "dropping send_email job without recipient")
+		return nil
+	}
+	return n.mailer.Send(ctx, to, subject, body)
+}
+
+func (n *Notifier) handleSendSMS(ctx context.Context, job types.Job) error {
+	phone, _ := job.Payload["phone"].(string)
+	message, _ := job.Payload["message"].(string)
+	if phone == "" {
+		logging.FromContext(ctx).WarnContext(ctx, "dropping send_sms job without recipient")
+		return nil
+	}
+	return n.sms.Send(ctx, phone, message)
+}
@@ -3,34 +3,45 @@ package oauth
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
 
+	"github.com/musishere/sportsApp/config"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/facebook"
 )
 
-// Config example (fill these with your values)
-var OAuthConfig = &oauth2.Config{
-	ClientID:     "YOUR_FACEBOOK_APP_ID",
-	ClientSecret: "YOUR_FACEBOOK_APP_SECRET",
-	RedirectURL:  "http://localhost:8080/oauth/callback",
-	Scopes:       []string{"email", "public_profile"},
-	Endpoint:     facebook.Endpoint,
+// Facebook exchanges OAuth codes for Facebook user info.
+type Facebook struct {
+	oauthConfig *oauth2.Config
+}
+
+func NewFacebook(cfg config.FacebookConfig) *Facebook {
+	return &Facebook{
+		oauthConfig: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       []string{"email", "public_profile"},
+			Endpoint:     facebook.Endpoint,
+		},
+	}
 }
 
 // ConnectToFacebook exchanges a code for an access token and returns a message or error
-func ConnectToFacebook(code string) (string, error) {
-	ctx := context.Background()
+func (f *Facebook) ConnectToFacebook(ctx context.Context, code string) (string, error) {
+	// Trace the token exchange and Graph API calls made by the oauth2 client
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)})
 
 	// Exchange code for access token
-	token, err := OAuthConfig.Exchange(ctx, code)
+	token, err := f.oauthConfig.Exchange(ctx, code)
 	if err != nil {
 		return "", fmt.Errorf("failed to exchange code for token: %w", err)
 	}
 
 	// Use token to fetch user info from Facebook
-	client := OAuthConfig.Client(ctx, token)
+	client := f.oauthConfig.Client(ctx, token)
 	resp, err := client.Get("https://graph.facebook.com/me?fields=id,name,email")
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch user info: %w", err)
@@ -41,7 +52,7 @@ func ConnectToFacebook(code string) (string, error) {
 		return "", fmt.Errorf("Facebook API returned status %d", resp.StatusCode)
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", fmt.Errorf("failed to read response: %w", err)
 	}
@@ -0,0 +1,78 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Tokens the fake provider treats specially; any other non-empty token succeeds.
+const (
+	FakeTokenDeclined = "tok_declined"
+	FakeTokenError    = "tok_error"
+)
+
+// FakeProvider is an in-memory provider for local development and tests. It never
+// moves money.
+type FakeProvider struct {
+	mu      sync.Mutex
+	charges map[string]fakeCharge
+	keys    map[string]string
+}
+
+type fakeCharge struct {
+	amount   int64
+	refundID string
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{charges: make(map[string]fakeCharge), keys: make(map[string]string)}
+}
+
+func (p *FakeProvider) Name() string { return "fake" }
+
+func (p *FakeProvider) Charge(_ context.Context, req ChargeRequest) (string, error) {
+	switch req.Token {
+	case "", FakeTokenDeclined:
+		return "", ErrDeclined
+	case FakeTokenError:
+		return "", fmt.Errorf("fake provider: simulated outage")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if id, ok := p.keys[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+		return id, nil
+	}
+	id := "ch_" + uuid.NewString()
+	p.charges[id] = fakeCharge{amount: req.Amount}
+	if req.IdempotencyKey != "" {
+		p.keys[req.IdempotencyKey] = id
+	}
+	return id, nil
+}
+
+func (p *FakeProvider) Refund(_ context.Context, chargeID string, amount int64) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Charges made before a restart are forgotten; refund them without checks so a
+	// restarted dev server can still settle old payments.
+	charge, ok := p.charges[chargeID]
+	if ok && amount > charge.amount {
+		return "", fmt.Errorf("fake provider: refund of %d exceeds charge of %d", amount, charge.amount)
+	}
+	if charge.refundID == "" {
+		charge.refundID = "re_" + uuid.NewString()
+		p.charges[chargeID] = charge
+	}
+	return charge.refundID, nil
+}
+
+// Refunded reports whether a charge this provider made has been refunded.
+func (p *FakeProvider) Refunded(chargeID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.charges[chargeID].refundID != ""
+}
@@ -0,0 +1,45 @@
+// Package payments charges players and refunds them through a payment provider.
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/musishere/sportsApp/config"
+)
+
+// ErrDeclined is returned when the provider refuses a charge, e.g. for insufficient
+// funds. The charge did not happen and can be retried with another payment method.
+var ErrDeclined = errors.New("payment declined")
+
+// ChargeRequest takes Amount, in the currency's minor unit, from the payment method
+// the client tokenised with the provider. IdempotencyKey makes retries safe.
+type ChargeRequest struct {
+	Amount         int64
+	Currency       string
+	Token          string
+	Description    string
+	IdempotencyKey string
+}
+
+// Provider is a payment gateway.
+type Provider interface {
+	// Name is recorded on each payment so refunds go back through the same provider.
+	Name() string
+	// Charge returns the provider's charge ID.
+	Charge(ctx context.Context, req ChargeRequest) (string, error)
+	// Refund returns the provider's refund ID. Refunding a charge twice is a no-op
+	// that returns the original refund ID.
+	Refund(ctx context.Context, chargeID string, amount int64) (string, error)
+}
+
+// NewProvider returns the configured provider.
+func NewProvider(cfg config.PaymentsConfig) (Provider, error) {
+	switch cfg.Provider {
+	case config.PaymentProviderFake:
+		return NewFakeProvider(), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment provider %q", cfg.Provider)
+	}
+}
@@ -2,15 +2,14 @@ package queue
 
 import (
 	"context"
-	"os"
 
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 )
 
-func NewClient(ctx context.Context) (*sqs.Client, error) {
+func NewClient(ctx context.Context, region string) (*sqs.Client, error) {
 	cfg, err := config.LoadDefaultConfig(ctx,
-		config.WithRegion(os.Getenv("AWS_REGION")),
+		config.WithRegion(region),
 	)
 	if err != nil {
 		return nil, err
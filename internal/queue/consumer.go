@@ -7,30 +7,40 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/musishere/sportsApp/types"
 )
 
 var ErrNoMessages = errors.New("no messages in queue")
 
-func Receive(ctx context.Context, client *sqs.Client, queueURL string) (types.Job, string, error) {
+// Message is a received job with the metadata needed to acknowledge it and to continue
+// the trace that enqueued it.
+type Message struct {
+	Job           types.Job
+	ReceiptHandle string
+	Attributes    map[string]sqstypes.MessageAttributeValue
+}
+
+func Receive(ctx context.Context, client *sqs.Client, queueURL string) (Message, error) {
 	resp, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
-		QueueUrl:            aws.String(queueURL),
-		MaxNumberOfMessages: 1,
-		WaitTimeSeconds:     20, // long polling
+		QueueUrl:              aws.String(queueURL),
+		MaxNumberOfMessages:   1,
+		WaitTimeSeconds:       20, // long polling
+		MessageAttributeNames: []string{"All"},
 	})
 	if err != nil {
-		return types.Job{}, "", err
+		return Message{}, err
 	}
 
 	if len(resp.Messages) == 0 {
-		return types.Job{}, "", ErrNoMessages
+		return Message{}, ErrNoMessages
 	}
 
 	msg := resp.Messages[0]
 	var job types.Job
 	if err := json.Unmarshal([]byte(*msg.Body), &job); err != nil {
-		return types.Job{}, "", err
+		return Message{}, err
 	}
 
-	return job, *msg.ReceiptHandle, nil
+	return Message{Job: job, ReceiptHandle: *msg.ReceiptHandle, Attributes: msg.MessageAttributes}, nil
 }
@@ -6,19 +6,43 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/metrics"
+	"github.com/musishere/sportsApp/internal/tracing"
 	"github.com/musishere/sportsApp/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func Send(ctx context.Context, client *sqs.Client, queueURL string, job types.Job) (string, error) {
+func Send(ctx context.Context, client *sqs.Client, queueURL string, job types.Job) (_ string, err error) {
+	ctx, span := tracing.Tracer("queue").Start(ctx, "queue send "+job.JobType,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "aws_sqs"),
+			attribute.String("messaging.operation.type", "send"),
+			attribute.String("job.type", job.JobType),
+		),
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	if job.RequestID == "" {
+		job.RequestID = logging.RequestID(ctx)
+	}
 	body, err := json.Marshal(job)
 	if err != nil {
 		return "Error sending the message to the queue", err
 	}
 
+	// The trace context travels as message attributes so the worker continues this trace.
 	_, err = client.SendMessage(ctx, &sqs.SendMessageInput{
-		QueueUrl:    aws.String(queueURL),
-		MessageBody: aws.String(string(body)),
+		QueueUrl:          aws.String(queueURL),
+		MessageBody:       aws.String(string(body)),
+		MessageAttributes: injectTraceContext(ctx),
 	})
+	metrics.ObserveEnqueue(job.JobType, err)
 
 	return "Message sent to the queue", err
 }
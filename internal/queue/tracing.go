@@ -0,0 +1,44 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"go.opentelemetry.io/otel"
+)
+
+// attributeCarrier lets the OpenTelemetry propagator read and write trace context
+// (traceparent, tracestate, baggage) as SQS string message attributes.
+type attributeCarrier map[string]sqstypes.MessageAttributeValue
+
+func (c attributeCarrier) Get(key string) string {
+	if v, ok := c[key]; ok && v.StringValue != nil {
+		return *v.StringValue
+	}
+	return ""
+}
+
+func (c attributeCarrier) Set(key, value string) {
+	c[key] = sqstypes.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(value)}
+}
+
+func (c attributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// injectTraceContext returns message attributes carrying the trace context of ctx.
+func injectTraceContext(ctx context.Context) map[string]sqstypes.MessageAttributeValue {
+	carrier := attributeCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
+
+// extractTraceContext returns ctx continuing the trace carried by the message attributes.
+func extractTraceContext(ctx context.Context, attrs map[string]sqstypes.MessageAttributeValue) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, attributeCarrier(attrs))
+}
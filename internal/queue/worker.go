@@ -2,35 +2,90 @@ package queue
 
 import (
 	"context"
-	"log"
+	"errors"
+	"log/slog"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/metrics"
+	"github.com/musishere/sportsApp/internal/tracing"
+	"github.com/musishere/sportsApp/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func StartWorkerPool(ctx context.Context, client *sqs.Client, queueURL string, numWorkers int) {
+// Handler processes one job. Returning an error leaves the message on the queue so SQS
+// redelivers it after the visibility timeout.
+type Handler func(ctx context.Context, job types.Job) error
+
+func StartWorkerPool(ctx context.Context, client *sqs.Client, queueURL string, numWorkers int, handlers map[string]Handler) {
 	for i := 0; i < numWorkers; i++ {
 		go func(workerID int) {
+			logger := logging.FromContext(ctx).With(slog.Int("worker", workerID))
 			for {
 				select {
 				case <-ctx.Done():
-					log.Printf("Worker %d shutting down", workerID)
+					logger.Info("worker shutting down")
 					return
 				default:
-					job, receipt, err := Receive(ctx, client, queueURL)
+					msg, err := Receive(ctx, client, queueURL)
 					if err != nil {
-						if err == ErrNoMessages {
+						if errors.Is(err, ErrNoMessages) || ctx.Err() != nil {
 							continue
 						}
-						log.Println("Receive error:", err)
+						metrics.ObserveReceiveError()
+						logger.Error("queue receive failed", logging.Err(err))
 						continue
 					}
 
-					// Process job
-					log.Println("Worker", workerID, "processing job:", job.JobType)
-					// call some service to handle job
-					_ = Delete(ctx, client, queueURL, receipt)
+					processMessage(ctx, logger, client, queueURL, msg, handlers)
 				}
 			}
 		}(i)
 	}
 }
+
+// processMessage runs the handler for one message inside a consumer span that continues
+// the producer's trace, then deletes the message unless the handler failed.
+func processMessage(ctx context.Context, logger *slog.Logger, client *sqs.Client, queueURL string, msg Message, handlers map[string]Handler) {
+	job, receipt := msg.Job, msg.ReceiptHandle
+
+	jobCtx, span := tracing.Tracer("queue").Start(extractTraceContext(ctx, msg.Attributes), "queue process "+job.JobType,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "aws_sqs"),
+			attribute.String("messaging.operation.type", "process"),
+			attribute.String("job.type", job.JobType),
+		),
+	)
+	defer span.End()
+
+	// Continue the originating request's logs in the job
+	jobLogger := logger.With(slog.String("job_type", job.JobType))
+	jobCtx = logging.WithLogger(jobCtx, jobLogger)
+	if job.RequestID != "" {
+		jobCtx = logging.WithRequestID(jobCtx, job.RequestID)
+		jobLogger = logging.FromContext(jobCtx)
+	}
+
+	// Process job
+	jobLogger.DebugContext(jobCtx, "processing job")
+	handler, ok := handlers[job.JobType]
+	if !ok {
+		jobLogger.WarnContext(jobCtx, "no handler for job type, dropping")
+		// Unknown types share one label so bad messages cannot add series.
+		metrics.ObserveJob("unknown", metrics.OutcomeDropped, 0)
+		_ = Delete(ctx, client, queueURL, receipt)
+		return
+	}
+	start := time.Now()
+	if err := handler(jobCtx, job); err != nil {
+		tracing.RecordError(span, err)
+		metrics.ObserveJob(job.JobType, metrics.OutcomeFailure, time.Since(start))
+		jobLogger.ErrorContext(jobCtx, "job failed", logging.Err(err))
+		return
+	}
+	metrics.ObserveJob(job.JobType, metrics.OutcomeSuccess, time.Since(start))
+	_ = Delete(ctx, client, queueURL, receipt)
+}
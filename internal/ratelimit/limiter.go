@@ -0,0 +1,162 @@
+// Package ratelimit implements a distributed fixed-window rate limiter stored in Redis,
+// so limits are shared across instances and survive process restarts.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/cache"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/middleware"
+)
+
+const keyPrefix = "ratelimit"
+
+// incrScript increments the window counter, starting the window TTL on first hit,
+// and returns the new count with the remaining TTL in milliseconds.
+var incrScript = redis.NewScript(`
+local current = redis.call("INCR", KEYS[1])
+if current == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+local ttl = redis.call("PTTL", KEYS[1])
+if ttl < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {current, ttl}
+`)
+
+// Result describes the state of a client's window after a request.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Reset     time.Duration
+}
+
+type Limiter struct {
+	rdb       *cache.Client
+	allowlist []*net.IPNet
+}
+
+// NewLimiter builds a limiter backed by rdb. allowlist holds IPs or CIDRs of internal
+// callers that are never limited.
+func NewLimiter(rdb *cache.Client, allowlist []string) (*Limiter, error) {
+	nets := make([]*net.IPNet, 0, len(allowlist))
+	for _, entry := range allowlist {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			if ip := net.ParseIP(entry); ip != nil && ip.To4() != nil {
+				entry += "/32"
+			} else {
+				entry += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit allowlist entry %q: %w", entry, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return &Limiter{rdb: rdb, allowlist: nets}, nil
+}
+
+// Allow counts one request for key under policy.
+func (l *Limiter) Allow(ctx context.Context, policy Policy, key string) (Result, error) {
+	redisKey := fmt.Sprintf("%s:%s:%s", keyPrefix, policy.Name, key)
+	res, err := incrScript.Run(ctx, l.rdb, []string{redisKey}, policy.Window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	count, ttl := int(res[0]), time.Duration(res[1])*time.Millisecond
+	remaining := policy.Limit - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	return Result{
+		Allowed:   count <= policy.Limit,
+		Limit:     policy.Limit,
+		Remaining: remaining,
+		Reset:     ttl,
+	}, nil
+}
+
+func (l *Limiter) allowlisted(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, n := range l.allowlist {
+		if n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// exempt reports whether the caller is on the allowlist. Internal callers connect
+// directly, so only the connection's address counts; a forwarded one never does.
+func (l *Limiter) exempt(c *gin.Context) bool {
+	return l.allowlisted(c.RemoteIP())
+}
+
+// clientKey identifies the caller: the authenticated user when signed in, else the IP.
+// ClientIP only follows X-Forwarded-For from the engine's trusted proxies.
+func clientKey(c *gin.Context) string {
+	if claims, ok := middleware.CurrentUser(c); ok {
+		return "user:" + claims.UserID.String()
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// Middleware enforces policy on a route and sets the RateLimit-* headers. Requests are
+// let through if Redis is unavailable so an outage does not take the API down.
+func (l *Limiter) Middleware(policy Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if l.exempt(c) {
+			c.Next()
+			return
+		}
+
+		// Skip the round trip entirely while Redis is known to be down.
+		if !l.rdb.Available() {
+			c.Next()
+			return
+		}
+
+		result, err := l.Allow(c.Request.Context(), policy, clientKey(c))
+		if err != nil {
+			ctx := c.Request.Context()
+			logging.FromContext(ctx).WarnContext(ctx, "rate limiter unavailable, allowing request", logging.Err(err))
+			c.Next()
+			return
+		}
+
+		resetSeconds := int((result.Reset + time.Second - 1) / time.Second)
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(resetSeconds))
+		c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, int(policy.Window.Seconds())))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(resetSeconds))
+			_ = c.Error(apperrors.RateLimited("rate limit exceeded, retry later"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// serve sends a request from remoteAddr with the given X-Forwarded-For through an
+// engine trusting proxies, and returns the key and exemption the limiter saw.
+func serve(t *testing.T, l *Limiter, proxies []string, remoteAddr, forwarded string) (key string, exempt bool) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	if err := engine.SetTrustedProxies(proxies); err != nil {
+		t.Fatalf("trusted proxies: %v", err)
+	}
+	engine.GET("/", func(c *gin.Context) {
+		key, exempt = clientKey(c), l.exempt(c)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	if forwarded != "" {
+		req.Header.Set("X-Forwarded-For", forwarded)
+	}
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+	return key, exempt
+}
+
+func TestClientKeyIgnoresForgedForwardedFor(t *testing.T) {
+	l, err := NewLimiter(nil, []string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("limiter: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		proxies    []string
+		remoteAddr string
+		forwarded  string
+		wantKey    string
+		wantExempt bool
+	}{
+		{"direct", nil, "203.0.113.7:4000", "", "ip:203.0.113.7", false},
+		{"forged address", nil, "203.0.113.7:4000", "198.51.100.1", "ip:203.0.113.7", false},
+		{"forged allowlisted address", nil, "203.0.113.7:4000", "10.0.0.1", "ip:203.0.113.7", false},
+		{"untrusted proxy", []string{"192.0.2.0/24"}, "203.0.113.7:4000", "198.51.100.1", "ip:203.0.113.7", false},
+		{"trusted proxy", []string{"192.0.2.0/24"}, "192.0.2.10:4000", "198.51.100.1", "ip:198.51.100.1", false},
+		{"allowlisted through trusted proxy", []string{"192.0.2.0/24"}, "192.0.2.10:4000", "10.0.0.1", "ip:10.0.0.1", false},
+		{"internal caller", nil, "10.0.0.1:4000", "", "ip:10.0.0.1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, exempt := serve(t, l, tt.proxies, tt.remoteAddr, tt.forwarded)
+			if key != tt.wantKey {
+				t.Errorf("key = %q, want %q", key, tt.wantKey)
+			}
+			if exempt != tt.wantExempt {
+				t.Errorf("exempt = %v, want %v", exempt, tt.wantExempt)
+			}
+		})
+	}
+
+	// Varying the header must not give an anonymous caller fresh windows.
+	first, _ := serve(t, l, nil, "203.0.113.7:4000", "198.51.100.1")
+	second, _ := serve(t, l, nil, "203.0.113.7:4000", "198.51.100.2")
+	if first != second {
+		t.Errorf("keys differ across forged headers: %q and %q", first, second)
+	}
+}
@@ -0,0 +1,18 @@
+package ratelimit
+
+import "time"
+
+// Policy is a fixed-window limit applied per client (authenticated user or IP).
+type Policy struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// Route policies: strict on credential and OTP endpoints, generous on reads.
+var (
+	PolicyAuth  = Policy{Name: "auth", Limit: 10, Window: time.Minute}
+	PolicyOTP   = Policy{Name: "otp", Limit: 5, Window: 10 * time.Minute}
+	PolicyRead  = Policy{Name: "read", Limit: 300, Window: time.Minute}
+	PolicyWrite = Policy{Name: "write", Limit: 60, Window: time.Minute}
+)
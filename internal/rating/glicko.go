@@ -0,0 +1,110 @@
+// Package rating implements Glicko-1 skill ratings. A rating is a value and a
+// deviation: how uncertain the value is. The deviation shrinks as a player plays and
+// grows back while they are idle, so results move new and returning players further
+// than settled ones.
+//
+// Team games are rated player by player against a composite of the opposing side.
+package rating
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// Initial is a new player's rating.
+	Initial = 1500.0
+	// InitialDeviation is a new player's deviation, and the most it can grow back to.
+	InitialDeviation = 350.0
+	// MinDeviation keeps a settled rating responsive to new results.
+	MinDeviation = 30.0
+	// decayPerDay is how much the squared deviation grows per idle day; a settled
+	// deviation of 50 returns to InitialDeviation after about a year.
+	decayPerDay = 18.1 * 18.1
+)
+
+// Scores for a result, from the rated player's side.
+const (
+	Win  = 1.0
+	Draw = 0.5
+	Loss = 0.0
+)
+
+const q = math.Ln10 / 400
+
+type Rating struct {
+	Value     float64
+	Deviation float64
+}
+
+// New returns the rating a player starts with.
+func New() Rating {
+	return Rating{Value: Initial, Deviation: InitialDeviation}
+}
+
+// Decay returns r after idle time without games.
+func Decay(r Rating, idle time.Duration) Rating {
+	days := max(idle.Hours()/24, 0)
+	r.Deviation = math.Min(math.Sqrt(r.Deviation*r.Deviation+decayPerDay*days), InitialDeviation)
+	return r
+}
+
+// g discounts a result by how uncertain the opponent's rating is.
+func g(deviation float64) float64 {
+	return 1 / math.Sqrt(1+3*q*q*deviation*deviation/(math.Pi*math.Pi))
+}
+
+// Expected is the score r is expected to take against opponent: 1 for a certain win,
+// 0.5 for an even game.
+func Expected(r, opponent Rating) float64 {
+	return 1 / (1 + math.Pow(10, -g(opponent.Deviation)*(r.Value-opponent.Value)/400))
+}
+
+// Game is one result of a rating period: the opponent's rating going in and the
+// score (Win, Draw or Loss) taken against them.
+type Game struct {
+	Opponent Rating
+	Score    float64
+}
+
+// Update returns r after scoring score (Win, Draw or Loss) against opponent.
+func Update(r, opponent Rating, score float64) Rating {
+	return UpdatePeriod(r, []Game{{Opponent: opponent, Score: score}})
+}
+
+// UpdatePeriod returns r after a rating period of games, each rated against the
+// opponent's rating going in. A period without games leaves r as it is.
+func UpdatePeriod(r Rating, games []Game) Rating {
+	if len(games) == 0 {
+		return r
+	}
+	var information, surprise float64
+	for _, game := range games {
+		gj := g(game.Opponent.Deviation)
+		e := Expected(r, game.Opponent)
+		information += gj * gj * e * (1 - e)
+		surprise += gj * (game.Score - e)
+	}
+	dSquared := 1 / (q * q * information)
+	precision := 1/(r.Deviation*r.Deviation) + 1/dSquared
+
+	return Rating{
+		Value:     r.Value + q/precision*surprise,
+		Deviation: math.Max(math.Sqrt(1/precision), MinDeviation),
+	}
+}
+
+// Composite stands a side of players in for a single opponent: their mean rating,
+// with the root mean square of their deviations.
+func Composite(side []Rating) Rating {
+	if len(side) == 0 {
+		return New()
+	}
+	var value, variance float64
+	for _, r := range side {
+		value += r.Value
+		variance += r.Deviation * r.Deviation
+	}
+	n := float64(len(side))
+	return Rating{Value: value / n, Deviation: math.Sqrt(variance / n)}
+}
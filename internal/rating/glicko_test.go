@@ -0,0 +1,94 @@
+package rating
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func near(t *testing.T, name string, got, want, tolerance float64) {
+	t.Helper()
+	if math.Abs(got-want) > tolerance {
+		t.Errorf("%s = %.4f, want %.4f ± %g", name, got, want, tolerance)
+	}
+}
+
+// The worked example from Glickman's "The Glicko system": a player rated 1500 with
+// deviation 200 beats a 1400 (30) and loses to a 1550 (100) and a 1700 (300).
+var (
+	example         = Rating{Value: 1500, Deviation: 200}
+	exampleGames    = []Game{{Rating{1400, 30}, Win}, {Rating{1550, 100}, Loss}, {Rating{1700, 300}, Loss}}
+	exampleG        = []float64{0.9955, 0.9531, 0.7242}
+	exampleExpected = []float64{0.639, 0.432, 0.303}
+)
+
+func TestGlickoWorkedExample(t *testing.T) {
+	for i, game := range exampleGames {
+		near(t, "g", g(game.Opponent.Deviation), exampleG[i], 0.0001)
+		near(t, "E", Expected(example, game.Opponent), exampleExpected[i], 0.001)
+	}
+
+	got := UpdatePeriod(example, exampleGames)
+	near(t, "rating", got.Value, 1464.06, 0.05)
+	near(t, "deviation", got.Deviation, 151.4, 0.05)
+}
+
+func TestUpdate(t *testing.T) {
+	// A single game is a period of one.
+	for _, game := range exampleGames {
+		single := Update(example, game.Opponent, game.Score)
+		period := UpdatePeriod(example, []Game{game})
+		if single != period {
+			t.Errorf("Update = %+v, UpdatePeriod = %+v", single, period)
+		}
+		if single.Deviation >= example.Deviation {
+			t.Errorf("deviation grew to %.2f after a game", single.Deviation)
+		}
+	}
+
+	if got := UpdatePeriod(example, nil); got != example {
+		t.Errorf("empty period = %+v, want %+v", got, example)
+	}
+
+	// Evenly matched new players move by the same amount in opposite directions.
+	winner, loser := Update(New(), New(), Win), Update(New(), New(), Loss)
+	near(t, "winner gain", winner.Value-Initial, Initial-loser.Value, 1e-9)
+	near(t, "draw", Update(New(), New(), Draw).Value, Initial, 1e-9)
+
+	// A settled player never drops below the floor.
+	settled := Rating{Value: 1800, Deviation: MinDeviation}
+	if got := Update(settled, Rating{1800, MinDeviation}, Win); got.Deviation != MinDeviation {
+		t.Errorf("deviation = %.2f, want the floor %.0f", got.Deviation, MinDeviation)
+	}
+}
+
+func TestExpected(t *testing.T) {
+	near(t, "even", Expected(New(), New()), 0.5, 1e-9)
+	strong, weak := Rating{1900, 50}, Rating{1500, 50}
+	near(t, "symmetry", Expected(strong, weak)+Expected(weak, strong), 1, 1e-9)
+	// A 400-point gap against a certain rating is 10 to 1.
+	near(t, "certain opponent", Expected(Rating{1900, 0}, Rating{1500, 0}), 10.0/11, 1e-9)
+}
+
+func TestDecay(t *testing.T) {
+	day := 24 * time.Hour
+	tests := []struct {
+		name string
+		r    Rating
+		idle time.Duration
+		want float64
+	}{
+		{"no time", Rating{1500, 50}, 0, 50},
+		{"negative time", Rating{1500, 50}, -day, 50},
+		{"one day", Rating{1500, 50}, day, math.Sqrt(50*50 + 18.1*18.1)},
+		{"about a year", Rating{1500, 50}, 365 * day, 349.4},
+		{"capped", Rating{1500, 300}, 10 * 365 * day, InitialDeviation},
+	}
+	for _, tt := range tests {
+		got := Decay(tt.r, tt.idle)
+		near(t, tt.name, got.Deviation, tt.want, 0.1)
+		if got.Value != tt.r.Value {
+			t.Errorf("%s: value changed to %.2f", tt.name, got.Value)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Package recurrence expands the weekly subset of RFC 5545 recurrence rules used for
+// booking series, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=10".
+//
+// Supported parts are FREQ (WEEKLY only), INTERVAL, BYDAY (plain day codes), and
+// exactly one of COUNT or UNTIL. Weeks start on Monday, and every occurrence keeps the
+// wall-clock time of the first in its location, across DST changes.
+package recurrence
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxInterval is the largest INTERVAL accepted, in weeks.
+const MaxInterval = 52
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+// Rule is a parsed weekly recurrence rule.
+type Rule struct {
+	// Interval is the number of weeks between occurrence weeks.
+	Interval int
+	// Days are the weekdays occurrences fall on, Monday first; none means the first
+	// occurrence's weekday.
+	Days []time.Weekday
+	// Count is the number of occurrences, or 0 when Until bounds the rule.
+	Count int
+	// Until is the last instant an occurrence may start at, or zero when Count does.
+	Until time.Time
+}
+
+// Parse parses a rule. A date-only UNTIL covers the whole of that day in loc.
+func Parse(s string, loc *time.Location) (Rule, error) {
+	rule := Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "RRULE:"), ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || name == "" || value == "" {
+			return Rule{}, fmt.Errorf("malformed rule part %q", part)
+		}
+		if seen[name] {
+			return Rule{}, fmt.Errorf("%s is given more than once", name)
+		}
+		seen[name] = true
+
+		switch name {
+		case "FREQ":
+			if value != "WEEKLY" {
+				return Rule{}, fmt.Errorf("only FREQ=WEEKLY is supported")
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > MaxInterval {
+				return Rule{}, fmt.Errorf("INTERVAL must be between 1 and %d", MaxInterval)
+			}
+			rule.Interval = n
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := weekdays[code]
+				if !ok {
+					return Rule{}, fmt.Errorf("BYDAY has an unknown day %q", code)
+				}
+				if !slices.Contains(rule.Days, day) {
+					rule.Days = append(rule.Days, day)
+				}
+			}
+			slices.SortFunc(rule.Days, func(a, b time.Weekday) int { return mondayOffset(a) - mondayOffset(b) })
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("COUNT must be a positive number")
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(value, loc)
+			if err != nil {
+				return Rule{}, err
+			}
+			rule.Until = until
+		default:
+			return Rule{}, fmt.Errorf("%s is not supported", name)
+		}
+	}
+
+	if !seen["FREQ"] {
+		return Rule{}, fmt.Errorf("FREQ is required")
+	}
+	if seen["COUNT"] == seen["UNTIL"] {
+		return Rule{}, fmt.Errorf("exactly one of COUNT or UNTIL is required")
+	}
+	return rule, nil
+}
+
+// parseUntil accepts a UTC date-time (20260131T200000Z) or a date (20260131).
+func parseUntil(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	day, err := time.ParseInLocation("20060102", value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("UNTIL must be a date (YYYYMMDD) or a UTC date-time (YYYYMMDDTHHMMSSZ)")
+	}
+	return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+}
+
+// Expand returns the start of every occurrence from start, which must fall on one of
+// the rule's days. It fails rather than return more than limit occurrences.
+func (r Rule) Expand(start time.Time, limit int) ([]time.Time, error) {
+	days := r.Days
+	if len(days) == 0 {
+		days = []time.Weekday{start.Weekday()}
+	}
+	if !slices.Contains(days, start.Weekday()) {
+		return nil, fmt.Errorf("the first occurrence must fall on one of the BYDAY days")
+	}
+
+	week := start.AddDate(0, 0, -mondayOffset(start.Weekday()))
+	var starts []time.Time
+	for {
+		for _, day := range days {
+			occurrence := week.AddDate(0, 0, mondayOffset(day))
+			if occurrence.Before(start) {
+				continue
+			}
+			if !r.Until.IsZero() && occurrence.After(r.Until) {
+				return starts, nil
+			}
+			if len(starts) == limit {
+				return nil, fmt.Errorf("the rule has more than %d occurrences", limit)
+			}
+			starts = append(starts, occurrence)
+			if r.Count > 0 && len(starts) == r.Count {
+				return starts, nil
+			}
+		}
+		week = week.AddDate(0, 0, 7*r.Interval)
+	}
+}
+
+// mondayOffset is the number of days from Monday to day.
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
@@ -0,0 +1,151 @@
+package recurrence
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestExpand(t *testing.T) {
+	karachi := mustLoad(t, "Asia/Karachi")
+	at := func(month time.Month, day int) time.Time { return time.Date(2026, month, day, 19, 0, 0, 0, karachi) }
+	// 3 March 2026 is a Tuesday.
+	tuesday := at(time.March, 3)
+
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		want  []time.Time
+	}{
+		{"weekly count", "FREQ=WEEKLY;COUNT=3", tuesday,
+			[]time.Time{at(time.March, 3), at(time.March, 10), at(time.March, 17)}},
+		{"every other week", "FREQ=WEEKLY;INTERVAL=2;COUNT=3", tuesday,
+			[]time.Time{at(time.March, 3), at(time.March, 17), at(time.March, 31)}},
+		{"days in week order from a midweek start", "FREQ=WEEKLY;BYDAY=FR,MO,WE;COUNT=4", at(time.March, 4),
+			[]time.Time{at(time.March, 4), at(time.March, 6), at(time.March, 9), at(time.March, 11)}},
+		{"every other week on two days", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH;COUNT=4", tuesday,
+			[]time.Time{at(time.March, 3), at(time.March, 5), at(time.March, 17), at(time.March, 19)}},
+		{"date-only until covers the whole day", "FREQ=WEEKLY;UNTIL=20260317", tuesday,
+			[]time.Time{at(time.March, 3), at(time.March, 10), at(time.March, 17)}},
+		{"date-time until includes an occurrence at it", "FREQ=WEEKLY;UNTIL=20260317T140000Z", tuesday,
+			[]time.Time{at(time.March, 3), at(time.March, 10), at(time.March, 17)}},
+		{"date-time until just before an occurrence", "FREQ=WEEKLY;UNTIL=20260317T135959Z", tuesday,
+			[]time.Time{at(time.March, 3), at(time.March, 10)}},
+		{"until before the start", "FREQ=WEEKLY;UNTIL=20260301", tuesday, nil},
+		{"prefix and lower case", "RRULE:freq=weekly;byday=tu;count=1", tuesday, []time.Time{tuesday}},
+		{"repeated day", "FREQ=WEEKLY;BYDAY=TU,TU;COUNT=2", tuesday,
+			[]time.Time{at(time.March, 3), at(time.March, 10)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule, karachi)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			got, err := rule.Expand(tt.start, 104)
+			if err != nil {
+				t.Fatalf("expand: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %v", len(got), got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExpandKeepsWallClockAcrossDST(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	// Clocks go forward on 8 March 2026.
+	start := time.Date(2026, time.March, 3, 19, 0, 0, 0, newYork)
+	rule, err := Parse("FREQ=WEEKLY;COUNT=3", newYork)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got, err := rule.Expand(start, 104)
+	if err != nil {
+		t.Fatalf("expand: %v", err)
+	}
+	for _, occurrence := range got {
+		if occurrence.Hour() != 19 || occurrence.Weekday() != time.Tuesday {
+			t.Errorf("occurrence %v is not Tuesday 19:00 local", occurrence)
+		}
+	}
+	if gap := got[1].Sub(got[0]); gap != 7*24*time.Hour-time.Hour {
+		t.Errorf("gap across the change = %v, want 167h", gap)
+	}
+}
+
+func TestExpandLimit(t *testing.T) {
+	start := time.Date(2026, time.March, 3, 19, 0, 0, 0, time.UTC)
+	tests := []struct {
+		rule    string
+		wantLen int
+		wantErr bool
+	}{
+		{"FREQ=WEEKLY;COUNT=104", 104, false},
+		{"FREQ=WEEKLY;COUNT=105", 0, true},
+		{"FREQ=WEEKLY;BYDAY=TU,TH;COUNT=104", 104, false},
+		{"FREQ=WEEKLY;UNTIL=20300101", 0, true},
+	}
+	for _, tt := range tests {
+		rule, err := Parse(tt.rule, time.UTC)
+		if err != nil {
+			t.Fatalf("%s: parse: %v", tt.rule, err)
+		}
+		got, err := rule.Expand(start, 104)
+		if (err != nil) != tt.wantErr || len(got) != tt.wantLen {
+			t.Errorf("%s: %d occurrences, err %v; want %d, error %v", tt.rule, len(got), err, tt.wantLen, tt.wantErr)
+		}
+	}
+}
+
+func TestExpandRejectsStartOffTheDays(t *testing.T) {
+	rule, err := Parse("FREQ=WEEKLY;BYDAY=MO;COUNT=2", time.UTC)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if _, err := rule.Expand(time.Date(2026, time.March, 3, 19, 0, 0, 0, time.UTC), 104); err == nil {
+		t.Error("a Tuesday start of a Monday rule expanded")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		rule, want string
+	}{
+		{"FREQ=WEEKLY;COUNT=2;COUNT=3", "COUNT is given more than once"},
+		{"FREQ=WEEKLY;BYDAY=MO;BYDAY=TU;COUNT=2", "BYDAY is given more than once"},
+		{"FREQ=WEEKLY;COUNT=2;UNTIL=20260401", "exactly one of COUNT or UNTIL"},
+		{"FREQ=WEEKLY", "exactly one of COUNT or UNTIL"},
+		{"COUNT=2", "FREQ is required"},
+		{"FREQ=DAILY;COUNT=2", "only FREQ=WEEKLY"},
+		{"FREQ=WEEKLY;INTERVAL=0;COUNT=2", "INTERVAL must be between"},
+		{"FREQ=WEEKLY;INTERVAL=53;COUNT=2", "INTERVAL must be between"},
+		{"FREQ=WEEKLY;BYDAY=1MO;COUNT=2", "unknown day"},
+		{"FREQ=WEEKLY;COUNT=0", "COUNT must be a positive number"},
+		{"FREQ=WEEKLY;UNTIL=2026-04-01", "UNTIL must be a date"},
+		{"FREQ=WEEKLY;BYMONTH=3;COUNT=2", "BYMONTH is not supported"},
+		{"FREQ=WEEKLY;;COUNT=2", "malformed rule part"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.rule, time.UTC)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Parse(%q) = %v, want an error containing %q", tt.rule, err, tt.want)
+		}
+	}
+}
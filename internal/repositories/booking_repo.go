@@ -0,0 +1,168 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingRepository struct {
+	db *gorm.DB
+}
+
+func NewBookingRepository(db *gorm.DB) *BookingRepository {
+	return &BookingRepository{
+		db: db,
+	}
+}
+
+// Create inserts the booking unless it overlaps another booking holding the same field.
+func (r *BookingRepository) Create(ctx context.Context, booking *models.Booking) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return insertBooking(tx, booking)
+	})
+}
+
+// insertBooking inserts the booking inside tx unless the field is taken or closed for
+// any of its time. The turf row is locked for the check so two requests cannot book
+// the same slot.
+func insertBooking(tx *gorm.DB, booking *models.Booking) error {
+	var turf models.Turf
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&turf, "id = ?", booking.TurfID).Error; err != nil {
+		return apperrors.FromDB(err, "turf")
+	}
+
+	overlapping, err := countOverlapping(tx, booking.TurfID, booking.FieldNumber, booking.StartsAt, booking.EndsAt)
+	if err != nil {
+		return err
+	}
+	if overlapping > 0 {
+		return apperrors.Conflict("the field is already booked for that time")
+	}
+	closed, err := countClosures(tx, booking.TurfID, booking.FieldNumber, booking.StartsAt, booking.EndsAt)
+	if err != nil {
+		return err
+	}
+	if closed > 0 {
+		return apperrors.Conflict("the field is closed for that time")
+	}
+	return tx.Omit(clause.Associations).Create(booking).Error
+}
+
+// holdingStatuses are the booking states that keep a field reserved.
+var holdingStatuses = []string{models.BookingConfirmed, models.BookingPendingPayment}
+
+// countOverlapping counts bookings holding the field that overlap [start, end).
+func countOverlapping(db *gorm.DB, turfID uuid.UUID, field int, start, end time.Time) (int64, error) {
+	var count int64
+	err := db.Model(&models.Booking{}).
+		Where("turf_id = ? AND field_number = ? AND status IN ?", turfID, field, holdingStatuses).
+		Where("starts_at < ? AND ends_at > ?", end, start).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *BookingRepository) GetByID(ctx context.Context, id string) (*models.Booking, error) {
+	var booking models.Booking
+	if err := r.db.WithContext(ctx).Preload("Turf").Preload("Team").First(&booking, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "booking")
+	}
+	return &booking, nil
+}
+
+// ListByUser returns the bookings userID made or whose team they belong to, soonest
+// first.
+func (r *BookingRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.Booking, error) {
+	var bookings []models.Booking
+	teams := r.db.Model(&models.TeamMember{}).Select("team_id").Where("user_id = ?", userID)
+	err := r.db.WithContext(ctx).Preload("Turf").Preload("Team").
+		Where("booked_by_id = ? OR team_id IN (?)", userID, teams).
+		Order("starts_at ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// Cancel marks a booking holding its field cancelled, along with any open match on it.
+// A payment split on the booking is cancelled too, which queues its payments for refund.
+func (r *BookingRepository) Cancel(ctx context.Context, booking *models.Booking) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		cancelled, err := cancelHolding(tx, []uuid.UUID{booking.ID})
+		if err != nil {
+			return err
+		}
+		if cancelled == 0 {
+			return apperrors.Conflict("booking is already cancelled or expired")
+		}
+		booking.Status = models.BookingCancelled
+		return nil
+	})
+}
+
+// cancelHolding cancels those of the bookings still holding their field, with their
+// payment splits and open matches, and returns how many it cancelled.
+func cancelHolding(tx *gorm.DB, ids []uuid.UUID) (int64, error) {
+	result := tx.Model(&models.Booking{}).
+		Where("id IN ? AND status IN ?", ids, holdingStatuses).
+		Update("status", models.BookingCancelled)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	if err := tx.Model(&models.PaymentSplit{}).
+		Where("booking_id IN ? AND status IN ?", ids, []string{models.SplitCollecting, models.SplitCovered}).
+		Update("status", models.SplitCancelled).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Model(&models.Match{}).
+		Where("booking_id IN ? AND status = ?", ids, models.MatchOpen).
+		Update("status", models.MatchCancelled).Error; err != nil {
+		return 0, err
+	}
+	return result.RowsAffected, nil
+}
+
+// ListHolding returns the bookings holding any field of the turf between from and to.
+func (r *BookingRepository) ListHolding(ctx context.Context, turfID uuid.UUID, from, to time.Time) ([]models.Booking, error) {
+	var bookings []models.Booking
+	err := r.db.WithContext(ctx).
+		Where("turf_id = ? AND status IN ? AND starts_at < ? AND ends_at > ?", turfID, holdingStatuses, to, from).
+		Order("starts_at ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// HasPlayed reports whether userID was party to a confirmed booking at the turf that
+// had ended by now: as the booker, a member of the booked team, or a player who
+// joined an open match on it.
+func (r *BookingRepository) HasPlayed(ctx context.Context, turfID, userID uuid.UUID, now time.Time) (bool, error) {
+	teams := r.db.Model(&models.TeamMember{}).Select("team_id").Where("user_id = ?", userID)
+	matches := r.db.Model(&models.Match{}).Select("matches.booking_id").
+		Joins("JOIN match_players ON match_players.match_id = matches.id").
+		Where("match_players.user_id = ? AND match_players.status = ?", userID, models.MatchPlayerJoined)
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Booking{}).
+		Where("turf_id = ? AND status = ? AND ends_at <= ?", turfID, models.BookingConfirmed, now).
+		Where("booked_by_id = ? OR team_id IN (?) OR id IN (?)", userID, teams, matches).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListHoldingForTurfs returns the bookings holding any field of the turfs between from
+// and to.
+func (r *BookingRepository) ListHoldingForTurfs(ctx context.Context, turfIDs []uuid.UUID, from, to time.Time) ([]models.Booking, error) {
+	if len(turfIDs) == 0 {
+		return nil, nil
+	}
+	var bookings []models.Booking
+	err := r.db.WithContext(ctx).
+		Where("turf_id IN ? AND status IN ? AND starts_at < ? AND ends_at > ?", turfIDs, holdingStatuses, to, from).
+		Order("starts_at ASC").
+		Find(&bookings).Error
+	return bookings, err
+}
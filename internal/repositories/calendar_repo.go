@@ -0,0 +1,184 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CalendarRepository stores turfs' weekday opening hours and closures.
+type CalendarRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarRepository(db *gorm.DB) *CalendarRepository {
+	return &CalendarRepository{
+		db: db,
+	}
+}
+
+// SetHours replaces the turf's weekday overrides with hours.
+func (r *CalendarRepository) SetHours(ctx context.Context, turfID uuid.UUID, hours []models.TurfHours) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("turf_id = ?", turfID).Delete(&models.TurfHours{}).Error; err != nil {
+			return err
+		}
+		if len(hours) == 0 {
+			return nil
+		}
+		return tx.Create(&hours).Error
+	})
+}
+
+// CreateClosure inserts the closure and flags the bookings holding its field, or any
+// field when it closes the whole turf, for any of its time, including bookings another
+// closure already flagged. The flagged bookings are returned with their team. The turf
+// row is locked so no booking can slip in between.
+func (r *CalendarRepository) CreateClosure(ctx context.Context, closure *models.TurfClosure) ([]models.Booking, error) {
+	var flagged []models.Booking
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTurf(tx, closure.TurfID); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(closure).Error; err != nil {
+			return err
+		}
+
+		affected := tx.Model(&models.Booking{}).
+			Select("id, ?", closure.ID).
+			Where("turf_id = ? AND status IN ?", closure.TurfID, holdingStatuses).
+			Where("starts_at < ? AND ends_at > ?", closure.EndsAt, closure.StartsAt)
+		if closure.FieldNumber != nil {
+			affected = affected.Where("field_number = ?", *closure.FieldNumber)
+		}
+		if err := tx.Exec("INSERT INTO booking_closures (booking_id, turf_closure_id) ?", affected).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Booking{}).
+			Where("closure_id IS NULL AND id IN (?)", closureBookingIDs(tx, closure.ID)).
+			Update("closure_id", closure.ID).Error; err != nil {
+			return err
+		}
+		return tx.Preload("Team").
+			Where("id IN (?)", closureBookingIDs(tx, closure.ID)).
+			Order("starts_at ASC").
+			Find(&flagged).Error
+	})
+	return flagged, err
+}
+
+// closureBookingIDs selects the IDs of the bookings the closure flagged.
+func closureBookingIDs(tx *gorm.DB, closureID uuid.UUID) *gorm.DB {
+	return tx.Table("booking_closures").Select("booking_id").Where("turf_closure_id = ?", closureID)
+}
+
+// GetClosure returns one of the turf's closures with the bookings it flagged.
+func (r *CalendarRepository) GetClosure(ctx context.Context, turfID uuid.UUID, id string) (*models.TurfClosure, error) {
+	var closure models.TurfClosure
+	err := r.db.WithContext(ctx).Preload("Turf").
+		Preload("Bookings", func(db *gorm.DB) *gorm.DB { return db.Order("starts_at ASC") }).
+		Preload("Bookings.Team").
+		First(&closure, "turf_id = ? AND id = ?", turfID, id).Error
+	if err != nil {
+		return nil, apperrors.FromDB(err, "closure")
+	}
+	return &closure, nil
+}
+
+// ListClosures returns the turf's closures overlapping [from, to), soonest first,
+// optionally only those with reason.
+func (r *CalendarRepository) ListClosures(ctx context.Context, turfID uuid.UUID, from, to time.Time, reason string) ([]models.TurfClosure, error) {
+	var closures []models.TurfClosure
+	query := r.db.WithContext(ctx).
+		Where("turf_id = ? AND starts_at < ? AND ends_at > ?", turfID, to, from)
+	if reason != "" {
+		query = query.Where("reason = ?", reason)
+	}
+	err := query.Order("starts_at ASC").Find(&closures).Error
+	return closures, err
+}
+
+// ListClosuresForTurfs returns the closures of any of the turfs overlapping [from, to).
+func (r *CalendarRepository) ListClosuresForTurfs(ctx context.Context, turfIDs []uuid.UUID, from, to time.Time) ([]models.TurfClosure, error) {
+	var closures []models.TurfClosure
+	if len(turfIDs) == 0 {
+		return closures, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("turf_id IN ? AND starts_at < ? AND ends_at > ?", turfIDs, to, from).
+		Find(&closures).Error
+	return closures, err
+}
+
+// DeleteClosure removes a closure. Bookings it flagged stay flagged by the earliest
+// other closure that flagged them, and are unflagged when there is none.
+func (r *CalendarRepository) DeleteClosure(ctx context.Context, closure *models.TurfClosure) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM booking_closures WHERE turf_closure_id = ?", closure.ID).Error; err != nil {
+			return err
+		}
+		remaining := tx.Table("booking_closures").
+			Select("turf_closures.id").
+			Joins("JOIN turf_closures ON turf_closures.id = booking_closures.turf_closure_id").
+			Where("booking_closures.booking_id = bookings.id").
+			Order("turf_closures.starts_at ASC, turf_closures.id ASC").
+			Limit(1)
+		if err := tx.Model(&models.Booking{}).
+			Where("closure_id = ?", closure.ID).
+			Update("closure_id", remaining).Error; err != nil {
+			return err
+		}
+		return tx.Delete(closure).Error
+	})
+}
+
+// AffectedPlayer is a player with a stake in a booking: its booker, a member of its
+// team, or a player who joined an open match on it.
+type AffectedPlayer struct {
+	BookingID uuid.UUID
+	UserID    uuid.UUID
+	Email     string
+}
+
+// AffectedPlayers returns the players of the bookings, once per booking.
+func (r *CalendarRepository) AffectedPlayers(ctx context.Context, bookingIDs []uuid.UUID) ([]AffectedPlayer, error) {
+	var players []AffectedPlayer
+	if len(bookingIDs) == 0 {
+		return players, nil
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT bookings.id AS booking_id, users.id AS user_id, users.email
+		FROM bookings JOIN users ON users.id = bookings.booked_by_id
+		WHERE bookings.id IN @ids
+		UNION
+		SELECT bookings.id, users.id, users.email
+		FROM bookings
+		JOIN team_members ON team_members.team_id = bookings.team_id
+		JOIN users ON users.id = team_members.user_id
+		WHERE bookings.id IN @ids
+		UNION
+		SELECT matches.booking_id, users.id, users.email
+		FROM matches
+		JOIN match_players ON match_players.match_id = matches.id AND match_players.status = @joined
+		JOIN users ON users.id = match_players.user_id
+		WHERE matches.booking_id IN @ids AND matches.status = @open`,
+		map[string]any{"ids": bookingIDs, "joined": models.MatchPlayerJoined, "open": models.MatchOpen}).
+		Scan(&players).Error
+	return players, err
+}
+
+// countClosures counts closures of the field, or of the whole turf, that overlap
+// [start, end).
+func countClosures(db *gorm.DB, turfID uuid.UUID, field int, start, end time.Time) (int64, error) {
+	var count int64
+	err := db.Model(&models.TurfClosure{}).
+		Where("turf_id = ? AND (field_number IS NULL OR field_number = ?)", turfID, field).
+		Where("starts_at < ? AND ends_at > ?", end, start).
+		Count(&count).Error
+	return count, err
+}
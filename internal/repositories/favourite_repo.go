@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FavouriteRepository struct {
+	db *gorm.DB
+}
+
+func NewFavouriteRepository(db *gorm.DB) *FavouriteRepository {
+	return &FavouriteRepository{
+		db: db,
+	}
+}
+
+// Add favourites the turf; favouriting it again is a no-op.
+func (r *FavouriteRepository) Add(ctx context.Context, favourite *models.FavouriteTurf) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}, {Name: "turf_id"}}, DoNothing: true}).
+		Omit(clause.Associations).
+		Create(favourite).Error
+}
+
+func (r *FavouriteRepository) Remove(ctx context.Context, userID, turfID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("user_id = ? AND turf_id = ?", userID, turfID).Delete(&models.FavouriteTurf{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("favourite")
+	}
+	return nil
+}
+
+// ListByUser returns userID's favourite turfs, most recently added first.
+func (r *FavouriteRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.FavouriteTurf, error) {
+	var favourites []models.FavouriteTurf
+	err := r.db.WithContext(ctx).Preload("Turf").Preload("Turf.Hours").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&favourites).Error
+	return favourites, err
+}
+
+// Exists reports whether userID has already favourited the turf.
+func (r *FavouriteRepository) Exists(ctx context.Context, userID, turfID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.FavouriteTurf{}).Where("user_id = ? AND turf_id = ?", userID, turfID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *FavouriteRepository) CountByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.FavouriteTurf{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
@@ -1,6 +1,9 @@
 package repositories
 
 import (
+	"context"
+
+	"github.com/musishere/sportsApp/internal/apperrors"
 	"github.com/musishere/sportsApp/internal/models"
 	"gorm.io/gorm"
 )
@@ -15,31 +18,31 @@ func NewLocationRepository(db *gorm.DB) *LocationRepository {
 	}
 }
 
-func (r *LocationRepository) CreateLocation(location *models.Location) error {
-	return r.db.Create(location).Error
+func (r *LocationRepository) CreateLocation(ctx context.Context, location *models.Location) error {
+	return r.db.WithContext(ctx).Create(location).Error
 }
 
-func (r *LocationRepository) GetLocationByID(id string) (*models.Location, error) {
+func (r *LocationRepository) GetLocationByID(ctx context.Context, id string) (*models.Location, error) {
 	var location models.Location
-	if err := r.db.First(&location, "id = ?", id).Error; err != nil {
-		return nil, err
+	if err := r.db.WithContext(ctx).First(&location, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "location")
 	}
 	return &location, nil
 }
 
-func (r *LocationRepository) UpdateLocation(location *models.Location) error {
-	return r.db.Save(location).Error
+func (r *LocationRepository) UpdateLocation(ctx context.Context, location *models.Location) error {
+	return r.db.WithContext(ctx).Save(location).Error
 }
 
-func (r *LocationRepository) DeleteLocation(id string) error {
-	return r.db.Delete(&models.Location{}, "id = ?", id).Error
+func (r *LocationRepository) DeleteLocation(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&models.Location{}, "id = ?", id).Error
 }
 
-func (r *LocationRepository) GetLocationByUserID(userID string) (*models.Location, error) {
+func (r *LocationRepository) GetLocationByUserID(ctx context.Context, userID string) (*models.Location, error) {
 	var location models.Location
-	err := r.db.Where("user_id = ?", userID).First(&location).Error
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&location).Error
 	if err != nil {
-		return nil, err
+		return nil, apperrors.FromDB(err, "location")
 	}
 	return &location, nil
 }
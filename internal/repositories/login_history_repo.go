@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+)
+
+type LoginHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginHistoryRepository(db *gorm.DB) *LoginHistoryRepository {
+	return &LoginHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *LoginHistoryRepository) Create(ctx context.Context, entry *models.LoginHistory) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// ListByUser returns the most recent login attempts for a user, newest first.
+func (r *LoginHistoryRepository) ListByUser(ctx context.Context, userID string, limit int) ([]models.LoginHistory, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	var entries []models.LoginHistory
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Limit(limit).Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MatchRepository struct {
+	db *gorm.DB
+}
+
+func NewMatchRepository(db *gorm.DB) *MatchRepository {
+	return &MatchRepository{
+		db: db,
+	}
+}
+
+// MatchSearch bounds a nearby search. The box is a cheap prefilter; callers compute exact
+// distances on the results.
+type MatchSearch struct {
+	MinLat, MaxLat float64
+	MinLng, MaxLng float64
+	SportID        *uuid.UUID
+	SkillLevel     string
+	Limit          int
+}
+
+// withPlayers preloads everything a match response needs, players in join order.
+func withPlayers(db *gorm.DB) *gorm.DB {
+	return db.Preload("Sport").Preload("Organizer").Preload("Booking.Turf").
+		Preload("Players", func(db *gorm.DB) *gorm.DB { return db.Order("match_players.created_at ASC") }).
+		Preload("Players.User")
+}
+
+func (r *MatchRepository) Create(ctx context.Context, match *models.Match) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(match).Error; err != nil {
+		return apperrors.FromDB(err, "match for this booking")
+	}
+	return withPlayers(r.db.WithContext(ctx)).First(match, "id = ?", match.ID).Error
+}
+
+func (r *MatchRepository) GetByID(ctx context.Context, id string) (*models.Match, error) {
+	var match models.Match
+	if err := withPlayers(r.db.WithContext(ctx)).First(&match, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "match")
+	}
+	return &match, nil
+}
+
+// FindOpen returns open matches before their cutoff on turfs inside the search box,
+// soonest first.
+func (r *MatchRepository) FindOpen(ctx context.Context, search MatchSearch) ([]models.Match, error) {
+	query := withPlayers(r.db.WithContext(ctx)).
+		Joins("JOIN bookings ON bookings.id = matches.booking_id").
+		Joins("JOIN turves ON turves.id = bookings.turf_id").
+		Where("matches.status = ? AND matches.cutoff_at > ?", models.MatchOpen, time.Now()).
+		Where("turves.latitude BETWEEN ? AND ? AND turves.longitude BETWEEN ? AND ?",
+			search.MinLat, search.MaxLat, search.MinLng, search.MaxLng)
+	if search.SportID != nil {
+		query = query.Where("matches.sport_id = ?", *search.SportID)
+	}
+	if search.SkillLevel != "" {
+		query = query.Where("matches.skill_level IN ?", []string{search.SkillLevel, models.SkillAny})
+	}
+
+	var matches []models.Match
+	err := query.Order("bookings.starts_at ASC").Limit(search.Limit).Find(&matches).Error
+	return matches, err
+}
+
+// ListByPlayer returns the matches userID organises or has joined, soonest first.
+func (r *MatchRepository) ListByPlayer(ctx context.Context, userID uuid.UUID) ([]models.Match, error) {
+	var matches []models.Match
+	joined := r.db.Model(&models.MatchPlayer{}).Select("match_id").Where("user_id = ?", userID)
+	err := withPlayers(r.db.WithContext(ctx)).
+		Joins("JOIN bookings ON bookings.id = matches.booking_id").
+		Where("matches.organizer_id = ? OR matches.id IN (?)", userID, joined).
+		Order("bookings.starts_at ASC").
+		Find(&matches).Error
+	return matches, err
+}
+
+// lockOpen locks an open match before its cutoff for the rest of the transaction.
+func lockOpen(tx *gorm.DB, matchID uuid.UUID) (*models.Match, error) {
+	var match models.Match
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&match, "id = ?", matchID).Error; err != nil {
+		return nil, apperrors.FromDB(err, "match")
+	}
+	if match.Status != models.MatchOpen || !time.Now().Before(match.CutoffAt) {
+		return nil, apperrors.Conflict("match is no longer open")
+	}
+	return &match, nil
+}
+
+// Join adds userID to the match, on the waitlist when every open spot is taken, and
+// returns the status they were given.
+func (r *MatchRepository) Join(ctx context.Context, matchID, userID uuid.UUID) (string, error) {
+	var status string
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		match, err := lockOpen(tx, matchID)
+		if err != nil {
+			return err
+		}
+
+		var joined int64
+		if err := tx.Model(&models.MatchPlayer{}).
+			Where("match_id = ? AND status = ?", matchID, models.MatchPlayerJoined).
+			Count(&joined).Error; err != nil {
+			return err
+		}
+		status = models.MatchPlayerWaitlisted
+		if int(joined) < match.OpenSpots {
+			status = models.MatchPlayerJoined
+		}
+
+		player := &models.MatchPlayer{MatchID: matchID, UserID: userID, Status: status}
+		if err := tx.Omit(clause.Associations).Create(player).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return apperrors.Conflict("already joined this match").WithCause(err)
+			}
+			return err
+		}
+		return nil
+	})
+	return status, err
+}
+
+// Leave removes userID from the match. When they held a spot, the longest-waiting
+// player is promoted into it and returned.
+func (r *MatchRepository) Leave(ctx context.Context, matchID, userID uuid.UUID) (*models.MatchPlayer, error) {
+	var promoted *models.MatchPlayer
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockOpen(tx, matchID); err != nil {
+			return err
+		}
+
+		var player models.MatchPlayer
+		if err := tx.First(&player, "match_id = ? AND user_id = ?", matchID, userID).Error; err != nil {
+			return apperrors.FromDB(err, "match player")
+		}
+		if err := tx.Delete(&player).Error; err != nil {
+			return err
+		}
+		if player.Status != models.MatchPlayerJoined {
+			return nil
+		}
+
+		var next models.MatchPlayer
+		err := tx.Preload("User").
+			Where("match_id = ? AND status = ?", matchID, models.MatchPlayerWaitlisted).
+			Order("created_at ASC").
+			First(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&next).Update("status", models.MatchPlayerJoined).Error; err != nil {
+			return err
+		}
+		promoted = &next
+		return nil
+	})
+	return promoted, err
+}
+
+// SetStatus moves an open match to status, reporting false when it was no longer open
+// (e.g. another instance's sweeper got there first).
+func (r *MatchRepository) SetStatus(ctx context.Context, matchID uuid.UUID, status string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.Match{}).
+		Where("id = ? AND status = ?", matchID, models.MatchOpen).
+		Update("status", status)
+	return result.RowsAffected > 0, result.Error
+}
+
+// DueForCutoff returns open matches whose cutoff has passed.
+func (r *MatchRepository) DueForCutoff(ctx context.Context, now time.Time, limit int) ([]models.Match, error) {
+	var matches []models.Match
+	err := withPlayers(r.db.WithContext(ctx)).
+		Where("status = ? AND cutoff_at <= ?", models.MatchOpen, now).
+		Order("cutoff_at ASC").
+		Limit(limit).
+		Find(&matches).Error
+	return matches, err
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) *PaymentRepository {
+	return &PaymentRepository{
+		db: db,
+	}
+}
+
+// withShares preloads a split's booking, turf and shares with their users.
+func withShares(db *gorm.DB) *gorm.DB {
+	return db.Preload("Booking.Turf").Preload("Booking.Team").
+		Preload("Shares", func(db *gorm.DB) *gorm.DB { return db.Order("payment_shares.created_at ASC") }).
+		Preload("Shares.User")
+}
+
+// CreateSplitBooking inserts a pending booking together with its split and shares.
+func (r *PaymentRepository) CreateSplitBooking(ctx context.Context, booking *models.Booking, split *models.PaymentSplit) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := insertBooking(tx, booking); err != nil {
+			return err
+		}
+		split.BookingID = booking.ID
+		if err := tx.Omit(clause.Associations).Create(split).Error; err != nil {
+			return err
+		}
+		for i := range split.Shares {
+			split.Shares[i].SplitID = split.ID
+		}
+		return tx.Omit(clause.Associations).Create(&split.Shares).Error
+	})
+}
+
+// GetSplitByBooking returns the split for a booking.
+func (r *PaymentRepository) GetSplitByBooking(ctx context.Context, bookingID string) (*models.PaymentSplit, error) {
+	var split models.PaymentSplit
+	if err := withShares(r.db.WithContext(ctx)).First(&split, "booking_id = ?", bookingID).Error; err != nil {
+		return nil, apperrors.FromDB(err, "payment split")
+	}
+	return &split, nil
+}
+
+// RecordPayment stores a successful charge against its share and reports whether it
+// covered the split, in which case the split is marked covered and its booking
+// confirmed. It fails with Conflict when the split stopped collecting, its deadline
+// passed or the share was already paid; the caller must then refund the charge.
+func (r *PaymentRepository) RecordPayment(ctx context.Context, payment *models.Payment) (bool, error) {
+	var covered bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var split models.PaymentSplit
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&split, "id = ?", payment.SplitID).Error; err != nil {
+			return apperrors.FromDB(err, "payment split")
+		}
+		now := time.Now()
+		if !split.Collecting(now) {
+			return apperrors.Conflict("the booking is no longer collecting payments")
+		}
+
+		result := tx.Model(&models.PaymentShare{}).
+			Where("id = ? AND paid_at IS NULL", payment.ShareID).
+			Update("paid_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.Conflict("your share is already paid")
+		}
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+
+		var unpaid int64
+		if err := tx.Model(&models.PaymentShare{}).
+			Where("split_id = ? AND paid_at IS NULL", split.ID).
+			Count(&unpaid).Error; err != nil {
+			return err
+		}
+		if unpaid > 0 {
+			return nil
+		}
+		if err := tx.Model(&split).Update("status", models.SplitCovered).Error; err != nil {
+			return err
+		}
+		covered = true
+		return tx.Model(&models.Booking{}).
+			Where("id = ? AND status = ?", split.BookingID, models.BookingPendingPayment).
+			Update("status", models.BookingConfirmed).Error
+	})
+	return covered, err
+}
+
+// DueForLapse returns collecting splits whose deadline has passed.
+func (r *PaymentRepository) DueForLapse(ctx context.Context, now time.Time, limit int) ([]models.PaymentSplit, error) {
+	var splits []models.PaymentSplit
+	err := withShares(r.db.WithContext(ctx)).
+		Where("status = ? AND deadline <= ?", models.SplitCollecting, now).
+		Order("deadline ASC").
+		Limit(limit).
+		Find(&splits).Error
+	return splits, err
+}
+
+// Lapse marks a still-collecting split lapsed and expires its booking, freeing the
+// field. It reports false when the split was no longer collecting (e.g. another
+// instance's sweeper got there first).
+func (r *PaymentRepository) Lapse(ctx context.Context, split *models.PaymentSplit) (bool, error) {
+	var lapsed bool
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(split).
+			Where("status = ?", models.SplitCollecting).
+			Update("status", models.SplitLapsed)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		lapsed = true
+		return tx.Model(&models.Booking{}).
+			Where("id = ? AND status = ?", split.BookingID, models.BookingPendingPayment).
+			Update("status", models.BookingExpired).Error
+	})
+	return lapsed, err
+}
+
+// DueForRefund returns payments still held for splits that lapsed or were cancelled.
+func (r *PaymentRepository) DueForRefund(ctx context.Context, limit int) ([]models.Payment, error) {
+	var payments []models.Payment
+	err := r.db.WithContext(ctx).
+		Joins("JOIN payment_splits ON payment_splits.id = payments.split_id").
+		Where("payments.status = ? AND payment_splits.status IN ?",
+			models.PaymentSucceeded, []string{models.SplitLapsed, models.SplitCancelled}).
+		Order("payments.created_at ASC").
+		Limit(limit).
+		Find(&payments).Error
+	return payments, err
+}
+
+// MarkRefunded records the provider's refund of a payment.
+func (r *PaymentRepository) MarkRefunded(ctx context.Context, paymentID uuid.UUID, refundID string) error {
+	return r.db.WithContext(ctx).Model(&models.Payment{}).
+		Where("id = ? AND status = ?", paymentID, models.PaymentSucceeded).
+		Updates(map[string]any{"status": models.PaymentRefunded, "refund_id": refundID, "refunded_at": time.Now()}).Error
+}
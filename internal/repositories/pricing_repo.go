@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PricingRepository struct {
+	db *gorm.DB
+}
+
+func NewPricingRepository(db *gorm.DB) *PricingRepository {
+	return &PricingRepository{
+		db: db,
+	}
+}
+
+// Create inserts the rule unless fn, given the turf's other rules, rejects it. The
+// turf row is locked so two overlapping rules cannot be added at once.
+func (r *PricingRepository) Create(ctx context.Context, rule *models.PricingRule, fn func(existing []models.PricingRule) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTurf(tx, rule.TurfID); err != nil {
+			return err
+		}
+		var existing []models.PricingRule
+		if err := tx.Where("turf_id = ?", rule.TurfID).Find(&existing).Error; err != nil {
+			return err
+		}
+		if err := fn(existing); err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(rule).Error
+	})
+}
+
+// ListByTurf returns the turf's rules by start hour.
+func (r *PricingRepository) ListByTurf(ctx context.Context, turfID uuid.UUID) ([]models.PricingRule, error) {
+	var rules []models.PricingRule
+	err := r.db.WithContext(ctx).
+		Where("turf_id = ?", turfID).
+		Order("from_hour ASC, created_at ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (r *PricingRepository) Delete(ctx context.Context, turfID uuid.UUID, id string) error {
+	result := r.db.WithContext(ctx).Where("turf_id = ? AND id = ?", turfID, id).Delete(&models.PricingRule{})
+	if result.Error != nil {
+		return apperrors.FromDB(result.Error, "pricing rule")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("pricing rule")
+	}
+	return nil
+}
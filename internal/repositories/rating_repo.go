@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RatingRepository struct {
+	db *gorm.DB
+}
+
+func NewRatingRepository(db *gorm.DB) *RatingRepository {
+	return &RatingRepository{
+		db: db,
+	}
+}
+
+// RateFunc computes new ratings from the current ones, keyed by user. Players without
+// a rating yet are missing from current.
+type RateFunc func(current map[uuid.UUID]models.PlayerRating) []models.PlayerRating
+
+// ListForPlayers returns the sport's ratings for the given users; users without one
+// are left out.
+func (r *RatingRepository) ListForPlayers(ctx context.Context, sportID uuid.UUID, userIDs []uuid.UUID) ([]models.PlayerRating, error) {
+	var ratings []models.PlayerRating
+	if len(userIDs) == 0 {
+		return ratings, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("sport_id = ? AND user_id IN ?", sportID, userIDs).
+		Find(&ratings).Error
+	return ratings, err
+}
+
+// ListByUser returns userID's ratings, most played sport first.
+func (r *RatingRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.PlayerRating, error) {
+	var ratings []models.PlayerRating
+	err := r.db.WithContext(ctx).Preload("Sport").
+		Where("user_id = ?", userID).
+		Order("games DESC").
+		Find(&ratings).Error
+	return ratings, err
+}
+
+// rate locks the players' current ratings in the sport, applies fn and saves what it
+// returns, all inside tx.
+func rate(tx *gorm.DB, sportID uuid.UUID, userIDs []uuid.UUID, fn RateFunc) error {
+	var locked []models.PlayerRating
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("sport_id = ? AND user_id IN ?", sportID, userIDs).
+		Find(&locked).Error; err != nil {
+		return err
+	}
+	current := make(map[uuid.UUID]models.PlayerRating, len(locked))
+	for _, r := range locked {
+		current[r.UserID] = r
+	}
+
+	updated := fn(current)
+	if len(updated) == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "sport_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rating", "deviation", "games", "last_played_at", "updated_at"}),
+	}).Create(&updated).Error
+}
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ResultRepository struct {
+	db *gorm.DB
+}
+
+func NewResultRepository(db *gorm.DB) *ResultRepository {
+	return &ResultRepository{
+		db: db,
+	}
+}
+
+// SportRecord is a player's games in one sport.
+type SportRecord struct {
+	SportID uuid.UUID
+	Played  int
+	Won     int
+	Drawn   int
+	Lost    int
+	Points  int
+}
+
+// StatTotal is a player's total for one stat in one sport.
+type StatTotal struct {
+	SportID uuid.UUID
+	Name    string
+	Total   int
+}
+
+// BoardFilter narrows a leaderboard query to a sport, and optionally a turf or city.
+type BoardFilter struct {
+	SportID uuid.UUID
+	TurfID  *uuid.UUID
+	City    string
+}
+
+// BoardRow is one player's points and games on a board.
+type BoardRow struct {
+	UserID uuid.UUID
+	Points int
+	Played int
+}
+
+// PlayerTotal is a player's points and games at one turf in one sport, the finest
+// grain every leaderboard is summed from.
+type PlayerTotal struct {
+	SportID uuid.UUID
+	TurfID  uuid.UUID
+	City    string
+	UserID  uuid.UUID
+	Points  int
+	Played  int
+}
+
+// Create records a result and its players, and updates their ratings in the sport
+// with fn in the same transaction. A match has at most one result.
+func (r *ResultRepository) Create(ctx context.Context, result *models.MatchResult, sportID uuid.UUID, fn RateFunc) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(result).Error; err != nil {
+			return apperrors.FromDB(err, "result for this match")
+		}
+		userIDs := make([]uuid.UUID, len(result.Players))
+		for i := range result.Players {
+			result.Players[i].ResultID = result.ID
+			userIDs[i] = result.Players[i].UserID
+		}
+		if err := tx.Omit(clause.Associations).Create(&result.Players).Error; err != nil {
+			return err
+		}
+		return rate(tx, sportID, userIDs, fn)
+	})
+}
+
+func (r *ResultRepository) GetByMatch(ctx context.Context, matchID string) (*models.MatchResult, error) {
+	var result models.MatchResult
+	err := r.db.WithContext(ctx).
+		Preload("Players", func(db *gorm.DB) *gorm.DB { return db.Order("side ASC") }).
+		Preload("Players.User").
+		First(&result, "match_id = ?", matchID).Error
+	if err != nil {
+		return nil, apperrors.FromDB(err, "match result")
+	}
+	return &result, nil
+}
+
+// Records returns userID's games per sport.
+func (r *ResultRepository) Records(ctx context.Context, userID uuid.UUID) ([]SportRecord, error) {
+	var records []SportRecord
+	err := r.db.WithContext(ctx).Model(&models.PlayerResult{}).
+		Select(`sport_id, COUNT(*) AS played,
+			COUNT(*) FILTER (WHERE outcome = ?) AS won,
+			COUNT(*) FILTER (WHERE outcome = ?) AS drawn,
+			COUNT(*) FILTER (WHERE outcome = ?) AS lost,
+			COALESCE(SUM(points), 0) AS points`,
+			models.OutcomeWon, models.OutcomeDrawn, models.OutcomeLost).
+		Where("user_id = ?", userID).
+		Group("sport_id").
+		Order("played DESC").
+		Scan(&records).Error
+	return records, err
+}
+
+// StatTotals sums userID's recorded stats per sport.
+func (r *ResultRepository) StatTotals(ctx context.Context, userID uuid.UUID) ([]StatTotal, error) {
+	var totals []StatTotal
+	err := r.db.WithContext(ctx).
+		Raw(`SELECT pr.sport_id, s.key AS name, SUM(s.value::int) AS total
+			FROM player_results pr, jsonb_each_text(pr.stats) s
+			WHERE pr.user_id = ?
+			GROUP BY pr.sport_id, s.key`, userID).
+		Scan(&totals).Error
+	return totals, err
+}
+
+// Board ranks players straight from Postgres, for when the Redis boards cannot
+// answer.
+func (r *ResultRepository) Board(ctx context.Context, filter BoardFilter, limit int) ([]BoardRow, error) {
+	query := r.db.WithContext(ctx).Model(&models.PlayerResult{}).
+		Select("user_id, SUM(points) AS points, COUNT(*) AS played").
+		Where("sport_id = ?", filter.SportID)
+	switch {
+	case filter.TurfID != nil:
+		query = query.Where("turf_id = ?", *filter.TurfID)
+	case filter.City != "":
+		query = query.Where("LOWER(TRIM(city)) = ?", filter.City)
+	}
+
+	var rows []BoardRow
+	err := query.Group("user_id").Order("points DESC, played ASC").Limit(limit).Scan(&rows).Error
+	return rows, err
+}
+
+// PlayerTotals returns every player's points and games per sport and turf, for
+// rebuilding the leaderboards.
+func (r *ResultRepository) PlayerTotals(ctx context.Context) ([]PlayerTotal, error) {
+	var totals []PlayerTotal
+	err := r.db.WithContext(ctx).Model(&models.PlayerResult{}).
+		Select("sport_id, turf_id, MAX(city) AS city, user_id, SUM(points) AS points, COUNT(*) AS played").
+		Group("sport_id, turf_id, user_id").
+		Scan(&totals).Error
+	return totals, err
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{
+		db: db,
+	}
+}
+
+// Create inserts the review and refreshes the turf's rating. A player reviews a turf
+// at most once.
+func (r *ReviewRepository) Create(ctx context.Context, review *models.TurfReview) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTurf(tx, review.TurfID); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(review).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return apperrors.Conflict("you have already reviewed this turf").WithCause(err)
+			}
+			return err
+		}
+		return refreshRating(tx, review.TurfID)
+	})
+}
+
+// lockTurf locks the turf row so concurrent rating refreshes see each other's reviews.
+func lockTurf(tx *gorm.DB, turfID uuid.UUID) error {
+	var turf models.Turf
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&turf, "id = ?", turfID).Error
+	return apperrors.FromDB(err, "turf")
+}
+
+// refreshRating recomputes the turf's rating average and count from its visible
+// reviews.
+func refreshRating(tx *gorm.DB, turfID uuid.UUID) error {
+	return tx.Exec(`UPDATE turves SET rating_average = COALESCE(r.average, 0), rating_count = r.count
+		FROM (SELECT AVG(rating) AS average, COUNT(*) AS count FROM turf_reviews WHERE turf_id = ? AND status = ?) r
+		WHERE turves.id = ?`, turfID, models.ReviewVisible, turfID).Error
+}
+
+// Exists reports whether userID has already reviewed the turf.
+func (r *ReviewRepository) Exists(ctx context.Context, turfID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.TurfReview{}).Where("turf_id = ? AND user_id = ?", turfID, userID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *ReviewRepository) GetByID(ctx context.Context, id string) (*models.TurfReview, error) {
+	var review models.TurfReview
+	if err := r.db.WithContext(ctx).Preload("User").Preload("Turf").First(&review, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "review")
+	}
+	return &review, nil
+}
+
+// ListByTurf returns a page of the turf's visible reviews, newest first.
+func (r *ReviewRepository) ListByTurf(ctx context.Context, turfID uuid.UUID, page, pageSize int) ([]models.TurfReview, int64, error) {
+	visible := func(db *gorm.DB) *gorm.DB {
+		return db.Where("turf_id = ? AND status = ?", turfID, models.ReviewVisible)
+	}
+
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.TurfReview{}).Scopes(visible).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var reviews []models.TurfReview
+	err := r.db.WithContext(ctx).Preload("User").Scopes(visible).
+		Order("created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&reviews).Error
+	return reviews, total, err
+}
+
+// ListReported returns a page of reviews with at least one report, most reported
+// first, hidden ones included.
+func (r *ReviewRepository) ListReported(ctx context.Context, page, pageSize int) ([]models.TurfReview, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.TurfReview{}).Where("report_count > 0").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var reviews []models.TurfReview
+	err := r.db.WithContext(ctx).Preload("User").
+		Where("report_count > 0").
+		Order("report_count DESC, created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&reviews).Error
+	return reviews, total, err
+}
+
+// Reply sets the owner's reply, replacing any earlier one.
+func (r *ReviewRepository) Reply(ctx context.Context, review *models.TurfReview, reply string, at time.Time) error {
+	err := r.db.WithContext(ctx).Model(review).Updates(map[string]any{
+		"owner_reply": reply,
+		"replied_at":  at,
+	}).Error
+	if err != nil {
+		return err
+	}
+	review.OwnerReply = reply
+	review.RepliedAt = &at
+	return nil
+}
+
+// Report records userID's report of the review and bumps its report count. Each user
+// reports a review once.
+func (r *ReviewRepository) Report(ctx context.Context, report *models.ReviewReport) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(report).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return apperrors.Conflict("you have already reported this review").WithCause(err)
+			}
+			return err
+		}
+		return tx.Model(&models.TurfReview{}).
+			Where("id = ?", report.ReviewID).
+			UpdateColumn("report_count", gorm.Expr("report_count + 1")).Error
+	})
+}
+
+// SetStatus hides or restores the review and refreshes the turf's rating. reason is
+// kept with a hidden review and cleared on restore.
+func (r *ReviewRepository) SetStatus(ctx context.Context, review *models.TurfReview, status, reason string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTurf(tx, review.TurfID); err != nil {
+			return err
+		}
+		if err := tx.Model(review).Updates(map[string]any{
+			"status":        status,
+			"hidden_reason": reason,
+		}).Error; err != nil {
+			return err
+		}
+		review.Status = status
+		review.HiddenReason = reason
+		return refreshRating(tx, review.TurfID)
+	})
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// kmPerDegreeLatitude converts a radius to degrees of latitude for a bounding filter.
+	kmPerDegreeLatitude = 111.0
+	// earthRadiusKm matches helpers.HaversineKm, so SQL and Go agree on distances.
+	earthRadiusKm = 6371.0
+)
+
+type SavedSearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSavedSearchRepository(db *gorm.DB) *SavedSearchRepository {
+	return &SavedSearchRepository{
+		db: db,
+	}
+}
+
+// OpenSlot is a field freed at a turf, to be matched against saved searches. TimeZone
+// is the turf's, which searches' daily hours are read in. Searches of the Excluded
+// users are skipped.
+type OpenSlot struct {
+	TurfID    uuid.UUID
+	Latitude  float64
+	Longitude float64
+	TimeZone  string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Excluded  []uuid.UUID
+}
+
+func (r *SavedSearchRepository) Create(ctx context.Context, search *models.SavedSearch) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(search).Error
+}
+
+// CountActive counts userID's searches whose window has not ended by now.
+func (r *SavedSearchRepository) CountActive(ctx context.Context, userID uuid.UUID, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.SavedSearch{}).
+		Where("user_id = ? AND window_end > ?", userID, now).
+		Count(&count).Error
+	return count, err
+}
+
+// ListByUser returns userID's searches, newest first.
+func (r *SavedSearchRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.SavedSearch, error) {
+	var searches []models.SavedSearch
+	err := r.db.WithContext(ctx).Preload("Sport").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&searches).Error
+	return searches, err
+}
+
+func (r *SavedSearchRepository) GetByID(ctx context.Context, id string) (*models.SavedSearch, error) {
+	var search models.SavedSearch
+	if err := r.db.WithContext(ctx).Preload("Sport").First(&search, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "saved search")
+	}
+	return &search, nil
+}
+
+func (r *SavedSearchRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&models.SavedSearch{}, "id = ?", id).Error
+}
+
+// Matching returns up to limit searches, oldest first with their users, that the
+// slot satisfies: the turf is within the search's radius, the slot overlaps its window
+// and its daily hours on the day the slot starts at the turf, and the search was last notified
+// before notifiedBefore. Turfs don't list their sports, so a search with a sport only
+// matches turfs that have hosted it, in an open match, tournament or team booking, or
+// that have hosted nothing yet.
+func (r *SavedSearchRepository) Matching(ctx context.Context, slot OpenSlot, notifiedBefore time.Time, limit int) ([]models.SavedSearch, error) {
+	hosted := r.db.Raw(`SELECT m.sport_id FROM matches m JOIN bookings b ON b.id = m.booking_id WHERE b.turf_id = @turf
+		UNION SELECT t.sport_id FROM tournaments t WHERE t.turf_id = @turf
+		UNION SELECT tm.sport_id FROM teams tm JOIN bookings b ON b.team_id = tm.id WHERE b.turf_id = @turf`,
+		map[string]any{"turf": slot.TurfID})
+
+	query := r.db.WithContext(ctx).Preload("User").Preload("Sport").
+		Where("window_start < ? AND window_end > ?", slot.EndsAt, slot.StartsAt).
+		// The latitude band is a cheap prefilter for the exact distance.
+		Where("ABS(latitude - @lat) <= radius_km / @kmPerDegree AND "+
+			"2 * @radius * ASIN(LEAST(1, SQRT(POWER(SIN(RADIANS(latitude - @lat) / 2), 2) + "+
+			"COS(RADIANS(@lat)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - @lng) / 2), 2)))) <= radius_km",
+			map[string]any{"lat": slot.Latitude, "lng": slot.Longitude, "kmPerDegree": kmPerDegreeLatitude, "radius": earthRadiusKm}).
+		// Daily hours are read on the day the slot starts, in the turf's time zone.
+		Where("(@start AT TIME ZONE @zone) < DATE_TRUNC('day', @start AT TIME ZONE @zone) + to_hour * INTERVAL '1 hour' AND "+
+			"(@end AT TIME ZONE @zone) > DATE_TRUNC('day', @start AT TIME ZONE @zone) + from_hour * INTERVAL '1 hour'",
+			map[string]any{"start": slot.StartsAt, "end": slot.EndsAt, "zone": slot.TimeZone}).
+		Where("last_notified_at IS NULL OR last_notified_at < ?", notifiedBefore).
+		Where("sport_id IS NULL OR sport_id IN (?) OR NOT EXISTS (?)", hosted, hosted)
+	if len(slot.Excluded) > 0 {
+		query = query.Where("user_id NOT IN ?", slot.Excluded)
+	}
+
+	var searches []models.SavedSearch
+	err := query.Order("created_at ASC").Limit(limit).Find(&searches).Error
+	return searches, err
+}
+
+// MarkNotified records that the searches were notified at.
+func (r *SavedSearchRepository) MarkNotified(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&models.SavedSearch{}).
+		Where("id IN ?", ids).
+		Update("last_notified_at", at).Error
+}
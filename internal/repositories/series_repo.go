@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewSeriesRepository(db *gorm.DB) *SeriesRepository {
+	return &SeriesRepository{
+		db: db,
+	}
+}
+
+// Conflicts returns the occurrences whose field is already held, or closed, for any of
+// their time.
+func (r *SeriesRepository) Conflicts(ctx context.Context, occurrences []models.Booking) ([]models.Booking, error) {
+	return conflicts(r.db.WithContext(ctx), occurrences)
+}
+
+func conflicts(db *gorm.DB, occurrences []models.Booking) ([]models.Booking, error) {
+	var clashing []models.Booking
+	for _, o := range occurrences {
+		overlapping, err := countOverlapping(db, o.TurfID, o.FieldNumber, o.StartsAt, o.EndsAt)
+		if err != nil {
+			return nil, err
+		}
+		closed, err := countClosures(db, o.TurfID, o.FieldNumber, o.StartsAt, o.EndsAt)
+		if err != nil {
+			return nil, err
+		}
+		if overlapping > 0 || closed > 0 {
+			clashing = append(clashing, o)
+		}
+	}
+	return clashing, nil
+}
+
+// Create inserts the series and its occurrences, series.Bookings, unless any of them
+// overlaps a booking holding the field or a closure; those are returned and nothing is
+// written.
+// The turf row is locked for the check, as for single bookings.
+func (r *SeriesRepository) Create(ctx context.Context, series *models.BookingSeries) ([]models.Booking, error) {
+	var clashing []models.Booking
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTurf(tx, series.TurfID); err != nil {
+			return err
+		}
+		var err error
+		if clashing, err = conflicts(tx, series.Bookings); err != nil || len(clashing) > 0 {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Create(series).Error; err != nil {
+			return err
+		}
+		for i := range series.Bookings {
+			series.Bookings[i].SeriesID = &series.ID
+		}
+		return tx.Omit(clause.Associations).Create(&series.Bookings).Error
+	})
+	return clashing, err
+}
+
+func (r *SeriesRepository) GetByID(ctx context.Context, id string) (*models.BookingSeries, error) {
+	var series models.BookingSeries
+	err := r.db.WithContext(ctx).Preload("Turf").Preload("Turf.Hours").Preload("Team").
+		Preload("Bookings", func(db *gorm.DB) *gorm.DB { return db.Order("starts_at ASC") }).
+		First(&series, "id = ?", id).Error
+	if err != nil {
+		return nil, apperrors.FromDB(err, "booking series")
+	}
+	return &series, nil
+}
+
+// ListByUser returns the series userID booked or whose team they belong to, newest
+// first.
+func (r *SeriesRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]models.BookingSeries, error) {
+	var series []models.BookingSeries
+	teams := r.db.Model(&models.TeamMember{}).Select("team_id").Where("user_id = ?", userID)
+	err := r.db.WithContext(ctx).Preload("Turf").Preload("Turf.Hours").Preload("Team").
+		Preload("Bookings", func(db *gorm.DB) *gorm.DB { return db.Order("starts_at ASC") }).
+		Where("booked_by_id = ? OR team_id IN (?)", userID, teams).
+		Order("created_at DESC").
+		Find(&series).Error
+	return series, err
+}
+
+// Cancel marks the series cancelled along with its occurrences still holding their
+// field that start after now; earlier occurrences are left as they are.
+func (r *SeriesRepository) Cancel(ctx context.Context, series *models.BookingSeries, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(series).
+			Where("status = ?", models.SeriesActive).
+			Update("status", models.SeriesCancelled)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.Conflict("booking series is already cancelled")
+		}
+		series.Status = models.SeriesCancelled
+
+		var ids []uuid.UUID
+		for _, b := range series.Bookings {
+			if b.StartsAt.After(now) {
+				ids = append(ids, b.ID)
+			}
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		_, err := cancelHolding(tx, ids)
+		return err
+	})
+}
+
+// Reschedule moves an occurrence to another field or time and reprices it, unless the
+// new slot overlaps another booking holding that field or a closure of it. A moved
+// occurrence is no longer flagged by the closures it was moved out of.
+func (r *SeriesRepository) Reschedule(ctx context.Context, booking *models.Booking, field int, start, end time.Time, amount int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockTurf(tx, booking.TurfID); err != nil {
+			return err
+		}
+		var overlapping int64
+		if err := tx.Model(&models.Booking{}).
+			Where("turf_id = ? AND field_number = ? AND status IN ? AND id <> ?", booking.TurfID, field, holdingStatuses, booking.ID).
+			Where("starts_at < ? AND ends_at > ?", end, start).
+			Count(&overlapping).Error; err != nil {
+			return err
+		}
+		if overlapping > 0 {
+			return apperrors.Conflict("the field is already booked for that time")
+		}
+		closed, err := countClosures(tx, booking.TurfID, field, start, end)
+		if err != nil {
+			return err
+		}
+		if closed > 0 {
+			return apperrors.Conflict("the field is closed for that time")
+		}
+
+		result := tx.Model(booking).
+			Where("status = ?", models.BookingConfirmed).
+			Updates(map[string]any{"field_number": field, "starts_at": start, "ends_at": end, "amount": amount, "closure_id": nil})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.Conflict("only confirmed occurrences can be rescheduled")
+		}
+		if err := tx.Exec("DELETE FROM booking_closures WHERE booking_id = ?", booking.ID).Error; err != nil {
+			return err
+		}
+		booking.FieldNumber, booking.StartsAt, booking.EndsAt, booking.Amount = field, start, end, amount
+		booking.ClosureID = nil
+		return nil
+	})
+}
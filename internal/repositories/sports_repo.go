@@ -1,6 +1,9 @@
 package repositories
 
 import (
+	"context"
+
+	"github.com/musishere/sportsApp/internal/apperrors"
 	"github.com/musishere/sportsApp/internal/models"
 	"gorm.io/gorm"
 )
@@ -15,36 +18,38 @@ func NewSportsRepository(db *gorm.DB) *SportsRepository {
 	}
 }
 
-func (r *SportsRepository) CreateSport(sports *models.Sports) error {
-	return r.db.Create(sports).Error
+func (r *SportsRepository) CreateSport(ctx context.Context, sports *models.Sports) error {
+	return r.db.WithContext(ctx).Create(sports).Error
 }
 
-func (r *SportsRepository) GetSports() []models.Sports {
-	var sports []models.Sports
-	r.db.Find(&sports)
-	return sports
+func (r *SportsRepository) GetSports(ctx context.Context) ([]models.Sports, error) {
+	sports := []models.Sports{}
+	if err := r.db.WithContext(ctx).Find(&sports).Error; err != nil {
+		return nil, err
+	}
+	return sports, nil
 }
 
-func (r *SportsRepository) GetSportsByID(id string) (models.Sports, error) {
+func (r *SportsRepository) GetSportsByID(ctx context.Context, id string) (models.Sports, error) {
 	var sport models.Sports
-	result := r.db.Where("id = ?", id).First(&sport)
+	result := r.db.WithContext(ctx).Where("id = ?", id).First(&sport)
 	if result.Error != nil {
-		return models.Sports{}, result.Error
+		return models.Sports{}, apperrors.FromDB(result.Error, "sport")
 	}
 	return sport, nil
 }
 
-func (r *SportsRepository) UpdateSport(sport *models.Sports) error {
-	return r.db.Save(sport).Error
+func (r *SportsRepository) UpdateSport(ctx context.Context, sport *models.Sports) error {
+	return r.db.WithContext(ctx).Save(sport).Error
 }
 
-func (r *SportsRepository) DeleteSport(id string) error {
-	result := r.db.Where("id = ?", id).Delete(&models.Sports{})
+func (r *SportsRepository) DeleteSport(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Sports{})
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		return apperrors.NotFound("sport")
 	}
 	return nil
 }
@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TeamRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamRepository(db *gorm.DB) *TeamRepository {
+	return &TeamRepository{
+		db: db,
+	}
+}
+
+// withMembers preloads the sport and the members with their users, oldest member first.
+func withMembers(db *gorm.DB) *gorm.DB {
+	return db.Preload("Sport").
+		Preload("Members", func(db *gorm.DB) *gorm.DB { return db.Order("team_members.created_at ASC") }).
+		Preload("Members.User")
+}
+
+// Create inserts the team together with its captain as the first member.
+func (r *TeamRepository) Create(ctx context.Context, team *models.Team) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(team).Error; err != nil {
+			return apperrors.FromDB(err, "team")
+		}
+		captain := &models.TeamMember{TeamID: team.ID, UserID: team.CaptainID, Role: models.TeamRoleCaptain}
+		return tx.Omit(clause.Associations).Create(captain).Error
+	})
+	if err != nil {
+		return err
+	}
+	return withMembers(r.db.WithContext(ctx)).First(team, "id = ?", team.ID).Error
+}
+
+func (r *TeamRepository) GetByID(ctx context.Context, id string) (*models.Team, error) {
+	var team models.Team
+	if err := withMembers(r.db.WithContext(ctx)).First(&team, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "team")
+	}
+	return &team, nil
+}
+
+// ListByMember returns the teams userID belongs to, newest first.
+func (r *TeamRepository) ListByMember(ctx context.Context, userID uuid.UUID) ([]models.Team, error) {
+	var teams []models.Team
+	err := withMembers(r.db.WithContext(ctx)).
+		Where("id IN (?)", r.db.Model(&models.TeamMember{}).Select("team_id").Where("user_id = ?", userID)).
+		Order("created_at DESC").
+		Find(&teams).Error
+	return teams, err
+}
+
+func (r *TeamRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Team{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("team")
+	}
+	return nil
+}
+
+// IsMember reports whether userID belongs to teamID.
+func (r *TeamRepository) IsMember(ctx context.Context, teamID, userID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.TeamMember{}).
+		Where("team_id = ? AND user_id = ?", teamID, userID).Count(&count).Error
+	return count > 0, err
+}
+
+// RemoveMember removes userID from the team. When newCaptainID is set, captaincy passes
+// to that member in the same transaction.
+func (r *TeamRepository) RemoveMember(ctx context.Context, teamID, userID uuid.UUID, newCaptainID *uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("team_id = ? AND user_id = ?", teamID, userID).Delete(&models.TeamMember{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NotFound("team member")
+		}
+		if newCaptainID == nil {
+			return nil
+		}
+		if err := tx.Model(&models.TeamMember{}).
+			Where("team_id = ? AND user_id = ?", teamID, *newCaptainID).
+			Update("role", models.TeamRoleCaptain).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Team{}).Where("id = ?", teamID).Update("captain_id", *newCaptainID).Error
+	})
+}
+
+func (r *TeamRepository) CreateInvitation(ctx context.Context, invitation *models.TeamInvitation) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(invitation).Error
+}
+
+func (r *TeamRepository) GetInvitation(ctx context.Context, id string) (*models.TeamInvitation, error) {
+	var invitation models.TeamInvitation
+	if err := r.db.WithContext(ctx).Preload("Team.Sport").First(&invitation, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "invitation")
+	}
+	return &invitation, nil
+}
+
+// CountPendingInvitations counts the team's unexpired pending invitations.
+func (r *TeamRepository) CountPendingInvitations(ctx context.Context, teamID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.TeamInvitation{}).
+		Where("team_id = ? AND status = ? AND expires_at > ?", teamID, models.InvitationPending, time.Now()).
+		Count(&count).Error
+	return count, err
+}
+
+// HasPendingInvitation reports whether the team already has an unexpired pending
+// invitation for the email or phone. Empty values never match.
+func (r *TeamRepository) HasPendingInvitation(ctx context.Context, teamID uuid.UUID, email, phone string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.TeamInvitation{}).
+		Where("team_id = ? AND status = ? AND expires_at > ?", teamID, models.InvitationPending, time.Now()).
+		Where(r.db.Where("email <> '' AND email = ?", email).Or("phone <> '' AND phone = ?", phone)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListPendingInvitations returns the unexpired pending invitations addressed to the
+// email or phone, newest first.
+func (r *TeamRepository) ListPendingInvitations(ctx context.Context, email, phone string) ([]models.TeamInvitation, error) {
+	var invitations []models.TeamInvitation
+	err := r.db.WithContext(ctx).Preload("Team.Sport").
+		Where("status = ? AND expires_at > ?", models.InvitationPending, time.Now()).
+		Where(r.db.Where("email <> '' AND email = ?", email).Or("phone <> '' AND phone = ?", phone)).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// DeclineInvitation marks a pending invitation declined.
+func (r *TeamRepository) DeclineInvitation(ctx context.Context, invitation *models.TeamInvitation) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(invitation).
+		Where("status = ?", models.InvitationPending).
+		Updates(map[string]any{"status": models.InvitationDeclined, "responded_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.Conflict("invitation has already been answered")
+	}
+	invitation.Status = models.InvitationDeclined
+	invitation.RespondedAt = &now
+	return nil
+}
+
+// AcceptInvitation adds the invitee to the team and marks the invitation accepted. The
+// team row is locked so concurrent acceptances cannot push it past maxPlayers.
+func (r *TeamRepository) AcceptInvitation(ctx context.Context, invitation *models.TeamInvitation, userID uuid.UUID, maxPlayers int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var team models.Team
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&team, "id = ?", invitation.TeamID).Error; err != nil {
+			return apperrors.FromDB(err, "team")
+		}
+
+		var members int64
+		if err := tx.Model(&models.TeamMember{}).Where("team_id = ?", team.ID).Count(&members).Error; err != nil {
+			return err
+		}
+		if int(members) >= maxPlayers {
+			return apperrors.Conflict("team is full")
+		}
+
+		now := time.Now()
+		result := tx.Model(invitation).
+			Where("status = ?", models.InvitationPending).
+			Updates(map[string]any{"status": models.InvitationAccepted, "responded_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.Conflict("invitation has already been answered")
+		}
+
+		member := &models.TeamMember{TeamID: team.ID, UserID: userID, Role: models.TeamRoleMember}
+		if err := tx.Omit(clause.Associations).Create(member).Error; err != nil {
+			return apperrors.FromDB(err, "team member")
+		}
+		invitation.Status = models.InvitationAccepted
+		invitation.RespondedAt = &now
+		return nil
+	})
+}
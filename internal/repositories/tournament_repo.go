@@ -0,0 +1,304 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TournamentRepository struct {
+	db *gorm.DB
+}
+
+func NewTournamentRepository(db *gorm.DB) *TournamentRepository {
+	return &TournamentRepository{
+		db: db,
+	}
+}
+
+// TournamentFilter narrows a tournament listing; zero fields match everything.
+type TournamentFilter struct {
+	SportID *uuid.UUID
+	TurfID  *uuid.UUID
+	Status  string
+}
+
+// withEntrants preloads the sport, turf, champion and registered teams in seed order.
+func withEntrants(db *gorm.DB) *gorm.DB {
+	return db.Preload("Sport").Preload("Turf").Preload("Turf.Hours").Preload("Champion").
+		Preload("Teams", func(db *gorm.DB) *gorm.DB { return db.Order("tournament_teams.seed ASC") }).
+		Preload("Teams.Team")
+}
+
+func (r *TournamentRepository) Create(ctx context.Context, tournament *models.Tournament) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(tournament).Error
+}
+
+func (r *TournamentRepository) GetByID(ctx context.Context, id string) (*models.Tournament, error) {
+	var tournament models.Tournament
+	if err := withEntrants(r.db.WithContext(ctx)).First(&tournament, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "tournament")
+	}
+	return &tournament, nil
+}
+
+// List returns a page of tournaments, soonest first, with the total matching filter.
+func (r *TournamentRepository) List(ctx context.Context, filter TournamentFilter, page, pageSize int) ([]models.Tournament, int64, error) {
+	filtered := func(db *gorm.DB) *gorm.DB {
+		if filter.SportID != nil {
+			db = db.Where("sport_id = ?", *filter.SportID)
+		}
+		if filter.TurfID != nil {
+			db = db.Where("turf_id = ?", *filter.TurfID)
+		}
+		if filter.Status != "" {
+			db = db.Where("status = ?", filter.Status)
+		}
+		return db
+	}
+
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.Tournament{}).Scopes(filtered).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var tournaments []models.Tournament
+	err := withEntrants(r.db.WithContext(ctx)).Scopes(filtered).
+		Order("starts_at ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&tournaments).Error
+	return tournaments, total, err
+}
+
+// lockTournament locks the tournament for the rest of the transaction.
+func lockTournament(tx *gorm.DB, id uuid.UUID) (*models.Tournament, error) {
+	var tournament models.Tournament
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&tournament, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "tournament")
+	}
+	return &tournament, nil
+}
+
+// RegisterTeam enters a team while registration is open and the tournament is not full.
+func (r *TournamentRepository) RegisterTeam(ctx context.Context, tournamentID, teamID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tournament, err := lockTournament(tx, tournamentID)
+		if err != nil {
+			return err
+		}
+		if tournament.Status != models.TournamentRegistration {
+			return apperrors.Conflict("registration for this tournament is closed")
+		}
+
+		var registered struct {
+			Count   int64
+			MaxSeed int
+		}
+		if err := tx.Model(&models.TournamentTeam{}).
+			Select("COUNT(*) AS count, COALESCE(MAX(seed), 0) AS max_seed").
+			Where("tournament_id = ?", tournamentID).
+			Scan(&registered).Error; err != nil {
+			return err
+		}
+		if int(registered.Count) >= tournament.MaxTeams {
+			return apperrors.Conflict("the tournament is full")
+		}
+
+		entry := &models.TournamentTeam{TournamentID: tournamentID, TeamID: teamID, Seed: registered.MaxSeed + 1}
+		if err := tx.Omit(clause.Associations).Create(entry).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return apperrors.Conflict("the team is already registered").WithCause(err)
+			}
+			return err
+		}
+		return nil
+	})
+}
+
+// WithdrawTeam removes a team while registration is open.
+func (r *TournamentRepository) WithdrawTeam(ctx context.Context, tournamentID, teamID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tournament, err := lockTournament(tx, tournamentID)
+		if err != nil {
+			return err
+		}
+		if tournament.Status != models.TournamentRegistration {
+			return apperrors.Conflict("teams cannot withdraw once the tournament is scheduled")
+		}
+		result := tx.Where("tournament_id = ? AND team_id = ?", tournamentID, teamID).Delete(&models.TournamentTeam{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.NotFound("tournament team")
+		}
+		return nil
+	})
+}
+
+// Schedule closes registration and stores the fixtures, each on a booking that holds
+// its field. groups assigns registered teams to groups. It fails with Conflict if a
+// field was booked in the meantime.
+func (r *TournamentRepository) Schedule(ctx context.Context, tournament *models.Tournament, groups map[uuid.UUID]int, fixtures []models.Fixture, bookings []models.Booking) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(tournament).
+			Where("status = ?", models.TournamentRegistration).
+			Update("status", models.TournamentScheduled)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.Conflict("the tournament is already scheduled")
+		}
+
+		for teamID, group := range groups {
+			if err := tx.Model(&models.TournamentTeam{}).
+				Where("tournament_id = ? AND team_id = ?", tournament.ID, teamID).
+				Update("group_number", group).Error; err != nil {
+				return err
+			}
+		}
+		for i := range bookings {
+			if err := insertBooking(tx, &bookings[i]); err != nil {
+				return err
+			}
+			fixtures[i].BookingID = bookings[i].ID
+		}
+		return tx.Omit(clause.Associations).Create(&fixtures).Error
+	})
+}
+
+// ListFixtures returns the tournament's fixtures in playing order.
+func (r *TournamentRepository) ListFixtures(ctx context.Context, tournamentID uuid.UUID) ([]models.Fixture, error) {
+	var fixtures []models.Fixture
+	err := r.db.WithContext(ctx).Preload("HomeTeam").Preload("AwayTeam").
+		Where("tournament_id = ?", tournamentID).
+		Order("starts_at ASC, field_number ASC").
+		Find(&fixtures).Error
+	return fixtures, err
+}
+
+func (r *TournamentRepository) GetFixture(ctx context.Context, tournamentID uuid.UUID, fixtureID string) (*models.Fixture, error) {
+	var fixture models.Fixture
+	err := r.db.WithContext(ctx).Preload("HomeTeam").Preload("AwayTeam").
+		First(&fixture, "id = ? AND tournament_id = ?", fixtureID, tournamentID).Error
+	if err != nil {
+		return nil, apperrors.FromDB(err, "fixture")
+	}
+	return &fixture, nil
+}
+
+// RecordResult completes a fixture with its score. In a knockout the winner moves into
+// the next round's fixture: from slot s into slot s/2, at home from an even slot.
+func (r *TournamentRepository) RecordResult(ctx context.Context, fixture *models.Fixture, homeScore, awayScore int, winnerID *uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tournament, err := lockTournament(tx, fixture.TournamentID)
+		if err != nil {
+			return err
+		}
+		if tournament.Status != models.TournamentScheduled && tournament.Status != models.TournamentInProgress {
+			return apperrors.Conflict("the tournament is not being played")
+		}
+
+		result := tx.Model(fixture).
+			Where("status = ? AND home_team_id IS NOT NULL AND away_team_id IS NOT NULL", models.FixtureScheduled).
+			Updates(map[string]any{
+				"home_score": homeScore,
+				"away_score": awayScore,
+				"winner_id":  winnerID,
+				"status":     models.FixtureCompleted,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.Conflict("the fixture already has a result or is still waiting for its teams")
+		}
+		fixture.HomeScore, fixture.AwayScore, fixture.WinnerID = &homeScore, &awayScore, winnerID
+		fixture.Status = models.FixtureCompleted
+
+		if err := tx.Model(tournament).
+			Where("status = ?", models.TournamentScheduled).
+			Update("status", models.TournamentInProgress).Error; err != nil {
+			return err
+		}
+		if fixture.Stage != models.StageKnockout || winnerID == nil {
+			return nil
+		}
+		side := "home_team_id"
+		if fixture.Slot%2 == 1 {
+			side = "away_team_id"
+		}
+		return tx.Model(&models.Fixture{}).
+			Where("tournament_id = ? AND stage = ? AND round = ? AND slot = ?",
+				fixture.TournamentID, models.StageKnockout, fixture.Round+1, fixture.Slot/2).
+			Update(side, *winnerID).Error
+	})
+}
+
+// CountUnplayed counts the stage's fixtures still waiting for a result.
+func (r *TournamentRepository) CountUnplayed(ctx context.Context, tournamentID uuid.UUID, stage string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Fixture{}).
+		Where("tournament_id = ? AND stage = ? AND status = ?", tournamentID, stage, models.FixtureScheduled).
+		Count(&count).Error
+	return count, err
+}
+
+// SeedKnockout fills the knockout sides waiting for group qualifiers, keyed by seed.
+// Sides already filled are left alone, so it is safe to call more than once.
+func (r *TournamentRepository) SeedKnockout(ctx context.Context, tournamentID uuid.UUID, qualifiers map[int]uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for seed, teamID := range qualifiers {
+			for _, side := range []string{"home", "away"} {
+				if err := tx.Model(&models.Fixture{}).
+					Where("tournament_id = ? AND stage = ? AND "+side+"_seed = ? AND "+side+"_team_id IS NULL",
+						tournamentID, models.StageKnockout, seed).
+					Update(side+"_team_id", teamID).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Complete marks a tournament being played as completed with its champion.
+func (r *TournamentRepository) Complete(ctx context.Context, tournamentID, championID uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&models.Tournament{}).
+		Where("id = ? AND status IN ?", tournamentID, []string{models.TournamentScheduled, models.TournamentInProgress}).
+		Updates(map[string]any{"status": models.TournamentCompleted, "champion_id": championID}).Error
+}
+
+// Cancel calls the tournament off, cancelling its unplayed fixtures and freeing their
+// fields.
+func (r *TournamentRepository) Cancel(ctx context.Context, tournament *models.Tournament) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(tournament).
+			Where("status NOT IN ?", []string{models.TournamentCompleted, models.TournamentCancelled}).
+			Update("status", models.TournamentCancelled)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return apperrors.Conflict("the tournament is already over")
+		}
+		tournament.Status = models.TournamentCancelled
+
+		unplayed := tx.Model(&models.Fixture{}).Select("booking_id").
+			Where("tournament_id = ? AND status = ?", tournament.ID, models.FixtureScheduled)
+		if err := tx.Model(&models.Booking{}).
+			Where("id IN (?) AND status = ?", unplayed, models.BookingConfirmed).
+			Update("status", models.BookingCancelled).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Fixture{}).
+			Where("tournament_id = ? AND status = ?", tournament.ID, models.FixtureScheduled).
+			Update("status", models.FixtureCancelled).Error
+	})
+}
@@ -1,7 +1,11 @@
 package repositories
 
 import (
+	"context"
+
+	"github.com/musishere/sportsApp/internal/apperrors"
 	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/types"
 	"gorm.io/gorm"
 )
 
@@ -15,18 +19,25 @@ func NewTurfRepository(db *gorm.DB) *TurfRepostitory {
 	}
 }
 
-func (r *TurfRepostitory) Create(turf *models.Turf) error {
-	if err := r.db.Create(turf).Error; err != nil {
-		return err
+func (r *TurfRepostitory) Create(ctx context.Context, turf *models.Turf) error {
+	if err := r.db.WithContext(ctx).Create(turf).Error; err != nil {
+		return apperrors.FromDB(err, "turf")
 	}
-	return r.db.Preload("Owner").Preload("Owner.Location").First(turf, turf.ID).Error
+	return r.db.WithContext(ctx).Preload("Owner").Preload("Owner.Location").Preload("Hours").First(turf, turf.ID).Error
+}
+
+// turfOrders maps the list orders callers may ask for to SQL. Rated turfs sort above
+// unrated ones, and among equal averages the more reviewed first.
+var turfOrders = map[string]string{
+	types.TurfSortRating: "rating_average DESC, rating_count DESC, created_at DESC",
+	types.TurfSortNewest: "created_at DESC",
 }
 
-func (r *TurfRepostitory) GetAllTurfsRepo(page, pageSize int) ([]models.Turf, int64, error) {
+func (r *TurfRepostitory) GetAllTurfsRepo(ctx context.Context, page, pageSize int, sort string) ([]models.Turf, int64, error) {
 	var turfs []models.Turf
 	var total int64
 
-	if err := r.db.Model(&models.Turf{}).Count(&total).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&models.Turf{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -38,32 +49,36 @@ func (r *TurfRepostitory) GetAllTurfsRepo(page, pageSize int) ([]models.Turf, in
 	}
 	offset := (page - 1) * pageSize
 
-	if err := r.db.Preload("Owner").Preload("Owner.Location").Offset(offset).Limit(pageSize).Find(&turfs).Error; err != nil {
+	query := r.db.WithContext(ctx).Preload("Owner").Preload("Owner.Location").Preload("Hours")
+	if order, ok := turfOrders[sort]; ok {
+		query = query.Order(order)
+	}
+	if err := query.Offset(offset).Limit(pageSize).Find(&turfs).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return turfs, total, nil
 }
 
-func (r *TurfRepostitory) GetTurfByID(id string) (*models.Turf, error) {
+func (r *TurfRepostitory) GetTurfByID(ctx context.Context, id string) (*models.Turf, error) {
 	var turf models.Turf
-	if err := r.db.Preload("Owner").Preload("Owner.Location").First(&turf, "id = ?", id).Error; err != nil {
-		return nil, err
+	if err := r.db.WithContext(ctx).Preload("Owner").Preload("Owner.Location").Preload("Hours").First(&turf, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "turf")
 	}
 	return &turf, nil
 }
 
-func (r *TurfRepostitory) UpdateTurf(turf *models.Turf) error {
-	return r.db.Save(turf).Error
+func (r *TurfRepostitory) UpdateTurf(ctx context.Context, turf *models.Turf) error {
+	return r.db.WithContext(ctx).Save(turf).Error
 }
 
-func (r *TurfRepostitory) DeleteTurf(id string) error {
-	result := r.db.Where("id = ?", id).Delete(&models.Turf{})
+func (r *TurfRepostitory) DeleteTurf(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Turf{})
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		return apperrors.NotFound("turf")
 	}
 	return nil
 }
@@ -1,8 +1,13 @@
 package repositories
 
 import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
 	"github.com/musishere/sportsApp/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserRepository struct {
@@ -15,39 +20,69 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 	}
 }
 
-func (r *UserRepository) CreateUser(user *models.User) error {
-	return r.db.Create(user).Error
+func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) error {
+	return apperrors.FromDB(r.db.WithContext(ctx).Create(user).Error, "user")
 }
 
-func (r *UserRepository) GetUserByID(id string) (*models.User, error) {
+func (r *UserRepository) GetUserByID(ctx context.Context, id string) (*models.User, error) {
 	var user models.User
-	if err := r.db.First(&user, "id = ?", id).Error; err != nil {
-		return nil, err
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
+		return nil, apperrors.FromDB(err, "user")
 	}
 
 	return &user, nil
 }
 
-func (r *UserRepository) GetUserByEmail(email string) (*models.User, error) {
+func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
-	if err := r.db.First(&user, "email = ?", email).Error; err != nil {
-		return nil, err
+	if err := r.db.WithContext(ctx).First(&user, "email = ?", email).Error; err != nil {
+		return nil, apperrors.FromDB(err, "user")
 	}
 	return &user, nil
 }
 
-func (r *UserRepository) GetUserByPhone(phone string) (*models.User, error) {
+func (r *UserRepository) GetUserByPhone(ctx context.Context, phone string) (*models.User, error) {
 	var user models.User
-	if err := r.db.First(&user, "phone = ?", phone).Error; err != nil {
+	if err := r.db.WithContext(ctx).First(&user, "phone = ?", phone).Error; err != nil {
+		return nil, apperrors.FromDB(err, "user")
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) UpdateUser(ctx context.Context, user *models.User) error {
+	return apperrors.FromDB(r.db.WithContext(ctx).Save(user).Error, "user")
+}
+
+// AttemptLogin locks the account with the email while fn decides a login attempt, then
+// saves the failed-login counters fn left on it. Concurrent attempts on one account
+// run one at a time, so none reads a count another is about to change.
+func (r *UserRepository) AttemptLogin(ctx context.Context, email string, fn func(user *models.User) error) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, "email = ?", email).Error; err != nil {
+			return apperrors.FromDB(err, "user")
+		}
+		if err := fn(&user); err != nil {
+			return err
+		}
+		return tx.Model(&user).Select("failed_login_attempts", "last_failed_login_at", "locked_until").Updates(&user).Error
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *UserRepository) UpdateUser(user *models.User) error {
-	return r.db.Save(user).Error
+func (r *UserRepository) DeleteUser(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&models.User{}, "id = ?", id).Error
 }
 
-func (r *UserRepository) DeleteUser(id string) error {
-	return r.db.Delete(&models.User{}, "id = ?", id).Error
+// ListByIDs returns the users with the given ids, in no particular order.
+func (r *UserRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]models.User, error) {
+	var users []models.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
+	return users, err
 }
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/handlers"
+)
+
+// SetupDocsRoutes serves the OpenAPI document and Swagger UI at the root, outside the
+// versioned API.
+func SetupDocsRoutes(router *gin.Engine) {
+	h := handlers.NewDocsHandler()
+
+	router.GET("/openapi.yaml", h.Spec)
+	router.GET("/docs", h.SwaggerUI)
+}
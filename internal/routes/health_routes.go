@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/internal/handlers"
+	"github.com/musishere/sportsApp/internal/health"
+)
+
+// SetupHealthRoutes mounts the probes at the root, outside the versioned API and its
+// rate limits, so orchestrators can always reach them.
+func SetupHealthRoutes(router *gin.Engine, checker *health.Checker) {
+	h := handlers.NewHealthHandler(checker)
+
+	router.GET("/healthz", h.Liveness)
+	router.GET("/readyz", h.Readiness)
+}
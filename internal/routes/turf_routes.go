@@ -3,13 +3,17 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/musishere/sportsApp/internal/handlers"
+	"github.com/musishere/sportsApp/internal/ratelimit"
 	"github.com/musishere/sportsApp/internal/services"
 )
 
-func SetupTurfRoutes(api *gin.RouterGroup, turfService *services.TurfService) {
-	api.POST("/turfs", handlers.NewTurfHandler(turfService).RegisterTurf)
-	api.GET("/turfs", handlers.NewTurfHandler(turfService).GetRegisteredTurfs)
-	api.GET("/turfs/:id", handlers.NewTurfHandler(turfService).GetRegisteredTurfByID)
-	api.PUT("/turfs/:id", handlers.NewTurfHandler(turfService).UpdateRegisteredTurf)
-	api.DELETE("/turfs/:id", handlers.NewTurfHandler(turfService).DeleteRegisteredTurf)
+func SetupTurfRoutes(api *gin.RouterGroup, turfService *services.TurfService, limiter *ratelimit.Limiter) {
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	api.POST("/turfs", write, handlers.NewTurfHandler(turfService).RegisterTurf)
+	api.GET("/turfs", read, handlers.NewTurfHandler(turfService).GetRegisteredTurfs)
+	api.GET("/turfs/:id", read, handlers.NewTurfHandler(turfService).GetRegisteredTurfByID)
+	api.PUT("/turfs/:id", write, handlers.NewTurfHandler(turfService).UpdateRegisteredTurf)
+	api.DELETE("/turfs/:id", write, handlers.NewTurfHandler(turfService).DeleteRegisteredTurf)
 }
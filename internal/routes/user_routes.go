@@ -2,16 +2,20 @@ package routes
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/config"
 	"github.com/musishere/sportsApp/internal/handlers"
+	"github.com/musishere/sportsApp/internal/oauth"
+	"github.com/musishere/sportsApp/internal/ratelimit"
 	"github.com/musishere/sportsApp/internal/services"
 )
 
-func SetupUserRoutes(api *gin.RouterGroup, userService *services.UserService) {
-	api.POST("/signup", handlers.NewUserHandler(userService).RegisterUser)
-	// api.POST("/verify-phone-otp", handlers.NewUserHandler(userService).VerifyOtp)
-	api.POST("/verify-email-otp", handlers.NewUserHandler(userService).VerifyEmailOtp)
-	api.POST("/login", handlers.NewUserHandler(userService).LoginUser)
-	api.GET("/get-currentUser", handlers.NewUserHandler(userService).GetCurrentUser)
-	api.POST("/logout", handlers.NewUserHandler(userService).LogOutUser)
-	// api.POST("/oauth-facebook", handlers.NewUserHandler(userService).SignUpOauth2Facebook)
+func SetupUserRoutes(api *gin.RouterGroup, userService *services.UserService, facebook *oauth.Facebook, limiter *ratelimit.Limiter, cookies config.CookieConfig) {
+	api.POST("/signup", limiter.Middleware(ratelimit.PolicyAuth), handlers.NewUserHandler(userService, facebook, cookies).RegisterUser)
+	// api.POST("/verify-phone-otp", limiter.Middleware(ratelimit.PolicyOTP), handlers.NewUserHandler(userService, facebook, cookies).VerifyOtp)
+	api.POST("/verify-email-otp", limiter.Middleware(ratelimit.PolicyOTP), handlers.NewUserHandler(userService, facebook, cookies).VerifyEmailOtp)
+	api.POST("/login", limiter.Middleware(ratelimit.PolicyAuth), handlers.NewUserHandler(userService, facebook, cookies).LoginUser)
+	api.GET("/get-currentUser", limiter.Middleware(ratelimit.PolicyRead), handlers.NewUserHandler(userService, facebook, cookies).GetCurrentUser)
+	api.GET("/login-history", limiter.Middleware(ratelimit.PolicyRead), handlers.NewUserHandler(userService, facebook, cookies).GetLoginHistory)
+	api.POST("/logout", limiter.Middleware(ratelimit.PolicyWrite), handlers.NewUserHandler(userService, facebook, cookies).LogOutUser)
+	// api.POST("/oauth-facebook", limiter.Middleware(ratelimit.PolicyAuth), handlers.NewUserHandler(userService, facebook, cookies).SignUpOauth2Facebook)
 }
@@ -0,0 +1,216 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/handlers"
+	"github.com/musishere/sportsApp/internal/middleware"
+	"github.com/musishere/sportsApp/internal/ratelimit"
+	"github.com/musishere/sportsApp/internal/services"
+)
+
+// API v2 wraps every response in {data, meta} and returns DTOs instead of models.
+
+func SetupV2UserRoutes(api *gin.RouterGroup, userService *services.UserService, limiter *ratelimit.Limiter, cookies config.CookieConfig) {
+	h := handlers.NewUserV2Handler(userService, cookies)
+
+	api.POST("/auth/signup", limiter.Middleware(ratelimit.PolicyAuth), h.Signup)
+	api.POST("/auth/verify-email", limiter.Middleware(ratelimit.PolicyOTP), h.VerifyEmail)
+	api.POST("/auth/login", limiter.Middleware(ratelimit.PolicyAuth), h.Login)
+	api.POST("/auth/logout", limiter.Middleware(ratelimit.PolicyWrite), h.Logout)
+	api.GET("/me", limiter.Middleware(ratelimit.PolicyRead), h.Me)
+	api.GET("/me/login-history", limiter.Middleware(ratelimit.PolicyRead), h.LoginHistory)
+	api.GET("/users/:id", limiter.Middleware(ratelimit.PolicyRead), h.GetUser)
+	api.GET("/admin/users/:id", limiter.Middleware(ratelimit.PolicyRead), h.AdminGetUser)
+}
+
+func SetupV2SportsRoutes(api *gin.RouterGroup, sportsService *services.SportsService, limiter *ratelimit.Limiter) {
+	h := handlers.NewSportsV2Handler(sportsService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	api.POST("/sports", write, h.CreateSport)
+	api.GET("/sports", read, h.ListSports)
+	api.GET("/sports/:id", read, h.GetSport)
+	api.PATCH("/sports/:id", write, h.UpdateSport)
+	api.DELETE("/sports/:id", write, h.DeleteSport)
+}
+
+func SetupV2TurfRoutes(api *gin.RouterGroup, turfService *services.TurfService, limiter *ratelimit.Limiter) {
+	h := handlers.NewTurfV2Handler(turfService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	api.GET("/turfs", read, h.ListTurfs)
+	api.GET("/turfs/:id", read, h.GetTurf)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.POST("/turfs", write, h.CreateTurf)
+	authed.PATCH("/turfs/:id", write, h.UpdateTurf)
+	authed.DELETE("/turfs/:id", write, h.DeleteTurf)
+}
+
+func SetupV2TeamRoutes(api *gin.RouterGroup, teamService *services.TeamService, limiter *ratelimit.Limiter) {
+	h := handlers.NewTeamHandler(teamService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.POST("/teams", write, h.CreateTeam)
+	authed.GET("/me/teams", read, h.ListMyTeams)
+	authed.GET("/teams/:id", read, h.GetTeam)
+	authed.DELETE("/teams/:id", write, h.DeleteTeam)
+	authed.POST("/teams/:id/invitations", write, h.InviteToTeam)
+	authed.POST("/teams/:id/leave", write, h.LeaveTeam)
+	authed.DELETE("/teams/:id/members/:userId", write, h.RemoveTeamMember)
+	authed.GET("/me/invitations", read, h.ListMyInvitations)
+	authed.POST("/invitations/:id/accept", write, h.AcceptInvitation)
+	authed.POST("/invitations/:id/decline", write, h.DeclineInvitation)
+}
+
+func SetupV2BookingRoutes(api *gin.RouterGroup, bookingService *services.BookingService, limiter *ratelimit.Limiter) {
+	h := handlers.NewBookingHandler(bookingService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.POST("/bookings", write, h.CreateBooking)
+	authed.GET("/me/bookings", read, h.ListMyBookings)
+	authed.GET("/bookings/:id", read, h.GetBooking)
+	authed.POST("/bookings/:id/cancel", write, h.CancelBooking)
+}
+
+func SetupV2MatchRoutes(api *gin.RouterGroup, matchService *services.MatchService, limiter *ratelimit.Limiter) {
+	h := handlers.NewMatchHandler(matchService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.POST("/matches", write, h.CreateMatch)
+	authed.GET("/matches/nearby", read, h.FindNearbyMatches)
+	authed.GET("/me/matches", read, h.ListMyMatches)
+	authed.GET("/matches/:id", read, h.GetMatch)
+	authed.POST("/matches/:id/join", write, h.JoinMatch)
+	authed.POST("/matches/:id/leave", write, h.LeaveMatch)
+	authed.POST("/matches/:id/cancel", write, h.CancelMatch)
+}
+
+func SetupV2PaymentRoutes(api *gin.RouterGroup, paymentService *services.PaymentService, limiter *ratelimit.Limiter) {
+	h := handlers.NewPaymentHandler(paymentService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.POST("/bookings/split", write, h.CreateSplitBooking)
+	authed.GET("/bookings/:id/payments", read, h.GetBookingPayments)
+	authed.POST("/bookings/:id/payments", write, h.PayBookingShare)
+}
+
+func SetupV2TournamentRoutes(api *gin.RouterGroup, tournamentService *services.TournamentService, limiter *ratelimit.Limiter) {
+	h := handlers.NewTournamentHandler(tournamentService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	api.GET("/tournaments", read, h.ListTournaments)
+	api.GET("/tournaments/:id", read, h.GetTournament)
+	api.GET("/tournaments/:id/fixtures", read, h.ListTournamentFixtures)
+	api.GET("/tournaments/:id/standings", read, h.GetTournamentStandings)
+	api.GET("/tournaments/:id/bracket", read, h.GetTournamentBracket)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.POST("/tournaments", write, h.CreateTournament)
+	authed.POST("/tournaments/:id/teams", write, h.RegisterTournamentTeam)
+	authed.DELETE("/tournaments/:id/teams/:teamId", write, h.WithdrawTournamentTeam)
+	authed.POST("/tournaments/:id/schedule", write, h.ScheduleTournament)
+	authed.POST("/tournaments/:id/cancel", write, h.CancelTournament)
+	authed.POST("/tournaments/:id/fixtures/:fixtureId/result", write, h.RecordFixtureResult)
+}
+
+func SetupV2StatsRoutes(api *gin.RouterGroup, statsService *services.StatsService, limiter *ratelimit.Limiter) {
+	h := handlers.NewStatsHandler(statsService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	api.GET("/users/:id/stats", read, h.GetPlayerStats)
+	api.GET("/sports/:id/leaderboard", read, h.GetLeaderboard)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.POST("/matches/:id/result", write, h.RecordMatchResult)
+	authed.GET("/matches/:id/result", read, h.GetMatchResult)
+	authed.POST("/admin/leaderboards/rebuild", write, h.RebuildLeaderboards)
+}
+
+func SetupV2MatchmakingRoutes(api *gin.RouterGroup, matchmakingService *services.MatchmakingService, limiter *ratelimit.Limiter) {
+	h := handlers.NewMatchmakingHandler(matchmakingService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+
+	api.GET("/users/:id/ratings", read, h.GetPlayerRatings)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.GET("/matches/recommended", read, h.RecommendMatches)
+	authed.GET("/matches/:id/teams", read, h.SuggestMatchTeams)
+}
+
+func SetupV2ReviewRoutes(api *gin.RouterGroup, reviewService *services.ReviewService, limiter *ratelimit.Limiter) {
+	h := handlers.NewReviewHandler(reviewService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	api.GET("/turfs/:id/reviews", read, h.ListTurfReviews)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.POST("/turfs/:id/reviews", write, h.CreateTurfReview)
+	authed.POST("/reviews/:id/reply", write, h.ReplyToReview)
+	authed.POST("/reviews/:id/report", write, h.ReportReview)
+	authed.GET("/admin/reviews/reported", read, h.ListReportedReviews)
+	authed.POST("/admin/reviews/:id/hide", write, h.HideReview)
+	authed.POST("/admin/reviews/:id/restore", write, h.RestoreReview)
+}
+
+func SetupV2FavouriteRoutes(api *gin.RouterGroup, favouriteService *services.FavouriteService, savedSearchService *services.SavedSearchService, limiter *ratelimit.Limiter) {
+	h := handlers.NewFavouriteHandler(favouriteService, savedSearchService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.GET("/me/favourites", read, h.ListFavouriteTurfs)
+	authed.PUT("/me/favourites/:id", write, h.AddFavouriteTurf)
+	authed.DELETE("/me/favourites/:id", write, h.RemoveFavouriteTurf)
+	authed.POST("/me/saved-searches", write, h.CreateSavedSearch)
+	authed.GET("/me/saved-searches", read, h.ListSavedSearches)
+	authed.GET("/me/saved-searches/:id", read, h.GetSavedSearch)
+	authed.DELETE("/me/saved-searches/:id", write, h.DeleteSavedSearch)
+}
+
+func SetupV2SeriesRoutes(api *gin.RouterGroup, seriesService *services.BookingSeriesService, pricingService *services.PricingService, limiter *ratelimit.Limiter) {
+	h := handlers.NewSeriesHandler(seriesService, pricingService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	api.GET("/turfs/:id/pricing-rules", read, h.ListPricingRules)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.POST("/turfs/:id/pricing-rules", write, h.CreatePricingRule)
+	authed.DELETE("/turfs/:id/pricing-rules/:ruleId", write, h.DeletePricingRule)
+	authed.POST("/booking-series", write, h.CreateBookingSeries)
+	authed.POST("/booking-series/quote", read, h.QuoteBookingSeries)
+	authed.GET("/me/booking-series", read, h.ListMyBookingSeries)
+	authed.GET("/booking-series/:id", read, h.GetBookingSeries)
+	authed.POST("/booking-series/:id/cancel", write, h.CancelBookingSeries)
+	authed.POST("/booking-series/:id/occurrences/:bookingId/cancel", write, h.CancelSeriesOccurrence)
+	authed.POST("/booking-series/:id/occurrences/:bookingId/reschedule", write, h.RescheduleSeriesOccurrence)
+}
+
+func SetupV2CalendarRoutes(api *gin.RouterGroup, calendarService *services.CalendarService, limiter *ratelimit.Limiter) {
+	h := handlers.NewCalendarHandler(calendarService)
+	read := limiter.Middleware(ratelimit.PolicyRead)
+	write := limiter.Middleware(ratelimit.PolicyWrite)
+
+	api.GET("/turfs/:id/closures", read, h.ListTurfClosures)
+
+	authed := api.Group("", middleware.RequireAuth())
+	authed.PUT("/turfs/:id/opening-hours", write, h.SetTurfOpeningHours)
+	authed.POST("/turfs/:id/closures", write, h.CreateTurfClosure)
+	authed.GET("/turfs/:id/closures/:closureId", read, h.GetTurfClosure)
+	authed.DELETE("/turfs/:id/closures/:closureId", write, h.DeleteTurfClosure)
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+)
+
+type BookingService struct {
+	bookings *repositories.BookingRepository
+	turfs    *repositories.TurfRepostitory
+	teams    *repositories.TeamRepository
+	alerts   *SavedSearchService
+}
+
+func NewBookingService(
+	bookings *repositories.BookingRepository,
+	turfs *repositories.TurfRepostitory,
+	teams *repositories.TeamRepository,
+	alerts *SavedSearchService,
+) *BookingService {
+	return &BookingService{
+		bookings: bookings,
+		turfs:    turfs,
+		teams:    teams,
+		alerts:   alerts,
+	}
+}
+
+// CreateBooking reserves a field for userID, or for a team userID captains.
+func (s *BookingService) CreateBooking(ctx context.Context, userID uuid.UUID, req types.CreateBookingRequest) (*models.Booking, error) {
+	booking, err := s.newBooking(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	booking.Status = models.BookingConfirmed
+	if err := s.bookings.Create(ctx, booking); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// newBooking validates req and builds the booking it describes, with its turf and team
+// loaded. Turf opening hours are hours of day in the turf's time zone.
+func (s *BookingService) newBooking(ctx context.Context, userID uuid.UUID, req types.CreateBookingRequest) (*models.Booking, error) {
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, apperrors.InvalidField("endsAt", "endsAt must be after startsAt")
+	}
+	if req.StartsAt.Before(time.Now()) {
+		return nil, apperrors.InvalidField("startsAt", "startsAt must be in the future")
+	}
+
+	turf, err := s.turfs.GetTurfByID(ctx, req.TurfID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBookable(turf, req.FieldNumber, req.StartsAt, req.EndsAt); err != nil {
+		return nil, err
+	}
+
+	booking := &models.Booking{
+		TurfID:      turf.ID,
+		Turf:        *turf,
+		FieldNumber: req.FieldNumber,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		BookedByID:  userID,
+	}
+	if req.TeamID != nil {
+		team, err := s.bookingTeam(ctx, *req.TeamID, userID)
+		if err != nil {
+			return nil, err
+		}
+		booking.TeamID = &team.ID
+		booking.Team = team
+	}
+	return booking, nil
+}
+
+// checkBookable validates a slot against the turf's status, fields and opening hours
+// on the weekday it starts in the turf's time zone. Closures are checked when the
+// booking is written.
+func checkBookable(turf *models.Turf, field int, start, end time.Time) error {
+	if !strings.EqualFold(turf.Status, "active") {
+		return apperrors.Conflict("turf is not accepting bookings")
+	}
+	if field < 1 || field > turf.NoOfFields {
+		return apperrors.InvalidField("fieldNumber", fmt.Sprintf("fieldNumber must be between 1 and %d", turf.NoOfFields))
+	}
+	start = start.In(turf.Location())
+	opensAt, closesAt, open := openingHours(turf, start.Weekday())
+	if !open {
+		return apperrors.InvalidField("startsAt", fmt.Sprintf("the turf is closed on %ss", start.Weekday()))
+	}
+	opens := time.Date(start.Year(), start.Month(), start.Day(), opensAt, 0, 0, 0, start.Location())
+	closes := time.Date(start.Year(), start.Month(), start.Day(), closesAt, 0, 0, 0, start.Location())
+	if start.Before(opens) || end.After(closes) {
+		return apperrors.InvalidField("startsAt", fmt.Sprintf("the turf is open from %02d:00 to %02d:00 on %ss", opensAt, closesAt, start.Weekday()))
+	}
+	return nil
+}
+
+// startTime formats when a booking starts for players, in its turf's time zone. The
+// booking's Turf must be loaded.
+func startTime(b *models.Booking) string {
+	return b.StartsAt.In(b.Turf.Location()).Format(time.RFC1123)
+}
+
+// bookingTeam loads a team that userID may book for: they must captain it, and it
+// must have at least the sport's MinPlayers members.
+func (s *BookingService) bookingTeam(ctx context.Context, teamID string, userID uuid.UUID) (*models.Team, error) {
+	team, err := s.teams.GetByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember(team, userID) {
+		return nil, apperrors.NotFound("team")
+	}
+	if err := requireCaptain(team, userID); err != nil {
+		return nil, err
+	}
+	if len(team.Members) < team.Sport.MinPlayers {
+		return nil, apperrors.InvalidField("teamId",
+			fmt.Sprintf("%s needs at least %d players to book; the team has %d", team.Sport.Name, team.Sport.MinPlayers, len(team.Members)))
+	}
+	return team, nil
+}
+
+// canView reports whether userID made the booking, plays for its team or owns the turf.
+func (s *BookingService) canView(ctx context.Context, booking *models.Booking, userID uuid.UUID) (bool, error) {
+	if booking.BookedByID == userID || booking.Turf.OwnerID == userID {
+		return true, nil
+	}
+	if booking.TeamID == nil {
+		return false, nil
+	}
+	return s.teams.IsMember(ctx, *booking.TeamID, userID)
+}
+
+func (s *BookingService) GetBooking(ctx context.Context, id string, userID uuid.UUID) (*models.Booking, error) {
+	booking, err := s.bookings.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	visible, err := s.canView(ctx, booking, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !visible {
+		return nil, apperrors.NotFound("booking")
+	}
+	return booking, nil
+}
+
+// ListBookings returns userID's own and team bookings.
+func (s *BookingService) ListBookings(ctx context.Context, userID uuid.UUID) ([]models.Booking, error) {
+	return s.bookings.ListByUser(ctx, userID)
+}
+
+// CancelBooking frees the field and alerts players whose saved searches it matches.
+// The booker, the booking team's captain and the turf owner may cancel.
+func (s *BookingService) CancelBooking(ctx context.Context, id string, userID uuid.UUID) (*models.Booking, error) {
+	booking, err := s.GetBooking(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	allowed := booking.BookedByID == userID || booking.Turf.OwnerID == userID ||
+		(booking.Team != nil && booking.Team.CaptainID == userID)
+	if !allowed {
+		return nil, apperrors.Forbidden("only the booker, the team captain or the turf owner can cancel")
+	}
+	if err := s.bookings.Cancel(ctx, booking); err != nil {
+		return nil, err
+	}
+	// A popular slot can match many searches; alert them without holding the response.
+	go s.alerts.SlotOpened(context.WithoutCancel(ctx), *booking, userID)
+	return booking, nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+func TestCheckBookableReadsTurfTimeZone(t *testing.T) {
+	turf := &models.Turf{Status: "active", NoOfFields: 1, StartTime: 8, EndTime: 22, TimeZone: "Asia/Karachi"}
+	tests := []struct {
+		name   string
+		start  time.Time
+		wantOK bool
+	}{
+		// 03:00 UTC is 08:00 in Karachi.
+		{"opening hour sent in UTC", time.Date(2026, time.March, 3, 3, 0, 0, 0, time.UTC), true},
+		{"before opening sent in UTC", time.Date(2026, time.March, 3, 2, 0, 0, 0, time.UTC), false},
+		// 21:00 in New York is 07:00 the next day in Karachi.
+		{"before opening sent in another zone", time.Date(2026, time.March, 2, 21, 0, 0, 0, mustLocation(t, "America/New_York")), false},
+		{"last hour sent in UTC", time.Date(2026, time.March, 3, 16, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		err := checkBookable(turf, 1, tt.start, tt.start.Add(time.Hour))
+		if (err == nil) != tt.wantOK {
+			t.Errorf("%s: err = %v, want ok %v", tt.name, err, tt.wantOK)
+		}
+	}
+}
+
+func TestCheckBookableUntilMidnight(t *testing.T) {
+	turf := &models.Turf{Status: "active", NoOfFields: 1, StartTime: 8, EndTime: 24, TimeZone: "UTC"}
+	start := time.Date(2026, time.March, 3, 23, 0, 0, 0, time.UTC)
+	if err := checkBookable(turf, 1, start, start.Add(time.Hour)); err != nil {
+		t.Errorf("the last hour before a midnight close: %v", err)
+	}
+	if err := checkBookable(turf, 1, start, start.Add(2*time.Hour)); err == nil {
+		t.Error("a booking past a midnight close was allowed")
+	}
+}
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load %s: %v", name, err)
+	}
+	return loc
+}
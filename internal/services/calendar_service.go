@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/notifications"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+)
+
+const (
+	// maxClosureLength is the longest a single closure may last.
+	maxClosureLength = 366 * 24 * time.Hour
+	// closureListWindow is how far ahead closures are listed when no range is given.
+	closureListWindow = 90 * 24 * time.Hour
+)
+
+// CalendarService manages when a turf takes bookings: its opening hours by weekday and
+// its closures of single fields or the whole turf.
+type CalendarService struct {
+	calendar *repositories.CalendarRepository
+	turfs    *repositories.TurfRepostitory
+	notifier *notifications.Notifier
+}
+
+func NewCalendarService(
+	calendar *repositories.CalendarRepository,
+	turfs *repositories.TurfRepostitory,
+	notifier *notifications.Notifier,
+) *CalendarService {
+	return &CalendarService{
+		calendar: calendar,
+		turfs:    turfs,
+		notifier: notifier,
+	}
+}
+
+// openingHours returns the hours the turf opens and closes on a weekday: its override
+// for the day if it has one, else its StartTime and EndTime. open is false on days
+// the turf is closed.
+func openingHours(turf *models.Turf, day time.Weekday) (opensAt, closesAt int, open bool) {
+	for _, h := range turf.Hours {
+		if h.Weekday == int(day) {
+			return h.OpensAt, h.ClosesAt, !h.Closed
+		}
+	}
+	return turf.StartTime, turf.EndTime, true
+}
+
+// shortestOpenDay returns the weekday the turf is open for the fewest hours, with its
+// hours. open is false when the turf is closed every day.
+func shortestOpenDay(turf *models.Turf) (day time.Weekday, opensAt, closesAt int, open bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		o, c, ok := openingHours(turf, d)
+		if ok && (!open || c-o < closesAt-opensAt) {
+			day, opensAt, closesAt, open = d, o, c, true
+		}
+	}
+	return day, opensAt, closesAt, open
+}
+
+// fieldClosed reports whether a closure of the field, or of the whole turf, covers any
+// of [start, end).
+func fieldClosed(closed []models.TurfClosure, field int, start, end time.Time) bool {
+	for _, c := range closed {
+		if (c.FieldNumber == nil || *c.FieldNumber == field) && c.StartsAt.Before(end) && c.EndsAt.After(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetOpeningHours replaces the turf's weekday opening hours; owner only. Existing
+// bookings outside the new hours are kept.
+func (s *CalendarService) SetOpeningHours(ctx context.Context, turfID string, userID uuid.UUID, req types.SetOpeningHoursRequest) (*models.Turf, error) {
+	turf, err := s.ownedTurf(ctx, turfID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	hours := make([]models.TurfHours, 0, len(req.Days))
+	seen := make(map[int]bool)
+	for _, day := range req.Days {
+		weekday := *day.Weekday
+		if seen[weekday] {
+			return nil, apperrors.InvalidField("days", fmt.Sprintf("%s is given more than once", time.Weekday(weekday)))
+		}
+		seen[weekday] = true
+		if !day.Closed && day.OpensAt >= day.ClosesAt {
+			return nil, apperrors.InvalidField("days", fmt.Sprintf("%s must close after it opens", time.Weekday(weekday)))
+		}
+		h := models.TurfHours{TurfID: turf.ID, Weekday: weekday, OpensAt: day.OpensAt, ClosesAt: day.ClosesAt, Closed: day.Closed}
+		if day.Closed {
+			h.OpensAt, h.ClosesAt = 0, 0
+		}
+		hours = append(hours, h)
+	}
+	if err := s.calendar.SetHours(ctx, turf.ID, hours); err != nil {
+		return nil, err
+	}
+	turf.Hours = hours
+	return turf, nil
+}
+
+// CreateClosure blocks a field, or the whole turf, for a time range; owner only.
+// Bookings already holding the time are flagged, not cancelled, and their players are
+// emailed so they can rearrange.
+func (s *CalendarService) CreateClosure(ctx context.Context, turfID string, userID uuid.UUID, req types.CreateClosureRequest) (*models.TurfClosure, error) {
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, apperrors.InvalidField("endsAt", "endsAt must be after startsAt")
+	}
+	if !req.EndsAt.After(time.Now()) {
+		return nil, apperrors.InvalidField("endsAt", "endsAt must be in the future")
+	}
+	if req.EndsAt.Sub(req.StartsAt) > maxClosureLength {
+		return nil, apperrors.InvalidField("endsAt", "a closure can last at most 366 days")
+	}
+	turf, err := s.ownedTurf(ctx, turfID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.FieldNumber != nil && *req.FieldNumber > turf.NoOfFields {
+		return nil, apperrors.InvalidField("fieldNumber", fmt.Sprintf("fieldNumber must be between 1 and %d", turf.NoOfFields))
+	}
+
+	closure := &models.TurfClosure{
+		TurfID:      turf.ID,
+		Turf:        *turf,
+		FieldNumber: req.FieldNumber,
+		Reason:      req.Reason,
+		Note:        req.Note,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		CreatedByID: userID,
+	}
+	flagged, err := s.calendar.CreateClosure(ctx, closure)
+	if err != nil {
+		return nil, err
+	}
+	closure.Bookings = flagged
+	// A holiday can flag a day's worth of bookings; email their players without
+	// holding the response.
+	go s.notifyAffected(context.WithoutCancel(ctx), *closure)
+	return closure, nil
+}
+
+// notifyAffected emails the players of each booking a new closure flagged, with times
+// in the turf's time zone. Failures are logged; the closure already happened.
+func (s *CalendarService) notifyAffected(ctx context.Context, closure models.TurfClosure) {
+	if len(closure.Bookings) == 0 {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	bookings := make(map[uuid.UUID]models.Booking, len(closure.Bookings))
+	ids := make([]uuid.UUID, len(closure.Bookings))
+	for i, b := range closure.Bookings {
+		bookings[b.ID] = b
+		ids[i] = b.ID
+	}
+	players, err := s.calendar.AffectedPlayers(ctx, ids)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to load players affected by closure",
+			slog.String("closure_id", closure.ID.String()), logging.Err(err))
+		return
+	}
+
+	turf := closure.Turf.Name
+	loc := closure.Turf.Location()
+	closed := "every field"
+	if closure.FieldNumber != nil {
+		closed = fmt.Sprintf("field %d", *closure.FieldNumber)
+	}
+	for _, p := range players {
+		b := bookings[p.BookingID]
+		body := fmt.Sprintf("%s has closed %s from %s to %s (%s), overlapping your booking of field %d on %s. The booking still stands for now; contact the turf, or cancel it if you need to play elsewhere.",
+			turf, closed, closure.StartsAt.In(loc).Format(time.RFC1123), closure.EndsAt.In(loc).Format(time.RFC1123),
+			closureReason(&closure), b.FieldNumber, b.StartsAt.In(loc).Format(time.RFC1123))
+		if err := s.notifier.SendEmail(ctx, p.Email, "Your booking at "+turf+" is affected by a closure", body); err != nil {
+			logger.ErrorContext(ctx, "failed to send closure notification",
+				slog.String("booking_id", b.ID.String()), slog.String("user_id", p.UserID.String()), logging.Err(err))
+		}
+	}
+}
+
+// closureReason describes a closure for players, with the owner's note if any.
+func closureReason(closure *models.TurfClosure) string {
+	reason := map[string]string{
+		models.ClosureMaintenance:  "maintenance",
+		models.ClosurePrivateEvent: "private event",
+		models.ClosureHoliday:      "holiday",
+	}[closure.Reason]
+	if closure.Note != "" {
+		reason += ": " + closure.Note
+	}
+	return reason
+}
+
+// ListClosures returns the turf's closures overlapping [from, to), soonest first. A
+// zero from is now and a zero to is 90 days after from; reason, when set, filters
+// them, e.g. to the turf's holiday calendar.
+func (s *CalendarService) ListClosures(ctx context.Context, turfID string, from, to time.Time, reason string) ([]models.TurfClosure, error) {
+	if from.IsZero() {
+		from = time.Now()
+	}
+	if to.IsZero() {
+		to = from.Add(closureListWindow)
+	}
+	if !to.After(from) {
+		return nil, apperrors.InvalidField("to", "to must be after from")
+	}
+	turf, err := s.turfs.GetTurfByID(ctx, turfID)
+	if err != nil {
+		return nil, err
+	}
+	return s.calendar.ListClosures(ctx, turf.ID, from, to, reason)
+}
+
+// GetClosure returns one of the turf's closures with the bookings it flagged; owner
+// only.
+func (s *CalendarService) GetClosure(ctx context.Context, turfID, closureID string, userID uuid.UUID) (*models.TurfClosure, error) {
+	turf, err := s.ownedTurf(ctx, turfID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.calendar.GetClosure(ctx, turf.ID, closureID)
+}
+
+// DeleteClosure reopens the time a closure blocked and unflags its bookings; owner
+// only.
+func (s *CalendarService) DeleteClosure(ctx context.Context, turfID, closureID string, userID uuid.UUID) error {
+	closure, err := s.GetClosure(ctx, turfID, closureID, userID)
+	if err != nil {
+		return err
+	}
+	return s.calendar.DeleteClosure(ctx, closure)
+}
+
+func (s *CalendarService) ownedTurf(ctx context.Context, turfID string, userID uuid.UUID) (*models.Turf, error) {
+	turf, err := s.turfs.GetTurfByID(ctx, turfID)
+	if err != nil {
+		return nil, err
+	}
+	if turf.OwnerID != userID {
+		return nil, apperrors.Forbidden("only the turf owner can change its calendar")
+	}
+	return turf, nil
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/repositories"
+)
+
+const (
+	// MaxFavouriteTurfs caps how many turfs one player can favourite.
+	MaxFavouriteTurfs = 50
+	// availabilityHorizon is how far ahead a favourite's availability is summarised.
+	availabilityHorizon = 24 * time.Hour
+	// slotLength is the unit availability is counted in.
+	slotLength = time.Hour
+)
+
+type FavouriteService struct {
+	favourites *repositories.FavouriteRepository
+	turfs      *repositories.TurfRepostitory
+	bookings   *repositories.BookingRepository
+	calendar   *repositories.CalendarRepository
+}
+
+func NewFavouriteService(
+	favourites *repositories.FavouriteRepository,
+	turfs *repositories.TurfRepostitory,
+	bookings *repositories.BookingRepository,
+	calendar *repositories.CalendarRepository,
+) *FavouriteService {
+	return &FavouriteService{
+		favourites: favourites,
+		turfs:      turfs,
+		bookings:   bookings,
+		calendar:   calendar,
+	}
+}
+
+// FavouriteTurf is a favourited turf with its upcoming availability.
+type FavouriteTurf struct {
+	Turf         models.Turf
+	AddedAt      time.Time
+	Availability Availability
+}
+
+// Availability summarises a turf's free one-hour field slots between From and To.
+type Availability struct {
+	From      time.Time
+	To        time.Time
+	FreeSlots int
+	NextFree  *Slot
+}
+
+// Slot is one field of a turf for a time range.
+type Slot struct {
+	FieldNumber int
+	StartsAt    time.Time
+	EndsAt      time.Time
+}
+
+// AddFavourite favourites a turf for userID. Favouriting a turf twice is a no-op, even
+// at the MaxFavouriteTurfs cap.
+func (s *FavouriteService) AddFavourite(ctx context.Context, userID uuid.UUID, turfID string) error {
+	turf, err := s.turfs.GetTurfByID(ctx, turfID)
+	if err != nil {
+		return err
+	}
+	exists, err := s.favourites.Exists(ctx, userID, turf.ID)
+	if err != nil || exists {
+		return err
+	}
+	count, err := s.favourites.CountByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if count >= MaxFavouriteTurfs {
+		return apperrors.Conflict(fmt.Sprintf("you can favourite at most %d turfs", MaxFavouriteTurfs))
+	}
+	return s.favourites.Add(ctx, &models.FavouriteTurf{UserID: userID, TurfID: turf.ID})
+}
+
+func (s *FavouriteService) RemoveFavourite(ctx context.Context, userID uuid.UUID, turfID string) error {
+	id, err := uuid.Parse(turfID)
+	if err != nil {
+		return apperrors.NotFound("favourite")
+	}
+	return s.favourites.Remove(ctx, userID, id)
+}
+
+// ListFavourites returns userID's favourite turfs, most recently added first, each
+// with its free slots over the day from from. Opening hours are read in each turf's
+// time zone.
+func (s *FavouriteService) ListFavourites(ctx context.Context, userID uuid.UUID, from time.Time) ([]FavouriteTurf, error) {
+	favourites, err := s.favourites.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	to := from.Add(availabilityHorizon)
+	turfIDs := make([]uuid.UUID, len(favourites))
+	for i, f := range favourites {
+		turfIDs[i] = f.TurfID
+	}
+	holding, err := s.bookings.ListHoldingForTurfs(ctx, turfIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+	busy := make(map[uuid.UUID][]models.Booking)
+	for _, b := range holding {
+		busy[b.TurfID] = append(busy[b.TurfID], b)
+	}
+	closures, err := s.calendar.ListClosuresForTurfs(ctx, turfIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+	closed := make(map[uuid.UUID][]models.TurfClosure)
+	for _, c := range closures {
+		closed[c.TurfID] = append(closed[c.TurfID], c)
+	}
+
+	result := make([]FavouriteTurf, len(favourites))
+	for i, f := range favourites {
+		result[i] = FavouriteTurf{
+			Turf:         f.Turf,
+			AddedAt:      f.CreatedAt,
+			Availability: summarise(&f.Turf, busy[f.TurfID], closed[f.TurfID], from, to),
+		}
+	}
+	return result, nil
+}
+
+// summarise counts the turf's free one-hour field slots within its opening hours
+// between from and to, starting on the hour in its time zone and outside its closures,
+// and finds the first. Inactive turfs have none.
+func summarise(turf *models.Turf, busy []models.Booking, closed []models.TurfClosure, from, to time.Time) Availability {
+	availability := Availability{From: from, To: to}
+	if !strings.EqualFold(turf.Status, "active") {
+		return availability
+	}
+
+	local := from.In(turf.Location())
+	start := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, local.Location())
+	if start.Before(from) {
+		start = start.Add(slotLength)
+	}
+	for ; !start.Add(slotLength).After(to); start = start.Add(slotLength) {
+		end := start.Add(slotLength)
+		opensAt, closesAt, open := openingHours(turf, start.Weekday())
+		if !open || start.Hour() < opensAt || start.Hour()+1 > closesAt {
+			continue
+		}
+		for field := 1; field <= turf.NoOfFields; field++ {
+			if fieldTaken(busy, field, start, end) || fieldClosed(closed, field, start, end) {
+				continue
+			}
+			availability.FreeSlots++
+			if availability.NextFree == nil {
+				availability.NextFree = &Slot{FieldNumber: field, StartsAt: start, EndsAt: end}
+			}
+		}
+	}
+	return availability
+}
+
+// fieldTaken reports whether a booking holds the field for any of [start, end).
+func fieldTaken(busy []models.Booking, field int, start, end time.Time) bool {
+	for _, b := range busy {
+		if b.FieldNumber == field && b.StartsAt.Before(end) && b.EndsAt.After(start) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,10 +1,11 @@
 package services
 
 import (
-	"errors"
+	"context"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
 	"github.com/musishere/sportsApp/internal/models"
 	"github.com/musishere/sportsApp/internal/repositories"
 )
@@ -19,12 +20,12 @@ func NewLocationService(repo *repositories.LocationRepository) *LocationService
 	}
 }
 
-func (s *LocationService) CreateLocationForUser(userID uuid.UUID, latitude, longitude float64) (*models.Location, error) {
+func (s *LocationService) CreateLocationForUser(ctx context.Context, userID uuid.UUID, latitude, longitude float64) (*models.Location, error) {
 	if latitude < -90 || latitude > 90 {
-		return nil, errors.New("invalid latitude")
+		return nil, apperrors.InvalidField("latitude", "invalid latitude")
 	}
 	if longitude < -180 || longitude > 180 {
-		return nil, errors.New("invalid longitude")
+		return nil, apperrors.InvalidField("longitude", "invalid longitude")
 	}
 
 	location := &models.Location{
@@ -36,7 +37,7 @@ func (s *LocationService) CreateLocationForUser(userID uuid.UUID, latitude, long
 		UpdatedAt: time.Now(),
 	}
 
-	if err := s.repo.CreateLocation(location); err != nil {
+	if err := s.repo.CreateLocation(ctx, location); err != nil {
 		return nil, err
 	}
 
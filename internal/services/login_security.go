@@ -0,0 +1,105 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+const (
+	// freeLoginAttempts failures are allowed before delays kick in.
+	freeLoginAttempts = 3
+	// Each further failure doubles the wait before the next attempt, up to maxLoginDelay.
+	baseLoginDelay = time.Second
+	maxLoginDelay  = 5 * time.Minute
+	// lockoutThreshold consecutive failures lock the account for lockoutDuration.
+	lockoutThreshold = 10
+	lockoutDuration  = 15 * time.Minute
+	// suspiciousLoginDistanceKm away from the stored location triggers a notification.
+	suspiciousLoginDistanceKm = 300.0
+)
+
+var errInvalidCredentials = apperrors.Unauthorized("invalid email or password")
+
+// loginRetryAfter returns how long the account must wait before another attempt.
+func loginRetryAfter(user *models.User, now time.Time) time.Duration {
+	if user.LockedUntil != nil && now.Before(*user.LockedUntil) {
+		return user.LockedUntil.Sub(now)
+	}
+	if user.LastFailedLoginAt == nil || user.FailedLoginAttempts < freeLoginAttempts {
+		return 0
+	}
+	if wait := user.LastFailedLoginAt.Add(progressiveDelay(user.FailedLoginAttempts)).Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// progressiveDelay doubles the wait for every failure beyond the free attempts.
+func progressiveDelay(failures int) time.Duration {
+	if failures < freeLoginAttempts {
+		return 0
+	}
+	delay := baseLoginDelay << (failures - freeLoginAttempts)
+	if delay <= 0 || delay > maxLoginDelay {
+		return maxLoginDelay
+	}
+	return delay
+}
+
+// registerFailedLogin bumps the failure counter and locks the account at the threshold.
+func registerFailedLogin(user *models.User, now time.Time) {
+	// A lockout that has expired starts a fresh count.
+	if user.LockedUntil != nil && !now.Before(*user.LockedUntil) {
+		user.FailedLoginAttempts = 0
+		user.LockedUntil = nil
+	}
+	user.FailedLoginAttempts++
+	user.LastFailedLoginAt = &now
+	if user.FailedLoginAttempts >= lockoutThreshold {
+		lockedUntil := now.Add(lockoutDuration)
+		user.LockedUntil = &lockedUntil
+	}
+}
+
+// attemptLogin decides one login attempt on the account's failed-login counters: it is
+// refused while they ask the caller to wait, and otherwise verify checks the password
+// and the counters are bumped on failure or cleared on success.
+func attemptLogin(user *models.User, now time.Time, verify func() error) error {
+	if wait := loginRetryAfter(user, now); wait > 0 {
+		return tooManyLoginAttempts(wait)
+	}
+	if err := verify(); err != nil {
+		registerFailedLogin(user, now)
+		return errInvalidCredentials
+	}
+	resetFailedLogins(user)
+	return nil
+}
+
+func resetFailedLogins(user *models.User) {
+	user.FailedLoginAttempts = 0
+	user.LastFailedLoginAt = nil
+	user.LockedUntil = nil
+}
+
+func tooManyLoginAttempts(wait time.Duration) error {
+	return apperrors.RateLimited("too many failed login attempts, try again later").WithRetryAfter(wait)
+}
+
+// coarseCoordinate rounds to two decimals (~1km) before storing login positions.
+func coarseCoordinate(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func newLocationEmailBody(name string, entry *models.LoginHistory) string {
+	return fmt.Sprintf(
+		"Hi %s,\n\nWe noticed a sign-in to your account from a new location about %.0f km from where you usually sign in.\n\n"+
+			"Time: %s\nIP address: %s\nDevice: %s\nApproximate location: %.2f, %.2f\n\n"+
+			"If this was you, no action is needed. If not, please change your password immediately.",
+		name, entry.DistanceKm, entry.CreatedAt.UTC().Format(time.RFC1123), entry.IP, entry.UserAgent, entry.Latitude, entry.Longitude,
+	)
+}
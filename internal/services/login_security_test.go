@@ -0,0 +1,98 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+func TestAttemptLoginCountsAndLocks(t *testing.T) {
+	wrong := func() error { return errors.New("wrong password") }
+	user := &models.User{}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// The free attempts fail without any wait.
+	for i := 1; i <= freeLoginAttempts; i++ {
+		if err := attemptLogin(user, now, wrong); !errors.Is(err, apperrors.ErrUnauthorized) {
+			t.Fatalf("attempt %d: err = %v, want unauthorized", i, err)
+		}
+		if user.FailedLoginAttempts != i {
+			t.Fatalf("attempt %d: count = %d", i, user.FailedLoginAttempts)
+		}
+	}
+
+	// Each further failure doubles the wait, and an attempt inside it is refused
+	// without checking the password or counting.
+	for failures := freeLoginAttempts; failures < lockoutThreshold-1; failures++ {
+		delay := progressiveDelay(failures)
+		if want := baseLoginDelay << (failures - freeLoginAttempts); delay != want {
+			t.Fatalf("delay after %d failures = %v, want %v", failures, delay, want)
+		}
+		verified := false
+		err := attemptLogin(user, now.Add(delay-time.Millisecond), func() error { verified = true; return nil })
+		if !errors.Is(err, apperrors.ErrRateLimited) || verified || user.FailedLoginAttempts != failures {
+			t.Fatalf("inside the %v wait after %d failures: err = %v, verified = %v, count = %d", delay, failures, err, verified, user.FailedLoginAttempts)
+		}
+		now = now.Add(delay)
+		if err := attemptLogin(user, now, wrong); !errors.Is(err, apperrors.ErrUnauthorized) {
+			t.Fatalf("after the %v wait: err = %v, want unauthorized", delay, err)
+		}
+		if user.LockedUntil != nil {
+			t.Fatalf("locked after %d failures", user.FailedLoginAttempts)
+		}
+	}
+
+	// The threshold locks the account, even against the right password.
+	now = now.Add(progressiveDelay(user.FailedLoginAttempts))
+	if err := attemptLogin(user, now, wrong); !errors.Is(err, apperrors.ErrUnauthorized) {
+		t.Fatalf("threshold attempt: err = %v", err)
+	}
+	if user.FailedLoginAttempts != lockoutThreshold || user.LockedUntil == nil || !user.LockedUntil.Equal(now.Add(lockoutDuration)) {
+		t.Fatalf("at threshold: count = %d, lockedUntil = %v", user.FailedLoginAttempts, user.LockedUntil)
+	}
+	err := attemptLogin(user, now.Add(lockoutDuration-time.Second), func() error { return nil })
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) || appErr.Code != apperrors.CodeRateLimited || appErr.RetryAfter != time.Second {
+		t.Fatalf("while locked: err = %v, want rate limited with 1s retry", err)
+	}
+
+	// An expired lockout starts a fresh count.
+	now = now.Add(lockoutDuration)
+	if err := attemptLogin(user, now, wrong); !errors.Is(err, apperrors.ErrUnauthorized) {
+		t.Fatalf("after lockout: err = %v", err)
+	}
+	if user.FailedLoginAttempts != 1 || user.LockedUntil != nil {
+		t.Fatalf("after lockout: count = %d, lockedUntil = %v", user.FailedLoginAttempts, user.LockedUntil)
+	}
+
+	// Success clears the counters.
+	if err := attemptLogin(user, now, func() error { return nil }); err != nil {
+		t.Fatalf("right password: err = %v", err)
+	}
+	if user.FailedLoginAttempts != 0 || user.LastFailedLoginAt != nil || user.LockedUntil != nil {
+		t.Fatalf("after success: %+v", user)
+	}
+}
+
+func TestTruncateKeepsRunes(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"curl/8.0", 512, "curl/8.0"},
+		{"abcdef", 3, "abc"},
+		{"aé", 2, "a"},
+		{"aé", 3, "aé"},
+		{"日本語", 5, "日"},
+		{"日本語", 2, ""},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.in, tt.max); got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/helpers"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/notifications"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+)
+
+const (
+	// maxNearbyMatches caps one nearby search.
+	maxNearbyMatches = 50
+	// cutoffBatchSize bounds how many matches one sweep settles.
+	cutoffBatchSize = 100
+	kmPerDegree     = 111.0
+)
+
+type MatchService struct {
+	matches       *repositories.MatchRepository
+	bookings      *repositories.BookingRepository
+	sports        *repositories.SportsRepository
+	locations     *repositories.LocationRepository
+	notifier      *notifications.Notifier
+	defaultCutoff time.Duration
+}
+
+func NewMatchService(
+	matches *repositories.MatchRepository,
+	bookings *repositories.BookingRepository,
+	sports *repositories.SportsRepository,
+	locations *repositories.LocationRepository,
+	notifier *notifications.Notifier,
+	defaultCutoff time.Duration,
+) *MatchService {
+	return &MatchService{
+		matches:       matches,
+		bookings:      bookings,
+		sports:        sports,
+		locations:     locations,
+		notifier:      notifier,
+		defaultCutoff: defaultCutoff,
+	}
+}
+
+// NearbyMatch is an open match with its distance from the searcher.
+type NearbyMatch struct {
+	Match      models.Match
+	DistanceKm float64
+}
+
+// CreateMatch opens a booking the caller made, or one for a team they captain, to
+// other players.
+func (s *MatchService) CreateMatch(ctx context.Context, userID uuid.UUID, req types.CreateMatchRequest) (*models.Match, error) {
+	booking, err := s.bookings.GetByID(ctx, req.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	ownsBooking := booking.BookedByID == userID || (booking.Team != nil && booking.Team.CaptainID == userID)
+	if !ownsBooking {
+		return nil, apperrors.Forbidden("only the booking party can open a match on it")
+	}
+	if booking.Status != models.BookingConfirmed || !booking.StartsAt.After(time.Now()) {
+		return nil, apperrors.InvalidField("bookingId", "the booking must be confirmed and in the future")
+	}
+
+	sport, err := s.sports.GetSportsByID(ctx, req.SportID)
+	if err != nil {
+		return nil, err
+	}
+	partySize := max(req.PartySize, 1)
+	if partySize+req.OpenSpots > sport.MaxPlayers {
+		return nil, apperrors.InvalidField("openSpots",
+			fmt.Sprintf("partySize plus openSpots cannot exceed %d players for %s", sport.MaxPlayers, sport.Name))
+	}
+
+	cutoff := booking.StartsAt.Add(-s.defaultCutoff)
+	if req.CutoffAt != nil {
+		cutoff = *req.CutoffAt
+		if !cutoff.After(time.Now()) || cutoff.After(booking.StartsAt) {
+			return nil, apperrors.InvalidField("cutoffAt", "cutoffAt must be in the future and no later than kick-off")
+		}
+	} else if !cutoff.After(time.Now()) {
+		// Too close to kick-off for the default; players can join until it starts.
+		cutoff = booking.StartsAt
+	}
+
+	match := &models.Match{
+		BookingID:   booking.ID,
+		SportID:     sport.ID,
+		OrganizerID: userID,
+		SkillLevel:  req.SkillLevel,
+		PartySize:   partySize,
+		OpenSpots:   req.OpenSpots,
+		Notes:       req.Notes,
+		CutoffAt:    cutoff,
+		Status:      models.MatchOpen,
+	}
+	if err := s.matches.Create(ctx, match); err != nil {
+		return nil, fmt.Errorf("failed to create match: %w", err)
+	}
+	return s.matches.GetByID(ctx, match.ID.String())
+}
+
+func (s *MatchService) GetMatch(ctx context.Context, id string) (*models.Match, error) {
+	return s.matches.GetByID(ctx, id)
+}
+
+// ListMatches returns the matches userID organises or plays in.
+func (s *MatchService) ListMatches(ctx context.Context, userID uuid.UUID) ([]models.Match, error) {
+	return s.matches.ListByPlayer(ctx, userID)
+}
+
+// FindNearby returns open matches within q.RadiusKm of userID's saved location,
+// nearest first.
+func (s *MatchService) FindNearby(ctx context.Context, userID uuid.UUID, q types.NearbyMatchesQuery) ([]NearbyMatch, error) {
+	location, err := s.locations.GetLocationByUserID(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	search := repositories.MatchSearch{SkillLevel: q.SkillLevel, Limit: maxNearbyMatches * 4}
+	if q.SportID != "" {
+		sportID, err := uuid.Parse(q.SportID)
+		if err != nil {
+			return nil, apperrors.InvalidField("sportId", "sportId must be a valid uuid")
+		}
+		search.SportID = &sportID
+	}
+	latDelta := q.RadiusKm / kmPerDegree
+	lngDelta := q.RadiusKm / (kmPerDegree * math.Max(math.Cos(location.Latitude*math.Pi/180), 0.01))
+	search.MinLat, search.MaxLat = location.Latitude-latDelta, location.Latitude+latDelta
+	search.MinLng, search.MaxLng = location.Longitude-lngDelta, location.Longitude+lngDelta
+
+	matches, err := s.matches.FindOpen(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	nearby := make([]NearbyMatch, 0, len(matches))
+	for _, m := range matches {
+		turf := m.Booking.Turf
+		distance := helpers.HaversineKm(location.Latitude, location.Longitude, turf.Latitude, turf.Longitude)
+		if distance <= q.RadiusKm {
+			nearby = append(nearby, NearbyMatch{Match: m, DistanceKm: distance})
+		}
+	}
+	sort.SliceStable(nearby, func(i, j int) bool { return nearby[i].DistanceKm < nearby[j].DistanceKm })
+	if len(nearby) > maxNearbyMatches {
+		nearby = nearby[:maxNearbyMatches]
+	}
+	return nearby, nil
+}
+
+// Join adds userID to the match, or to its waitlist once every open spot is taken.
+func (s *MatchService) Join(ctx context.Context, id string, userID uuid.UUID) (*models.Match, error) {
+	match, err := s.matches.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if match.OrganizerID == userID {
+		return nil, apperrors.Conflict("the organiser is already part of the match")
+	}
+	if _, err := s.matches.Join(ctx, match.ID, userID); err != nil {
+		return nil, err
+	}
+	return s.matches.GetByID(ctx, id)
+}
+
+// Leave removes userID from the match and promotes the first waitlisted player into a
+// freed spot, telling them by email. Organisers cancel instead.
+func (s *MatchService) Leave(ctx context.Context, id string, userID uuid.UUID) error {
+	match, err := s.matches.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if match.OrganizerID == userID {
+		return apperrors.Conflict("the organiser cannot leave; cancel the match instead")
+	}
+	promoted, err := s.matches.Leave(ctx, match.ID, userID)
+	if err != nil {
+		return err
+	}
+	if promoted != nil {
+		s.notify(ctx, match, []models.User{promoted.User}, "You're in: a spot opened up",
+			fmt.Sprintf("A spot opened up in the %s match at %s on %s and you have been moved off the waitlist.",
+				match.Sport.Name, match.Booking.Turf.Name, startTime(&match.Booking)))
+	}
+	return nil
+}
+
+// Cancel calls the match off and tells its players. Only the organiser may do this.
+func (s *MatchService) Cancel(ctx context.Context, id string, userID uuid.UUID) (*models.Match, error) {
+	match, err := s.matches.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if match.OrganizerID != userID {
+		return nil, apperrors.Forbidden("only the organiser can cancel the match")
+	}
+	cancelled, err := s.matches.SetStatus(ctx, match.ID, models.MatchCancelled)
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, apperrors.Conflict("match is no longer open")
+	}
+	match.Status = models.MatchCancelled
+
+	s.notify(ctx, match, players(match), "Match cancelled",
+		fmt.Sprintf("The organiser cancelled the %s match at %s on %s.",
+			match.Sport.Name, match.Booking.Turf.Name, startTime(&match.Booking)))
+	return match, nil
+}
+
+// RunCutoffSweeper settles matches whose cutoff has passed every interval until ctx is
+// cancelled. It is safe to run on every instance: each match is settled once.
+func (s *MatchService) RunCutoffSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.SweepCutoffs(ctx, now); err != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "match cutoff sweep failed", logging.Err(err))
+			}
+		}
+	}
+}
+
+// SweepCutoffs confirms matches that reached their sport's MinPlayers by the cutoff and
+// cancels the rest, telling everyone involved.
+func (s *MatchService) SweepCutoffs(ctx context.Context, now time.Time) error {
+	due, err := s.matches.DueForCutoff(ctx, now, cutoffBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range due {
+		match := &due[i]
+		enough := match.PartySize+joinedCount(match) >= match.Sport.MinPlayers
+		status := models.MatchCancelled
+		if enough {
+			status = models.MatchConfirmed
+		}
+		settled, err := s.matches.SetStatus(ctx, match.ID, status)
+		if err != nil {
+			return err
+		}
+		if !settled || enough {
+			continue
+		}
+
+		logging.FromContext(ctx).InfoContext(ctx, "match cancelled at cutoff",
+			slog.String("match_id", match.ID.String()))
+		recipients := append(players(match), match.Organizer)
+		s.notify(ctx, match, recipients, "Match cancelled: not enough players",
+			fmt.Sprintf("The %s match at %s on %s was cancelled because it did not reach %d players in time.",
+				match.Sport.Name, match.Booking.Turf.Name, startTime(&match.Booking), match.Sport.MinPlayers))
+	}
+	return nil
+}
+
+func joinedCount(match *models.Match) int {
+	joined := 0
+	for _, p := range match.Players {
+		if p.Status == models.MatchPlayerJoined {
+			joined++
+		}
+	}
+	return joined
+}
+
+// players returns the users who joined or are waitlisted for the match.
+func players(match *models.Match) []models.User {
+	users := make([]models.User, 0, len(match.Players))
+	for _, p := range match.Players {
+		users = append(users, p.User)
+	}
+	return users
+}
+
+// notify emails each recipient. Failures are logged; the match change already happened.
+func (s *MatchService) notify(ctx context.Context, match *models.Match, recipients []models.User, subject, body string) {
+	for _, user := range recipients {
+		if err := s.notifier.SendEmail(ctx, user.Email, subject, body); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to send match notification",
+				slog.String("match_id", match.ID.String()), slog.String("user_id", user.ID.String()), logging.Err(err))
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"context"
+	"math"
+	"math/bits"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/rating"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+)
+
+// maxExactSplit is the largest lineup split by trying every division; larger ones are
+// split greedily.
+const maxExactSplit = 16
+
+type MatchmakingService struct {
+	ratings      *repositories.RatingRepository
+	users        *repositories.UserRepository
+	matches      *repositories.MatchRepository
+	matchService *MatchService
+}
+
+func NewMatchmakingService(
+	ratings *repositories.RatingRepository,
+	users *repositories.UserRepository,
+	matches *repositories.MatchRepository,
+	matchService *MatchService,
+) *MatchmakingService {
+	return &MatchmakingService{
+		ratings:      ratings,
+		users:        users,
+		matches:      matches,
+		matchService: matchService,
+	}
+}
+
+// RatedPlayer is a player with their current rating in the match's sport.
+type RatedPlayer struct {
+	User   models.User
+	Rating rating.Rating
+	Games  int
+}
+
+// TeamSplit divides a match's lineup into two sides of near-equal strength.
+type TeamSplit struct {
+	Home               []RatedPlayer
+	Away               []RatedPlayer
+	HomeRating         rating.Rating
+	AwayRating         rating.Rating
+	HomeWinProbability float64
+}
+
+// RecommendedMatch is a nearby open match with how far its players' average rating is
+// from the searcher's.
+type RecommendedMatch struct {
+	NearbyMatch
+	AverageRating float64
+	RatingGap     float64
+}
+
+// ratingOf returns a stored rating as of at, or a newcomer's rating for a player
+// without one.
+func ratingOf(row *models.PlayerRating, at time.Time) rating.Rating {
+	if row == nil {
+		return rating.New()
+	}
+	r := rating.Rating{Value: row.Rating, Deviation: row.Deviation}
+	return rating.Decay(r, at.Sub(row.LastPlayedAt))
+}
+
+func scoreFor(outcome string) float64 {
+	switch outcome {
+	case models.OutcomeWon:
+		return rating.Win
+	case models.OutcomeDrawn:
+		return rating.Draw
+	default:
+		return rating.Loss
+	}
+}
+
+// rateResult rates each player in a result against a composite of the other side, as
+// of when the match was played.
+func rateResult(players []models.PlayerResult, sportID uuid.UUID, playedAt time.Time) repositories.RateFunc {
+	return func(current map[uuid.UUID]models.PlayerRating) []models.PlayerRating {
+		before := make(map[uuid.UUID]rating.Rating, len(players))
+		sides := map[string][]rating.Rating{}
+		for _, p := range players {
+			var row *models.PlayerRating
+			if r, ok := current[p.UserID]; ok {
+				row = &r
+			}
+			before[p.UserID] = ratingOf(row, playedAt)
+			sides[p.Side] = append(sides[p.Side], before[p.UserID])
+		}
+
+		updated := make([]models.PlayerRating, 0, len(players))
+		for _, p := range players {
+			opponents := sides[models.SideAway]
+			if p.Side == models.SideAway {
+				opponents = sides[models.SideHome]
+			}
+			r := rating.Update(before[p.UserID], rating.Composite(opponents), scoreFor(p.Outcome))
+
+			row := current[p.UserID]
+			row.UserID, row.SportID = p.UserID, sportID
+			row.Rating, row.Deviation = r.Value, r.Deviation
+			row.Games++
+			if playedAt.After(row.LastPlayedAt) {
+				row.LastPlayedAt = playedAt
+			}
+			updated = append(updated, row)
+		}
+		return updated
+	}
+}
+
+// Ratings returns a player's rating in every sport they have played.
+func (s *MatchmakingService) Ratings(ctx context.Context, userID string) ([]models.PlayerRating, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := s.ratings.ListByUser(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range ratings {
+		ratings[i].Deviation = ratingOf(&ratings[i], now).Deviation
+	}
+	return ratings, nil
+}
+
+// SuggestTeams splits the organiser and joined players into two sides whose average
+// ratings are as close as possible. Sides differ in size by at most one player.
+func (s *MatchmakingService) SuggestTeams(ctx context.Context, matchID string) (*TeamSplit, error) {
+	match, err := s.matches.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	rated, err := s.rate(ctx, match.SportID, lineup(match))
+	if err != nil {
+		return nil, err
+	}
+
+	home, away := balance(rated)
+	split := &TeamSplit{Home: home, Away: away, HomeRating: composite(home), AwayRating: composite(away)}
+	split.HomeWinProbability = rating.Expected(split.HomeRating, split.AwayRating)
+	return split, nil
+}
+
+// Recommend returns the nearby open matches whose players' average rating is within
+// q.Band of userID's rating in the sport, closest in rating first. The band widens by
+// the user's rating deviation, so players with few games see more matches.
+func (s *MatchmakingService) Recommend(ctx context.Context, userID uuid.UUID, q types.RecommendedMatchesQuery) ([]RecommendedMatch, error) {
+	nearby, err := s.matchService.FindNearby(ctx, userID, types.NearbyMatchesQuery{RadiusKm: q.RadiusKm, SportID: q.SportID})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	own := map[uuid.UUID]rating.Rating{}
+	recommended := []RecommendedMatch{}
+	for _, candidate := range nearby {
+		match := &candidate.Match
+		users := lineup(match)
+		if containsUser(users, userID) || containsUser(players(match), userID) {
+			continue
+		}
+		mine, ok := own[match.SportID]
+		if !ok {
+			rows, err := s.ratings.ListForPlayers(ctx, match.SportID, []uuid.UUID{userID})
+			if err != nil {
+				return nil, err
+			}
+			var row *models.PlayerRating
+			if len(rows) > 0 {
+				row = &rows[0]
+			}
+			mine = ratingOf(row, now)
+			own[match.SportID] = mine
+		}
+
+		rated, err := s.rate(ctx, match.SportID, users)
+		if err != nil {
+			return nil, err
+		}
+		average := composite(rated).Value
+		gap := math.Abs(average - mine.Value)
+		if gap > q.Band+mine.Deviation {
+			continue
+		}
+		recommended = append(recommended, RecommendedMatch{NearbyMatch: candidate, AverageRating: average, RatingGap: gap})
+	}
+	sort.SliceStable(recommended, func(i, j int) bool { return recommended[i].RatingGap < recommended[j].RatingGap })
+	return recommended, nil
+}
+
+// rate looks up each user's current rating in the sport.
+func (s *MatchmakingService) rate(ctx context.Context, sportID uuid.UUID, users []models.User) ([]RatedPlayer, error) {
+	ids := make([]uuid.UUID, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	rows, err := s.ratings.ListForPlayers(ctx, sportID, ids)
+	if err != nil {
+		return nil, err
+	}
+	byUser := make(map[uuid.UUID]*models.PlayerRating, len(rows))
+	for i := range rows {
+		byUser[rows[i].UserID] = &rows[i]
+	}
+
+	now := time.Now()
+	rated := make([]RatedPlayer, len(users))
+	for i, u := range users {
+		rated[i] = RatedPlayer{User: u, Rating: ratingOf(byUser[u.ID], now)}
+		if row := byUser[u.ID]; row != nil {
+			rated[i].Games = row.Games
+		}
+	}
+	return rated, nil
+}
+
+// lineup returns the organiser and the players holding a spot.
+func lineup(match *models.Match) []models.User {
+	users := []models.User{match.Organizer}
+	for _, p := range match.Players {
+		if p.Status == models.MatchPlayerJoined {
+			users = append(users, p.User)
+		}
+	}
+	return users
+}
+
+func containsUser(users []models.User, userID uuid.UUID) bool {
+	for _, u := range users {
+		if u.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func composite(players []RatedPlayer) rating.Rating {
+	ratings := make([]rating.Rating, len(players))
+	for i, p := range players {
+		ratings[i] = p.Rating
+	}
+	return rating.Composite(ratings)
+}
+
+// balance divides players into two sides, sizes differing by at most one, minimising
+// the difference between their average ratings. Small lineups try every division;
+// larger ones place the strongest remaining player on the weaker side that still has
+// room.
+func balance(players []RatedPlayer) (home, away []RatedPlayer) {
+	n := len(players)
+	if n < 2 {
+		return players, []RatedPlayer{}
+	}
+	homeSize := (n + 1) / 2
+	total := 0.0
+	for _, p := range players {
+		total += p.Rating.Value
+	}
+
+	if n <= maxExactSplit {
+		best, bestDiff := uint32(0), math.Inf(1)
+		// With even sides every split has a mirror image, so fixing the first player
+		// at home halves the search.
+		start, step := uint32(0), uint32(1)
+		if n%2 == 0 {
+			start, step = 1, 2
+		}
+		for mask := start; mask < 1<<n; mask += step {
+			if bits.OnesCount32(mask) != homeSize {
+				continue
+			}
+			sum := 0.0
+			for i := range n {
+				if mask&(1<<i) != 0 {
+					sum += players[i].Rating.Value
+				}
+			}
+			if diff := math.Abs(sum/float64(homeSize) - (total-sum)/float64(n-homeSize)); diff < bestDiff {
+				best, bestDiff = mask, diff
+			}
+		}
+		for i, p := range players {
+			if best&(1<<i) != 0 {
+				home = append(home, p)
+			} else {
+				away = append(away, p)
+			}
+		}
+		return home, away
+	}
+
+	sorted := append([]RatedPlayer(nil), players...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Rating.Value > sorted[j].Rating.Value })
+	var homeSum, awaySum float64
+	for _, p := range sorted {
+		toHome := len(away) >= n-homeSize || (len(home) < homeSize && homeSum <= awaySum)
+		if toHome {
+			home, homeSum = append(home, p), homeSum+p.Rating.Value
+		} else {
+			away, awaySum = append(away, p), awaySum+p.Rating.Value
+		}
+	}
+	return home, away
+}
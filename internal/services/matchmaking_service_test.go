@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/rating"
+)
+
+func ratedPlayers(values ...float64) []RatedPlayer {
+	players := make([]RatedPlayer, len(values))
+	for i, v := range values {
+		players[i] = RatedPlayer{User: models.User{ID: uuid.New()}, Rating: rating.Rating{Value: v, Deviation: 100}}
+	}
+	return players
+}
+
+func averageGap(home, away []RatedPlayer) float64 {
+	avg := func(side []RatedPlayer) float64 {
+		sum := 0.0
+		for _, p := range side {
+			sum += p.Rating.Value
+		}
+		return sum / float64(len(side))
+	}
+	return math.Abs(avg(home) - avg(away))
+}
+
+// checkSides fails unless home and away divide players into sides of (n+1)/2 and n/2.
+func checkSides(t *testing.T, players, home, away []RatedPlayer) {
+	t.Helper()
+	n := len(players)
+	if len(home) != (n+1)/2 || len(away) != n/2 {
+		t.Fatalf("sides of %d and %d for %d players", len(home), len(away), n)
+	}
+	var want, got []string
+	for _, p := range players {
+		want = append(want, p.User.ID.String())
+	}
+	for _, p := range append(slices.Clone(home), away...) {
+		got = append(got, p.User.ID.String())
+	}
+	slices.Sort(want)
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Fatalf("sides do not hold every player exactly once")
+	}
+}
+
+func TestBalanceFewPlayers(t *testing.T) {
+	home, away := balance(nil)
+	if len(home) != 0 || away == nil || len(away) != 0 {
+		t.Errorf("no players: %v, %v", home, away)
+	}
+	solo := ratedPlayers(1500)
+	if home, away := balance(solo); len(home) != 1 || len(away) != 0 {
+		t.Errorf("one player: sides of %d and %d", len(home), len(away))
+	}
+}
+
+// The exact search skips mirror images when sides are even; it must still find the
+// best split an unrestricted search finds.
+func TestBalanceExact(t *testing.T) {
+	rng := rand.New(rand.NewSource(46))
+	for n := 2; n <= 12; n++ {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			values := make([]float64, n)
+			for i := range values {
+				values[i] = 1000 + math.Round(rng.Float64()*1000)
+			}
+			players := ratedPlayers(values...)
+			home, away := balance(players)
+			checkSides(t, players, home, away)
+
+			best := math.Inf(1)
+			for mask := uint32(0); mask < 1<<n; mask++ {
+				if bits.OnesCount32(mask) != (n+1)/2 {
+					continue
+				}
+				var h, a []RatedPlayer
+				for i, p := range players {
+					if mask&(1<<i) != 0 {
+						h = append(h, p)
+					} else {
+						a = append(a, p)
+					}
+				}
+				best = math.Min(best, averageGap(h, a))
+			}
+			if gap := averageGap(home, away); math.Abs(gap-best) > 1e-9 {
+				t.Errorf("gap %.3f, best possible %.3f", gap, best)
+			}
+			if n%2 == 0 && home[0].User.ID != players[0].User.ID {
+				t.Errorf("even split does not keep the first player at home")
+			}
+		})
+	}
+}
+
+func TestBalanceGreedy(t *testing.T) {
+	for n := maxExactSplit + 1; n <= maxExactSplit+8; n++ {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			values := make([]float64, n)
+			for i := range values {
+				values[i] = 2000 - 10*float64(i)
+			}
+			players := ratedPlayers(values...)
+			home, away := balance(players)
+			checkSides(t, players, home, away)
+			if gap := averageGap(home, away); gap > 10 {
+				t.Errorf("gap %.3f between evenly spread ratings", gap)
+			}
+		})
+	}
+
+	// A standout player goes to the larger side, which dilutes them most.
+	values := []float64{3000}
+	for range maxExactSplit {
+		values = append(values, 1500)
+	}
+	players := ratedPlayers(values...)
+	home, away := balance(players)
+	checkSides(t, players, home, away)
+	if home[0].Rating.Value != 3000 {
+		t.Errorf("standout not on the larger side")
+	}
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/notifications"
+	"github.com/musishere/sportsApp/internal/payments"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+)
+
+// paymentBatchSize bounds how many splits or refunds one sweep handles.
+const paymentBatchSize = 100
+
+// paymentStore keeps splits, shares and payments; *repositories.PaymentRepository is
+// the production implementation.
+type paymentStore interface {
+	CreateSplitBooking(ctx context.Context, booking *models.Booking, split *models.PaymentSplit) error
+	GetSplitByBooking(ctx context.Context, bookingID string) (*models.PaymentSplit, error)
+	RecordPayment(ctx context.Context, payment *models.Payment) (bool, error)
+	DueForLapse(ctx context.Context, now time.Time, limit int) ([]models.PaymentSplit, error)
+	Lapse(ctx context.Context, split *models.PaymentSplit) (bool, error)
+	DueForRefund(ctx context.Context, limit int) ([]models.Payment, error)
+	MarkRefunded(ctx context.Context, paymentID uuid.UUID, refundID string) error
+}
+
+type PaymentService struct {
+	payments paymentStore
+	bookings *BookingService
+	pricing  *repositories.PricingRepository
+	users    *repositories.UserRepository
+	provider payments.Provider
+	notifier *notifications.Notifier
+	cfg      config.PaymentsConfig
+}
+
+func NewPaymentService(
+	paymentRepo *repositories.PaymentRepository,
+	bookings *BookingService,
+	pricing *repositories.PricingRepository,
+	users *repositories.UserRepository,
+	provider payments.Provider,
+	notifier *notifications.Notifier,
+	cfg config.PaymentsConfig,
+) *PaymentService {
+	return &PaymentService{
+		payments: paymentRepo,
+		bookings: bookings,
+		pricing:  pricing,
+		users:    users,
+		provider: provider,
+		notifier: notifier,
+		cfg:      cfg,
+	}
+}
+
+// CreateSplitBooking holds a field for organizerID and splits its price under the
+// turf's pricing rules evenly between them and the participants; the organiser's share
+// absorbs any remainder. The booking is confirmed once every share is paid.
+func (s *PaymentService) CreateSplitBooking(ctx context.Context, organizerID uuid.UUID, req types.CreateSplitBookingRequest) (*models.PaymentSplit, error) {
+	booking, err := s.bookings.newBooking(ctx, organizerID, req.CreateBookingRequest)
+	if err != nil {
+		return nil, err
+	}
+	booking.Status = models.BookingPendingPayment
+
+	deadline := time.Now().Add(s.cfg.DefaultDeadline)
+	if req.PaymentDeadline != nil {
+		deadline = *req.PaymentDeadline
+		if !deadline.After(time.Now()) || deadline.After(booking.StartsAt) {
+			return nil, apperrors.InvalidField("paymentDeadline", "paymentDeadline must be in the future and no later than startsAt")
+		}
+	} else if deadline.After(booking.StartsAt) {
+		deadline = booking.StartsAt
+	}
+
+	participants := []models.User{}
+	for _, id := range req.ParticipantIDs {
+		if id == organizerID.String() || slices.ContainsFunc(participants, func(u models.User) bool { return u.ID.String() == id }) {
+			continue
+		}
+		user, err := s.users.GetUserByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, apperrors.ErrNotFound) {
+				return nil, apperrors.InvalidField("participantIds", "participant "+id+" does not exist")
+			}
+			return nil, err
+		}
+		participants = append(participants, *user)
+	}
+	if len(participants) == 0 {
+		return nil, apperrors.InvalidField("participantIds", "add at least one participant other than yourself")
+	}
+
+	rules, err := s.pricing.ListByTurf(ctx, booking.TurfID)
+	if err != nil {
+		return nil, err
+	}
+	total, err := priceSlot(rules, booking.Turf.Location(), booking.StartsAt, booking.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+	if total < int64(len(participants)+1) {
+		return nil, apperrors.Conflict("the turf's price for this slot is too small to split between the players")
+	}
+	split := &models.PaymentSplit{
+		OrganizerID: organizerID,
+		TotalAmount: total,
+		Currency:    s.cfg.Currency,
+		Deadline:    deadline,
+		Status:      models.SplitCollecting,
+		Shares:      splitShares(total, organizerID, participants),
+	}
+
+	if err := s.payments.CreateSplitBooking(ctx, booking, split); err != nil {
+		return nil, err
+	}
+
+	for _, share := range split.Shares[1:] {
+		s.notify(ctx, split, share.User, "Pay your share for a booking",
+			fmt.Sprintf("You have been added to a booking at %s on %s. Your share is %s; pay it by %s or the booking is released and payments refunded.",
+				booking.Turf.Name, startTime(booking), formatAmount(share.Amount, split.Currency),
+				deadline.In(booking.Turf.Location()).Format(time.RFC1123)))
+	}
+	return s.payments.GetSplitByBooking(ctx, booking.ID.String())
+}
+
+// splitShares divides total evenly between the organiser, whose share comes first and
+// absorbs the remainder, and the participants.
+func splitShares(total int64, organizerID uuid.UUID, participants []models.User) []models.PaymentShare {
+	players := int64(len(participants) + 1)
+	share := total / players
+	shares := []models.PaymentShare{{UserID: organizerID, Amount: share + total%players}}
+	for _, user := range participants {
+		shares = append(shares, models.PaymentShare{UserID: user.ID, User: user, Amount: share})
+	}
+	return shares
+}
+
+// GetSplit returns a booking's split. Its players and the turf owner can see it.
+func (s *PaymentService) GetSplit(ctx context.Context, bookingID string, userID uuid.UUID) (*models.PaymentSplit, error) {
+	split, err := s.payments.GetSplitByBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if split.Booking.Turf.OwnerID != userID && shareOf(split, userID) == nil {
+		return nil, apperrors.NotFound("payment split")
+	}
+	return split, nil
+}
+
+func shareOf(split *models.PaymentSplit, userID uuid.UUID) *models.PaymentShare {
+	for i := range split.Shares {
+		if split.Shares[i].UserID == userID {
+			return &split.Shares[i]
+		}
+	}
+	return nil
+}
+
+// PayShare charges userID's share. If the split stopped collecting while the charge
+// was in flight, the charge is refunded straight away.
+func (s *PaymentService) PayShare(ctx context.Context, bookingID string, userID uuid.UUID, req types.PayShareRequest) (*models.PaymentSplit, error) {
+	split, err := s.payments.GetSplitByBooking(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	share := shareOf(split, userID)
+	if share == nil {
+		return nil, apperrors.NotFound("payment share")
+	}
+	if share.PaidAt != nil {
+		return nil, apperrors.Conflict("your share is already paid")
+	}
+	if !split.Collecting(time.Now()) {
+		return nil, apperrors.Conflict("the booking is no longer collecting payments")
+	}
+
+	chargeID, err := s.provider.Charge(ctx, payments.ChargeRequest{
+		Amount:         share.Amount,
+		Currency:       split.Currency,
+		Token:          req.PaymentToken,
+		Description:    "Share of booking " + bookingID,
+		IdempotencyKey: share.ID.String() + ":" + req.PaymentToken,
+	})
+	if errors.Is(err, payments.ErrDeclined) {
+		return nil, apperrors.Declined("the payment was declined").WithCause(err)
+	}
+	if err != nil {
+		return nil, apperrors.Unavailable("the payment provider is unavailable").WithCause(err)
+	}
+
+	payment := &models.Payment{
+		ShareID:  share.ID,
+		SplitID:  split.ID,
+		UserID:   userID,
+		Amount:   share.Amount,
+		Currency: split.Currency,
+		Provider: s.provider.Name(),
+		ChargeID: chargeID,
+		Status:   models.PaymentSucceeded,
+	}
+	covered, err := s.payments.RecordPayment(ctx, payment)
+	if err != nil {
+		// The money was taken but not recorded against the share; give it back.
+		if _, refundErr := s.provider.Refund(context.WithoutCancel(ctx), chargeID, share.Amount); refundErr != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to refund unrecorded charge",
+				slog.String("charge_id", chargeID), logging.Err(refundErr))
+		}
+		return nil, err
+	}
+
+	if covered {
+		for _, player := range split.Shares {
+			s.notify(ctx, split, player.User, "Booking confirmed",
+				fmt.Sprintf("Everyone has paid: your booking at %s on %s is confirmed.",
+					split.Booking.Turf.Name, startTime(&split.Booking)))
+		}
+	}
+	return s.payments.GetSplitByBooking(ctx, bookingID)
+}
+
+// RunSweeper releases splits past their deadline and refunds their payments every
+// interval until ctx is cancelled. It is safe to run on every instance.
+func (s *PaymentService) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.Sweep(ctx, now); err != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "payment sweep failed", logging.Err(err))
+			}
+		}
+	}
+}
+
+// Sweep lapses collecting splits whose deadline has passed, which frees their fields,
+// then refunds payments held for lapsed and cancelled splits. A failed refund stays
+// queued and is retried on the next sweep.
+func (s *PaymentService) Sweep(ctx context.Context, now time.Time) error {
+	due, err := s.payments.DueForLapse(ctx, now, paymentBatchSize)
+	if err != nil {
+		return err
+	}
+	for i := range due {
+		split := &due[i]
+		lapsed, err := s.payments.Lapse(ctx, split)
+		if err != nil {
+			return err
+		}
+		if !lapsed {
+			continue
+		}
+		logging.FromContext(ctx).InfoContext(ctx, "split booking lapsed",
+			slog.String("booking_id", split.BookingID.String()))
+		for _, player := range split.Shares {
+			s.notify(ctx, split, player.User, "Booking released: not fully paid",
+				fmt.Sprintf("The booking at %s on %s was not fully paid by the deadline and has been released. Any payment you made will be refunded.",
+					split.Booking.Turf.Name, startTime(&split.Booking)))
+		}
+	}
+
+	refunds, err := s.payments.DueForRefund(ctx, paymentBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, payment := range refunds {
+		refundID, err := s.provider.Refund(ctx, payment.ChargeID, payment.Amount)
+		if err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "refund failed; will retry",
+				slog.String("payment_id", payment.ID.String()), logging.Err(err))
+			continue
+		}
+		if err := s.payments.MarkRefunded(ctx, payment.ID, refundID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatAmount renders minor units as a decimal amount, e.g. 150050 PKR as "PKR 1500.50".
+func formatAmount(amount int64, currency string) string {
+	return fmt.Sprintf("%s %d.%02d", currency, amount/100, amount%100)
+}
+
+// notify emails a player about their split. Failures are logged; the change already happened.
+func (s *PaymentService) notify(ctx context.Context, split *models.PaymentSplit, user models.User, subject, body string) {
+	if err := s.notifier.SendEmail(ctx, user.Email, subject, body); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to send payment notification",
+			slog.String("booking_id", split.BookingID.String()), slog.String("user_id", user.ID.String()), logging.Err(err))
+	}
+}
@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/helpers"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/notifications"
+	"github.com/musishere/sportsApp/internal/payments"
+	"github.com/musishere/sportsApp/types"
+)
+
+// memPayments keeps splits in memory with the same contract as
+// repositories.PaymentRepository.
+type memPayments struct {
+	mu       sync.Mutex
+	splits   map[uuid.UUID]*models.PaymentSplit
+	payments []models.Payment
+	// beforeRecord, when set, runs on the split as a payment is recorded.
+	beforeRecord func(*models.PaymentSplit)
+}
+
+func (m *memPayments) CreateSplitBooking(_ context.Context, booking *models.Booking, split *models.PaymentSplit) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	booking.ID, split.ID = uuid.New(), uuid.New()
+	split.BookingID = booking.ID
+	split.Booking = *booking
+	for i := range split.Shares {
+		split.Shares[i].ID, split.Shares[i].SplitID = uuid.New(), split.ID
+	}
+	m.splits[booking.ID] = split
+	return nil
+}
+
+func (m *memPayments) GetSplitByBooking(_ context.Context, bookingID string) (*models.PaymentSplit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, split := range m.splits {
+		if id.String() == bookingID {
+			clone := *split
+			clone.Shares = append([]models.PaymentShare(nil), split.Shares...)
+			return &clone, nil
+		}
+	}
+	return nil, apperrors.NotFound("payment split")
+}
+
+func (m *memPayments) RecordPayment(_ context.Context, payment *models.Payment) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var split *models.PaymentSplit
+	for _, s := range m.splits {
+		if s.ID == payment.SplitID {
+			split = s
+		}
+	}
+	if m.beforeRecord != nil {
+		m.beforeRecord(split)
+	}
+	now := time.Now()
+	if !split.Collecting(now) {
+		return false, apperrors.Conflict("the booking is no longer collecting payments")
+	}
+	unpaid := 0
+	for i := range split.Shares {
+		share := &split.Shares[i]
+		if share.ID == payment.ShareID {
+			if share.PaidAt != nil {
+				return false, apperrors.Conflict("your share is already paid")
+			}
+			share.PaidAt = &now
+		}
+		if share.PaidAt == nil {
+			unpaid++
+		}
+	}
+	payment.ID = uuid.New()
+	m.payments = append(m.payments, *payment)
+	if unpaid > 0 {
+		return false, nil
+	}
+	split.Status = models.SplitCovered
+	split.Booking.Status = models.BookingConfirmed
+	return true, nil
+}
+
+func (m *memPayments) DueForLapse(_ context.Context, now time.Time, limit int) ([]models.PaymentSplit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var due []models.PaymentSplit
+	for _, split := range m.splits {
+		if split.Status == models.SplitCollecting && !split.Deadline.After(now) && len(due) < limit {
+			due = append(due, *split)
+		}
+	}
+	return due, nil
+}
+
+func (m *memPayments) Lapse(_ context.Context, split *models.PaymentSplit) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := m.splits[split.BookingID]
+	if stored.Status != models.SplitCollecting {
+		return false, nil
+	}
+	stored.Status = models.SplitLapsed
+	stored.Booking.Status = models.BookingExpired
+	return true, nil
+}
+
+func (m *memPayments) DueForRefund(_ context.Context, limit int) ([]models.Payment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var due []models.Payment
+	for _, payment := range m.payments {
+		for _, split := range m.splits {
+			if split.ID == payment.SplitID && payment.Status == models.PaymentSucceeded &&
+				(split.Status == models.SplitLapsed || split.Status == models.SplitCancelled) && len(due) < limit {
+				due = append(due, payment)
+			}
+		}
+	}
+	return due, nil
+}
+
+func (m *memPayments) MarkRefunded(_ context.Context, paymentID uuid.UUID, refundID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.payments {
+		if m.payments[i].ID == paymentID && m.payments[i].Status == models.PaymentSucceeded {
+			now := time.Now()
+			m.payments[i].Status, m.payments[i].RefundID, m.payments[i].RefundedAt = models.PaymentRefunded, refundID, &now
+		}
+	}
+	return nil
+}
+
+// newSplitTest returns a payment service over an in-memory store and the fake provider,
+// with a collecting split of the shares' amounts due by deadline.
+func newSplitTest(t *testing.T, deadline time.Time, amounts ...int64) (*PaymentService, *memPayments, *payments.FakeProvider, *models.PaymentSplit) {
+	t.Helper()
+	store := &memPayments{splits: make(map[uuid.UUID]*models.PaymentSplit)}
+	provider := payments.NewFakeProvider()
+	svc := &PaymentService{
+		payments: store,
+		provider: provider,
+		notifier: notifications.NewNotifier(nil, "", helpers.NewMailer(config.SMTPConfig{}), nil),
+		cfg:      config.PaymentsConfig{Currency: "PKR"},
+	}
+
+	booking := &models.Booking{
+		Turf:     models.Turf{Name: "Arena", TimeZone: "Asia/Karachi"},
+		StartsAt: deadline.Add(time.Hour),
+		EndsAt:   deadline.Add(2 * time.Hour),
+		Status:   models.BookingPendingPayment,
+	}
+	split := &models.PaymentSplit{Currency: "PKR", Deadline: deadline, Status: models.SplitCollecting}
+	for _, amount := range amounts {
+		split.TotalAmount += amount
+		split.Shares = append(split.Shares, models.PaymentShare{UserID: uuid.New(), Amount: amount})
+	}
+	if err := store.CreateSplitBooking(context.Background(), booking, split); err != nil {
+		t.Fatal(err)
+	}
+	return svc, store, provider, split
+}
+
+func pay(svc *PaymentService, split *models.PaymentSplit, share int) (*models.PaymentSplit, error) {
+	return svc.PayShare(context.Background(), split.BookingID.String(), split.Shares[share].UserID,
+		types.PayShareRequest{PaymentToken: "tok_" + split.Shares[share].UserID.String()})
+}
+
+func TestSplitSharesRounding(t *testing.T) {
+	tests := []struct {
+		total        int64
+		participants int
+		want         []int64
+	}{
+		{1000, 1, []int64{500, 500}},
+		{1000, 2, []int64{334, 333, 333}},
+		{7, 2, []int64{3, 2, 2}},
+		{3, 2, []int64{1, 1, 1}},
+		{150050, 3, []int64{37514, 37512, 37512, 37512}},
+	}
+	for _, tt := range tests {
+		organizer := uuid.New()
+		participants := make([]models.User, tt.participants)
+		for i := range participants {
+			participants[i].ID = uuid.New()
+		}
+		shares := splitShares(tt.total, organizer, participants)
+		if shares[0].UserID != organizer {
+			t.Errorf("%d/%d: the organiser's share is not first", tt.total, tt.participants+1)
+		}
+		var sum int64
+		for i, share := range shares {
+			sum += share.Amount
+			if share.Amount != tt.want[i] {
+				t.Errorf("%d/%d: share %d = %d, want %d", tt.total, tt.participants+1, i, share.Amount, tt.want[i])
+			}
+		}
+		if sum != tt.total {
+			t.Errorf("%d/%d: shares sum to %d", tt.total, tt.participants+1, sum)
+		}
+	}
+}
+
+func TestPayShareConfirmsWhenCovered(t *testing.T) {
+	svc, _, _, split := newSplitTest(t, time.Now().Add(time.Hour), 334, 333, 333)
+
+	for i := range split.Shares {
+		got, err := pay(svc, split, i)
+		if err != nil {
+			t.Fatalf("share %d: %v", i, err)
+		}
+		last := i == len(split.Shares)-1
+		if covered := got.Status == models.SplitCovered; covered != last {
+			t.Errorf("after share %d the split is %s", i, got.Status)
+		}
+		if confirmed := got.Booking.Status == models.BookingConfirmed; confirmed != last {
+			t.Errorf("after share %d the booking is %s", i, got.Booking.Status)
+		}
+	}
+
+	if _, err := pay(svc, split, 0); !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("paying a covered split: err = %v, want a conflict", err)
+	}
+}
+
+func TestSweepLapsesAndRefundsPartialPayments(t *testing.T) {
+	deadline := time.Now().Add(time.Hour)
+	svc, store, provider, split := newSplitTest(t, deadline, 500, 500)
+	if _, err := pay(svc, split, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.Sweep(context.Background(), deadline.Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.splits[split.BookingID].Status; got != models.SplitCollecting {
+		t.Fatalf("before the deadline the split is %s", got)
+	}
+
+	// The sweep at the deadline lapses the split; the next one finds nothing to refund.
+	for range 2 {
+		if err := svc.Sweep(context.Background(), deadline); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stored := store.splits[split.BookingID]
+	if stored.Status != models.SplitLapsed || stored.Booking.Status != models.BookingExpired {
+		t.Errorf("at the deadline the split is %s and its booking %s", stored.Status, stored.Booking.Status)
+	}
+	if len(store.payments) != 1 {
+		t.Fatalf("%d payments recorded, want 1", len(store.payments))
+	}
+	payment := store.payments[0]
+	if payment.Status != models.PaymentRefunded || payment.RefundID == "" || !provider.Refunded(payment.ChargeID) {
+		t.Errorf("the partial payment was not refunded: %+v", payment)
+	}
+}
+
+func TestPayShareAfterDeadlineRejected(t *testing.T) {
+	svc, store, _, split := newSplitTest(t, time.Now().Add(-time.Minute), 500, 500)
+	if _, err := pay(svc, split, 0); !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("paying after the deadline: err = %v, want a conflict", err)
+	}
+	if len(store.payments) != 0 {
+		t.Errorf("%d payments recorded after the deadline", len(store.payments))
+	}
+}
+
+func TestPayShareRefundsChargeRecordedAfterDeadline(t *testing.T) {
+	svc, store, provider, split := newSplitTest(t, time.Now().Add(time.Hour), 500, 500)
+	// The deadline passes while the charge is with the provider.
+	var chargeID string
+	store.beforeRecord = func(s *models.PaymentSplit) {
+		s.Deadline = time.Now().Add(-time.Second)
+	}
+	svc.provider = recordingProvider{FakeProvider: provider, chargeID: &chargeID}
+
+	if _, err := pay(svc, split, 0); !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("recording after the deadline: err = %v, want a conflict", err)
+	}
+	if len(store.payments) != 0 {
+		t.Errorf("%d payments recorded after the deadline", len(store.payments))
+	}
+	if chargeID == "" || !provider.Refunded(chargeID) {
+		t.Errorf("the late charge %q was not refunded", chargeID)
+	}
+}
+
+// recordingProvider remembers the last charge the fake provider made.
+type recordingProvider struct {
+	*payments.FakeProvider
+	chargeID *string
+}
+
+func (p recordingProvider) Charge(ctx context.Context, req payments.ChargeRequest) (string, error) {
+	id, err := p.FakeProvider.Charge(ctx, req)
+	*p.chargeID = id
+	return id, err
+}
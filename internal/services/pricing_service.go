@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+)
+
+type PricingService struct {
+	rules *repositories.PricingRepository
+	turfs *repositories.TurfRepostitory
+}
+
+func NewPricingService(rules *repositories.PricingRepository, turfs *repositories.TurfRepostitory) *PricingService {
+	return &PricingService{
+		rules: rules,
+		turfs: turfs,
+	}
+}
+
+// ListRules returns the turf's pricing rules by start hour.
+func (s *PricingService) ListRules(ctx context.Context, turfID string) ([]models.PricingRule, error) {
+	turf, err := s.turfs.GetTurfByID(ctx, turfID)
+	if err != nil {
+		return nil, err
+	}
+	return s.rules.ListByTurf(ctx, turf.ID)
+}
+
+// CreateRule adds an hourly rate to a turf; owner only. A rule may not share any hour
+// of any day with the turf's other rules.
+func (s *PricingService) CreateRule(ctx context.Context, turfID string, userID uuid.UUID, req types.CreatePricingRuleRequest) (*models.PricingRule, error) {
+	if *req.FromHour >= *req.ToHour {
+		return nil, apperrors.InvalidField("toHour", "toHour must be after fromHour")
+	}
+	turf, err := s.ownedTurf(ctx, turfID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	days := slices.Clone(req.Days)
+	slices.Sort(days)
+	rule := &models.PricingRule{
+		TurfID:     turf.ID,
+		Name:       strings.TrimSpace(req.Name),
+		Days:       slices.Compact(days),
+		FromHour:   *req.FromHour,
+		ToHour:     *req.ToHour,
+		HourlyRate: req.HourlyRate,
+	}
+	if rule.Days == nil {
+		rule.Days = []int{}
+	}
+	err = s.rules.Create(ctx, rule, func(existing []models.PricingRule) error {
+		for _, other := range existing {
+			if rulesOverlap(rule, &other) {
+				return apperrors.Conflict(fmt.Sprintf("the rule overlaps %s, %02d:00 to %02d:00", ruleName(&other), other.FromHour, other.ToHour))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DeleteRule removes one of a turf's pricing rules; owner only.
+func (s *PricingService) DeleteRule(ctx context.Context, turfID, ruleID string, userID uuid.UUID) error {
+	turf, err := s.ownedTurf(ctx, turfID, userID)
+	if err != nil {
+		return err
+	}
+	return s.rules.Delete(ctx, turf.ID, ruleID)
+}
+
+func (s *PricingService) ownedTurf(ctx context.Context, turfID string, userID uuid.UUID) (*models.Turf, error) {
+	turf, err := s.turfs.GetTurfByID(ctx, turfID)
+	if err != nil {
+		return nil, err
+	}
+	if turf.OwnerID != userID {
+		return nil, apperrors.Forbidden("only the turf owner can change its prices")
+	}
+	return turf, nil
+}
+
+// rulesOverlap reports whether two rules share an hour on a day both apply to.
+func rulesOverlap(a, b *models.PricingRule) bool {
+	if a.FromHour >= b.ToHour || b.FromHour >= a.ToHour {
+		return false
+	}
+	if len(a.Days) == 0 || len(b.Days) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(a.Days, func(day int) bool { return slices.Contains(b.Days, day) })
+}
+
+func ruleName(rule *models.PricingRule) string {
+	if rule.Name != "" {
+		return fmt.Sprintf("%q", rule.Name)
+	}
+	return "another rule"
+}
+
+// priceSlot is what booking [start, end) costs under a turf's rules, with hours and
+// days read in loc, the turf's time zone, and rates prorated to the second. A turf
+// without rules is free to book; one with rules must price every hour of the slot.
+func priceSlot(rules []models.PricingRule, loc *time.Location, start, end time.Time) (int64, error) {
+	if len(rules) == 0 {
+		return 0, nil
+	}
+	var total int64 // rate × seconds
+	for t := start.In(loc); t.Before(end); {
+		next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		if next.After(end) {
+			next = end
+		}
+		rule := ruleAt(rules, t.Weekday(), t.Hour())
+		if rule == nil {
+			return 0, apperrors.InvalidField("startsAt",
+				fmt.Sprintf("the turf has no price for %s at %02d:00", t.Weekday(), t.Hour()))
+		}
+		total += rule.HourlyRate * int64(next.Sub(t)/time.Second)
+		t = next
+	}
+	return (total + 1800) / 3600, nil
+}
+
+// ruleAt finds the rule covering an hour of a weekday.
+func ruleAt(rules []models.PricingRule, day time.Weekday, hour int) *models.PricingRule {
+	for i, rule := range rules {
+		if hour < rule.FromHour || hour >= rule.ToHour {
+			continue
+		}
+		if len(rule.Days) == 0 || slices.Contains(rule.Days, int(day)) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/helpers"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// Review limits.
+const (
+	MaxReviewPhotos  = 3
+	maxReviewBodyLen = 2000
+)
+
+type ReviewService struct {
+	reviews  *repositories.ReviewRepository
+	turfs    *repositories.TurfRepostitory
+	bookings *repositories.BookingRepository
+	uploader *helpers.ImageUploader
+}
+
+func NewReviewService(
+	reviews *repositories.ReviewRepository,
+	turfs *repositories.TurfRepostitory,
+	bookings *repositories.BookingRepository,
+	uploader *helpers.ImageUploader,
+) *ReviewService {
+	return &ReviewService{
+		reviews:  reviews,
+		turfs:    turfs,
+		bookings: bookings,
+		uploader: uploader,
+	}
+}
+
+// CreateReview rates a turf the caller has played at: they must have been party to a
+// confirmed booking there that has ended. Owners cannot review their own turf.
+func (s *ReviewService) CreateReview(ctx context.Context, turfID string, userID uuid.UUID, req types.CreateReviewRequest) (*models.TurfReview, error) {
+	if req.Rating < 1 || req.Rating > 5 {
+		return nil, apperrors.InvalidField("rating", "rating must be between 1 and 5")
+	}
+	body := strings.TrimSpace(req.Body)
+	if utf8.RuneCountInString(body) > maxReviewBodyLen {
+		return nil, apperrors.InvalidField("body", fmt.Sprintf("body must be at most %d characters", maxReviewBodyLen))
+	}
+	if len(req.Photos) > MaxReviewPhotos {
+		return nil, apperrors.Validation(fmt.Sprintf("a review has at most %d photos", MaxReviewPhotos))
+	}
+
+	turf, err := s.turfs.GetTurfByID(ctx, turfID)
+	if err != nil {
+		return nil, err
+	}
+	if turf.OwnerID == userID {
+		return nil, apperrors.Forbidden("owners cannot review their own turf")
+	}
+	played, err := s.bookings.HasPlayed(ctx, turf.ID, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !played {
+		return nil, apperrors.Forbidden("only players with a completed booking at this turf can review it")
+	}
+	// Check before uploading so a second review does not leave its photos behind;
+	// Create still enforces it for concurrent requests.
+	reviewed, err := s.reviews.Exists(ctx, turf.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewed {
+		return nil, apperrors.Conflict("you have already reviewed this turf")
+	}
+
+	// Upload the photos in parallel; the first failure cancels the others.
+	photos := make([]string, len(req.Photos))
+	group, uploadCtx := errgroup.WithContext(ctx)
+	for i, photo := range req.Photos {
+		group.Go(func() error {
+			url, err := s.uploader.UploadFromBytes(uploadCtx, photo.FileBytes, photo.Filename, "reviews")
+			if err != nil {
+				return fmt.Errorf("upload photo %d: %w", i+1, err)
+			}
+			photos[i] = url
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	review := &models.TurfReview{
+		TurfID: turf.ID,
+		UserID: userID,
+		Rating: req.Rating,
+		Body:   body,
+		Photos: photos,
+		Status: models.ReviewVisible,
+	}
+	if err := s.reviews.Create(ctx, review); err != nil {
+		return nil, err
+	}
+	return s.reviews.GetByID(ctx, review.ID.String())
+}
+
+// ListTurfReviews returns a page of the turf's visible reviews, newest first.
+func (s *ReviewService) ListTurfReviews(ctx context.Context, turfID string, page, pageSize int) ([]models.TurfReview, int64, error) {
+	turf, err := s.turfs.GetTurfByID(ctx, turfID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.reviews.ListByTurf(ctx, turf.ID, page, pageSize)
+}
+
+// visibleReview loads a review, treating a hidden one as missing.
+func (s *ReviewService) visibleReview(ctx context.Context, id string) (*models.TurfReview, error) {
+	review, err := s.reviews.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status != models.ReviewVisible {
+		return nil, apperrors.NotFound("review")
+	}
+	return review, nil
+}
+
+// Reply sets the turf owner's reply to a review, replacing any earlier one.
+func (s *ReviewService) Reply(ctx context.Context, id string, userID uuid.UUID, req types.ReplyToReviewRequest) (*models.TurfReview, error) {
+	reply := strings.TrimSpace(req.Reply)
+	if reply == "" {
+		return nil, apperrors.InvalidField("reply", "reply is required")
+	}
+	review, err := s.visibleReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.Turf.OwnerID != userID {
+		return nil, apperrors.Forbidden("only the turf owner can reply to its reviews")
+	}
+	if err := s.reviews.Reply(ctx, review, reply, time.Now()); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// Report flags a review for moderation. Authors cannot report their own review, and
+// each user reports a review once.
+func (s *ReviewService) Report(ctx context.Context, id string, userID uuid.UUID, req types.ReportReviewRequest) error {
+	review, err := s.visibleReview(ctx, id)
+	if err != nil {
+		return err
+	}
+	if review.UserID == userID {
+		return apperrors.Forbidden("you cannot report your own review")
+	}
+	return s.reviews.Report(ctx, &models.ReviewReport{
+		ReviewID: review.ID,
+		UserID:   userID,
+		Reason:   strings.TrimSpace(req.Reason),
+	})
+}
+
+// ListReported returns a page of reported reviews for moderation, most reported
+// first.
+func (s *ReviewService) ListReported(ctx context.Context, page, pageSize int) ([]models.TurfReview, int64, error) {
+	return s.reviews.ListReported(ctx, page, pageSize)
+}
+
+// Hide takes a review out of the turf's listing and rating.
+func (s *ReviewService) Hide(ctx context.Context, id string, req types.HideReviewRequest) (*models.TurfReview, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, apperrors.InvalidField("reason", "reason is required")
+	}
+	review, err := s.reviews.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status == models.ReviewHidden {
+		return nil, apperrors.Conflict("review is already hidden")
+	}
+	if err := s.reviews.SetStatus(ctx, review, models.ReviewHidden, reason); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// Restore puts a hidden review back in the turf's listing and rating.
+func (s *ReviewService) Restore(ctx context.Context, id string) (*models.TurfReview, error) {
+	review, err := s.reviews.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status == models.ReviewVisible {
+		return nil, apperrors.Conflict("review is not hidden")
+	}
+	if err := s.reviews.SetStatus(ctx, review, models.ReviewVisible, ""); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/notifications"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+)
+
+const (
+	// MaxSavedSearches caps how many live searches one player can keep.
+	MaxSavedSearches = 10
+	// maxSearchWindow is the longest period one search can watch.
+	maxSearchWindow = 90 * 24 * time.Hour
+	// slotAlertCooldown stops a search being alerted more than once in the interval.
+	slotAlertCooldown = 15 * time.Minute
+	// slotAlertBatch bounds how many searches one freed slot alerts.
+	slotAlertBatch = 200
+)
+
+type SavedSearchService struct {
+	searches  *repositories.SavedSearchRepository
+	locations *repositories.LocationRepository
+	sports    *repositories.SportsRepository
+	notifier  *notifications.Notifier
+}
+
+func NewSavedSearchService(
+	searches *repositories.SavedSearchRepository,
+	locations *repositories.LocationRepository,
+	sports *repositories.SportsRepository,
+	notifier *notifications.Notifier,
+) *SavedSearchService {
+	return &SavedSearchService{
+		searches:  searches,
+		locations: locations,
+		sports:    sports,
+		notifier:  notifier,
+	}
+}
+
+// CreateSearch saves a search around userID's saved location. Players keep at most
+// MaxSavedSearches whose window has not ended.
+func (s *SavedSearchService) CreateSearch(ctx context.Context, userID uuid.UUID, req types.CreateSavedSearchRequest) (*models.SavedSearch, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperrors.InvalidField("name", "name is required")
+	}
+	now := time.Now()
+	if !req.WindowEnd.After(req.WindowStart) {
+		return nil, apperrors.InvalidField("windowEnd", "windowEnd must be after windowStart")
+	}
+	if !req.WindowEnd.After(now) {
+		return nil, apperrors.InvalidField("windowEnd", "windowEnd must be in the future")
+	}
+	if req.WindowEnd.Sub(req.WindowStart) > maxSearchWindow {
+		return nil, apperrors.InvalidField("windowEnd", "a search can watch at most 90 days")
+	}
+	fromHour, toHour := 0, 24
+	if req.FromHour != nil {
+		fromHour = *req.FromHour
+	}
+	if req.ToHour != nil {
+		toHour = *req.ToHour
+	}
+	if fromHour >= toHour {
+		return nil, apperrors.InvalidField("toHour", "toHour must be after fromHour")
+	}
+
+	count, err := s.searches.CountActive(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if count >= MaxSavedSearches {
+		return nil, apperrors.Conflict(fmt.Sprintf("you can keep at most %d saved searches", MaxSavedSearches))
+	}
+	location, err := s.locations.GetLocationByUserID(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	search := &models.SavedSearch{
+		UserID:      userID,
+		Name:        name,
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
+		RadiusKm:    req.RadiusKm,
+		WindowStart: req.WindowStart,
+		WindowEnd:   req.WindowEnd,
+		FromHour:    fromHour,
+		ToHour:      toHour,
+	}
+	if req.SportID != nil {
+		sport, err := s.sports.GetSportsByID(ctx, *req.SportID)
+		if err != nil {
+			return nil, err
+		}
+		search.SportID = &sport.ID
+		search.Sport = &sport
+	}
+	if err := s.searches.Create(ctx, search); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+// ListSearches returns userID's saved searches, newest first, ended ones included.
+func (s *SavedSearchService) ListSearches(ctx context.Context, userID uuid.UUID) ([]models.SavedSearch, error) {
+	return s.searches.ListByUser(ctx, userID)
+}
+
+// GetSearch returns one of userID's searches; other players' searches are not found.
+func (s *SavedSearchService) GetSearch(ctx context.Context, id string, userID uuid.UUID) (*models.SavedSearch, error) {
+	search, err := s.searches.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if search.UserID != userID {
+		return nil, apperrors.NotFound("saved search")
+	}
+	return search, nil
+}
+
+func (s *SavedSearchService) DeleteSearch(ctx context.Context, id string, userID uuid.UUID) error {
+	search, err := s.GetSearch(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	return s.searches.Delete(ctx, search.ID)
+}
+
+// SlotOpened emails the players whose saved searches match a cancelled booking's field
+// and time, other than the booking party and whoever cancelled it. Each search is
+// alerted at most once per slotAlertCooldown. A booking flagged by a closure frees
+// nothing bookable, so alerts no one. Failures are logged; the cancellation already
+// happened.
+func (s *SavedSearchService) SlotOpened(ctx context.Context, booking models.Booking, cancelledBy uuid.UUID) {
+	now := time.Now()
+	if !booking.StartsAt.After(now) || booking.ClosureID != nil {
+		return
+	}
+	turf := booking.Turf
+	zone := turf.Location()
+	logger := logging.FromContext(ctx)
+
+	candidates, err := s.searches.Matching(ctx, repositories.OpenSlot{
+		TurfID:    booking.TurfID,
+		Latitude:  turf.Latitude,
+		Longitude: turf.Longitude,
+		TimeZone:  zone.String(),
+		StartsAt:  booking.StartsAt,
+		EndsAt:    booking.EndsAt,
+		Excluded:  []uuid.UUID{booking.BookedByID, cancelledBy},
+	}, now.Add(-slotAlertCooldown), slotAlertBatch)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to match saved searches",
+			slog.String("booking_id", booking.ID.String()), logging.Err(err))
+		return
+	}
+
+	notified := make([]uuid.UUID, 0, len(candidates))
+	alerted := make(map[uuid.UUID]bool)
+	for _, search := range candidates {
+		if alerted[search.UserID] {
+			continue
+		}
+		body := fmt.Sprintf("Field %d at %s is free from %s to %s, matching your saved search %q. Book it before someone else does.",
+			booking.FieldNumber, turf.Name, booking.StartsAt.In(zone).Format(time.RFC1123), booking.EndsAt.In(zone).Format(time.Kitchen), search.Name)
+		if err := s.notifier.SendEmail(ctx, search.User.Email, "A slot opened up at "+turf.Name, body); err != nil {
+			logger.ErrorContext(ctx, "failed to send slot alert",
+				slog.String("search_id", search.ID.String()), slog.String("user_id", search.UserID.String()), logging.Err(err))
+			continue
+		}
+		alerted[search.UserID] = true
+		notified = append(notified, search.ID)
+	}
+
+	if err := s.searches.MarkNotified(ctx, notified, now); err != nil {
+		logger.ErrorContext(ctx, "failed to record slot alerts",
+			slog.String("booking_id", booking.ID.String()), logging.Err(err))
+	}
+}
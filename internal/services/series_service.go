@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/recurrence"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+)
+
+const (
+	// maxSeriesOccurrences caps how many bookings one series can make, two years of
+	// weekly games.
+	maxSeriesOccurrences = 104
+	// maxConflictsListed bounds the clashing dates named in a conflict error.
+	maxConflictsListed = 5
+)
+
+type BookingSeriesService struct {
+	series   *repositories.SeriesRepository
+	pricing  *repositories.PricingRepository
+	teams    *repositories.TeamRepository
+	bookings *BookingService
+	cfg      config.PaymentsConfig
+}
+
+func NewBookingSeriesService(
+	series *repositories.SeriesRepository,
+	pricing *repositories.PricingRepository,
+	teams *repositories.TeamRepository,
+	bookings *BookingService,
+	cfg config.PaymentsConfig,
+) *BookingSeriesService {
+	return &BookingSeriesService{
+		series:   series,
+		pricing:  pricing,
+		teams:    teams,
+		bookings: bookings,
+		cfg:      cfg,
+	}
+}
+
+// SeriesQuote is a series as it would be booked, with the occurrences whose field is
+// already taken.
+type SeriesQuote struct {
+	Series    *models.BookingSeries
+	Conflicts []models.Booking
+}
+
+// Available reports whether an occurrence of the quote is free to book.
+func (q *SeriesQuote) Available(occurrence *models.Booking) bool {
+	for _, c := range q.Conflicts {
+		if c.StartsAt.Equal(occurrence.StartsAt) {
+			return false
+		}
+	}
+	return true
+}
+
+// QuoteSeries prices every occurrence of a series and finds those that clash with
+// existing bookings, without booking anything.
+func (s *BookingSeriesService) QuoteSeries(ctx context.Context, userID uuid.UUID, req types.CreateBookingSeriesRequest) (*SeriesQuote, error) {
+	series, err := s.plan(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	conflicts, err := s.series.Conflicts(ctx, series.Bookings)
+	if err != nil {
+		return nil, err
+	}
+	return &SeriesQuote{Series: series, Conflicts: conflicts}, nil
+}
+
+// CreateSeries books every occurrence of a series for userID, or for a team userID
+// captains. Nothing is booked if any occurrence clashes with an existing booking or a
+// closure.
+func (s *BookingSeriesService) CreateSeries(ctx context.Context, userID uuid.UUID, req types.CreateBookingSeriesRequest) (*models.BookingSeries, error) {
+	series, err := s.plan(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+	clashing, err := s.series.Create(ctx, series)
+	if err != nil {
+		return nil, err
+	}
+	if len(clashing) > 0 {
+		zone := series.Turf.Location()
+		dates := make([]string, 0, maxConflictsListed)
+		for _, b := range clashing[:min(len(clashing), maxConflictsListed)] {
+			dates = append(dates, b.StartsAt.In(zone).Format("Mon 2 Jan 2006"))
+		}
+		more := ""
+		if len(clashing) > maxConflictsListed {
+			more = fmt.Sprintf(" and %d more", len(clashing)-maxConflictsListed)
+		}
+		return nil, apperrors.Conflict(fmt.Sprintf("the field is booked or closed on %d of the %d dates: %s%s",
+			len(clashing), len(series.Bookings), strings.Join(dates, ", "), more))
+	}
+	return series, nil
+}
+
+// plan validates req and builds the series it describes with every occurrence priced,
+// its turf and team loaded. The rule is expanded in the turf's time zone, so every
+// occurrence starts at the turf's same wall-clock time across DST changes.
+func (s *BookingSeriesService) plan(ctx context.Context, userID uuid.UUID, req types.CreateBookingSeriesRequest) (*models.BookingSeries, error) {
+	first, err := s.bookings.newBooking(ctx, userID, req.CreateBookingRequest)
+	if err != nil {
+		return nil, err
+	}
+	loc := first.Turf.Location()
+	rule, err := recurrence.Parse(req.RRule, loc)
+	if err != nil {
+		return nil, apperrors.InvalidField("rrule", err.Error())
+	}
+	starts, err := rule.Expand(req.StartsAt.In(loc), maxSeriesOccurrences)
+	if err != nil {
+		return nil, apperrors.InvalidField("rrule", err.Error())
+	}
+	if len(starts) == 0 {
+		return nil, apperrors.InvalidField("rrule", "the rule has no occurrences from startsAt")
+	}
+	rules, err := s.pricing.ListByTurf(ctx, first.TurfID)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := req.EndsAt.Sub(req.StartsAt)
+	series := &models.BookingSeries{
+		TurfID:          first.TurfID,
+		Turf:            first.Turf,
+		FieldNumber:     first.FieldNumber,
+		BookedByID:      userID,
+		TeamID:          first.TeamID,
+		Team:            first.Team,
+		RRule:           strings.TrimSpace(req.RRule),
+		StartsAt:        req.StartsAt.In(loc),
+		DurationMinutes: int(duration / time.Minute),
+		Currency:        s.cfg.Currency,
+		Status:          models.SeriesActive,
+	}
+	for _, start := range starts {
+		end := start.Add(duration)
+		if err := checkBookable(&first.Turf, first.FieldNumber, start, end); err != nil {
+			return nil, err
+		}
+		amount, err := priceSlot(rules, loc, start, end)
+		if err != nil {
+			return nil, err
+		}
+		series.Bookings = append(series.Bookings, models.Booking{
+			TurfID:      first.TurfID,
+			FieldNumber: first.FieldNumber,
+			StartsAt:    start,
+			EndsAt:      end,
+			BookedByID:  userID,
+			TeamID:      first.TeamID,
+			Status:      models.BookingConfirmed,
+			Amount:      amount,
+		})
+	}
+	return series, nil
+}
+
+// GetSeries returns a series to its booker, its team's players and the turf owner.
+func (s *BookingSeriesService) GetSeries(ctx context.Context, id string, userID uuid.UUID) (*models.BookingSeries, error) {
+	series, err := s.series.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	visible := series.BookedByID == userID || series.Turf.OwnerID == userID
+	if !visible && series.TeamID != nil {
+		if visible, err = s.teams.IsMember(ctx, *series.TeamID, userID); err != nil {
+			return nil, err
+		}
+	}
+	if !visible {
+		return nil, apperrors.NotFound("booking series")
+	}
+	return series, nil
+}
+
+// ListSeries returns userID's own and team series, newest first.
+func (s *BookingSeriesService) ListSeries(ctx context.Context, userID uuid.UUID) ([]models.BookingSeries, error) {
+	return s.series.ListByUser(ctx, userID)
+}
+
+// CancelSeries cancels the series and frees its occurrences yet to start. The booker,
+// the team's captain and the turf owner may cancel.
+func (s *BookingSeriesService) CancelSeries(ctx context.Context, id string, userID uuid.UUID) (*models.BookingSeries, error) {
+	series, err := s.manageable(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var freed []models.Booking
+	for _, b := range series.Bookings {
+		if b.Status == models.BookingConfirmed && b.StartsAt.After(now) {
+			b.Turf = series.Turf
+			freed = append(freed, b)
+		}
+	}
+	if err := s.series.Cancel(ctx, series, now); err != nil {
+		return nil, err
+	}
+	// Searches are alerted at most once per cooldown, so walking every freed date
+	// costs queries rather than inbox space.
+	go func(ctx context.Context) {
+		for _, b := range freed {
+			s.bookings.alerts.SlotOpened(ctx, b, userID)
+		}
+	}(context.WithoutCancel(ctx))
+	return s.series.GetByID(ctx, id)
+}
+
+// CancelOccurrence cancels one occurrence of a series, as CancelBooking does.
+func (s *BookingSeriesService) CancelOccurrence(ctx context.Context, id, bookingID string, userID uuid.UUID) (*models.BookingSeries, error) {
+	series, err := s.GetSeries(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := occurrence(series, bookingID); err != nil {
+		return nil, err
+	}
+	if _, err := s.bookings.CancelBooking(ctx, bookingID, userID); err != nil {
+		return nil, err
+	}
+	return s.series.GetByID(ctx, id)
+}
+
+// RescheduleOccurrence moves one confirmed occurrence of an active series to another
+// time or field of the same turf and reprices it at the turf's rates for the new time.
+func (s *BookingSeriesService) RescheduleOccurrence(ctx context.Context, id, bookingID string, userID uuid.UUID, req types.RescheduleOccurrenceRequest) (*models.BookingSeries, error) {
+	series, err := s.manageable(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if series.Status != models.SeriesActive {
+		return nil, apperrors.Conflict("booking series is cancelled")
+	}
+	booking, err := occurrence(series, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !booking.StartsAt.After(now) {
+		return nil, apperrors.Conflict("occurrences that have started cannot be rescheduled")
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, apperrors.InvalidField("endsAt", "endsAt must be after startsAt")
+	}
+	if req.StartsAt.Before(now) {
+		return nil, apperrors.InvalidField("startsAt", "startsAt must be in the future")
+	}
+	field := booking.FieldNumber
+	if req.FieldNumber != nil {
+		field = *req.FieldNumber
+	}
+	if err := checkBookable(&series.Turf, field, req.StartsAt, req.EndsAt); err != nil {
+		return nil, err
+	}
+	rules, err := s.pricing.ListByTurf(ctx, series.TurfID)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := priceSlot(rules, series.Turf.Location(), req.StartsAt, req.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.series.Reschedule(ctx, booking, field, req.StartsAt, req.EndsAt, amount); err != nil {
+		return nil, err
+	}
+	return s.series.GetByID(ctx, id)
+}
+
+// manageable loads a series userID may change: the booker's, the team captain's or
+// the turf owner's.
+func (s *BookingSeriesService) manageable(ctx context.Context, id string, userID uuid.UUID) (*models.BookingSeries, error) {
+	series, err := s.GetSeries(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	allowed := series.BookedByID == userID || series.Turf.OwnerID == userID ||
+		(series.Team != nil && series.Team.CaptainID == userID)
+	if !allowed {
+		return nil, apperrors.Forbidden("only the booker, the team captain or the turf owner can change the series")
+	}
+	return series, nil
+}
+
+// occurrence finds one of the series' bookings.
+func occurrence(series *models.BookingSeries, bookingID string) (*models.Booking, error) {
+	for i, b := range series.Bookings {
+		if b.ID.String() == bookingID {
+			return &series.Bookings[i], nil
+		}
+	}
+	return nil, apperrors.NotFound("occurrence")
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// sportStats names the per-player stats recorded for each sport, keyed by lower-case
+// sport name. Sports not listed here record a single generic "points" stat.
+var sportStats = map[string][]string{
+	"football":   {"goals", "assists", "saves"},
+	"futsal":     {"goals", "assists", "saves"},
+	"hockey":     {"goals", "assists", "saves"},
+	"cricket":    {"runs", "wickets", "catches"},
+	"basketball": {"points", "rebounds", "assists"},
+	"volleyball": {"points", "aces", "blocks"},
+	"padel":      {"games", "aces"},
+	"tennis":     {"games", "aces"},
+	"badminton":  {"points", "smashes"},
+}
+
+var defaultStats = []string{"points"}
+
+// statsFor returns the stat names recorded for sport.
+func statsFor(sport *models.Sports) []string {
+	if stats, ok := sportStats[strings.ToLower(strings.TrimSpace(sport.Name))]; ok {
+		return stats
+	}
+	return defaultStats
+}
@@ -2,9 +2,7 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"time"
 
 	"github.com/musishere/sportsApp/internal/helpers"
 	"github.com/musishere/sportsApp/internal/models"
@@ -25,14 +23,11 @@ func NewSportsService(repo *repositories.SportsRepository, uploader *helpers.Ima
 	}
 }
 
-func (s *SportsService) CreateNewSport(req types.CreateSportRequest) (*models.Sports, error) {
+func (s *SportsService) CreateNewSport(ctx context.Context, req types.CreateSportRequest) (*models.Sports, error) {
 	if err := validators.ValidateSportInput(req.Name, req.MinPlayers, req.MaxPlayers); err != nil {
 		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
-	defer cancel()
-
 	imageURL, err := s.uploader.UploadFromBytes(ctx, req.FileBytes, req.Filename, "sports")
 	if err != nil {
 		return nil, fmt.Errorf("upload failed: %w", err)
@@ -44,7 +39,7 @@ func (s *SportsService) CreateNewSport(req types.CreateSportRequest) (*models.Sp
 		IconUrl:    imageURL,
 	}
 
-	err = s.repo.CreateSport(sports)
+	err = s.repo.CreateSport(ctx, sports)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sport: %w", err)
 	}
@@ -52,19 +47,17 @@ func (s *SportsService) CreateNewSport(req types.CreateSportRequest) (*models.Sp
 	return sports, nil
 }
 
-func (s *SportsService) GetAllSports() (*[]models.Sports, error) {
-
-	sports := s.repo.GetSports()
-
-	if len(sports) == 0 {
-		return nil, errors.New("No sports found")
+// GetAllSports returns every sport; the list is empty, not an error, when there are none.
+func (s *SportsService) GetAllSports(ctx context.Context) (*[]models.Sports, error) {
+	sports, err := s.repo.GetSports(ctx)
+	if err != nil {
+		return nil, err
 	}
-
 	return &sports, nil
 }
 
-func (s *SportsService) GetSportsByID(id string) (*models.Sports, error) {
-	sports, err := s.repo.GetSportsByID(id)
+func (s *SportsService) GetSportsByID(ctx context.Context, id string) (*models.Sports, error) {
+	sports, err := s.repo.GetSportsByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -73,8 +66,8 @@ func (s *SportsService) GetSportsByID(id string) (*models.Sports, error) {
 }
 
 // UpdateSports updates only the fields that are non-nil (partial update).
-func (s *SportsService) UpdateSports(id string, req types.UpdateSportRequest) (*models.Sports, error) {
-	sport, err := s.repo.GetSportsByID(id)
+func (s *SportsService) UpdateSports(ctx context.Context, id string, req types.UpdateSportRequest) (*models.Sports, error) {
+	sport, err := s.repo.GetSportsByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -90,12 +83,12 @@ func (s *SportsService) UpdateSports(id string, req types.UpdateSportRequest) (*
 	if err := validators.ValidateSportInput(sport.Name, sport.MinPlayers, sport.MaxPlayers); err != nil {
 		return nil, err
 	}
-	if err := s.repo.UpdateSport(&sport); err != nil {
+	if err := s.repo.UpdateSport(ctx, &sport); err != nil {
 		return nil, fmt.Errorf("failed to update sport: %w", err)
 	}
 	return &sport, nil
 }
 
-func (s *SportsService) DeleteSports(id string) error {
-	return s.repo.DeleteSport(id)
+func (s *SportsService) DeleteSports(ctx context.Context, id string) error {
+	return s.repo.DeleteSport(ctx, id)
 }
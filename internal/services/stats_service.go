@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/leaderboard"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+)
+
+type StatsService struct {
+	results *repositories.ResultRepository
+	matches *repositories.MatchRepository
+	users   *repositories.UserRepository
+	sports  *repositories.SportsRepository
+	board   *leaderboard.Board
+}
+
+func NewStatsService(
+	results *repositories.ResultRepository,
+	matches *repositories.MatchRepository,
+	users *repositories.UserRepository,
+	sports *repositories.SportsRepository,
+	board *leaderboard.Board,
+) *StatsService {
+	return &StatsService{
+		results: results,
+		matches: matches,
+		users:   users,
+		sports:  sports,
+		board:   board,
+	}
+}
+
+// PlayerProfile is a player's record across every sport they have results in.
+type PlayerProfile struct {
+	User   models.User
+	Sports []SportProfile
+}
+
+// SportProfile is a player's record and stat totals in one sport.
+type SportProfile struct {
+	Sport  models.Sports
+	Record repositories.SportRecord
+	Stats  map[string]int
+}
+
+// Ranking is a player's place on a leaderboard.
+type Ranking struct {
+	Rank   int
+	User   models.User
+	Points int
+	Played int
+}
+
+// RecordResult records the final score of a confirmed match once its booking has ended.
+// Only the organiser may do this, and only for themselves and joined players. Each
+// player earns leaderboard points for their side's outcome.
+func (s *StatsService) RecordResult(ctx context.Context, matchID string, userID uuid.UUID, req types.RecordMatchResultRequest) (*models.MatchResult, error) {
+	match, err := s.matches.GetByID(ctx, matchID)
+	if err != nil {
+		return nil, err
+	}
+	if match.OrganizerID != userID {
+		return nil, apperrors.Forbidden("only the organiser can record the result")
+	}
+	if match.Status != models.MatchConfirmed {
+		return nil, apperrors.Conflict("only confirmed matches have results")
+	}
+	if time.Now().Before(match.Booking.EndsAt) {
+		return nil, apperrors.Conflict("the match has not finished yet")
+	}
+
+	eligible := map[uuid.UUID]bool{match.OrganizerID: true}
+	for _, p := range match.Players {
+		if p.Status == models.MatchPlayerJoined {
+			eligible[p.UserID] = true
+		}
+	}
+	stats := statsFor(&match.Sport)
+	home, away := *req.HomeScore, *req.AwayScore
+
+	result := &models.MatchResult{MatchID: match.ID, HomeScore: home, AwayScore: away, RecordedByID: userID}
+	sides := map[string]int{}
+	seen := map[uuid.UUID]bool{}
+	for i, p := range req.Players {
+		field := fmt.Sprintf("players[%d]", i)
+		id, err := uuid.Parse(p.UserID)
+		if err != nil || !eligible[id] {
+			return nil, apperrors.InvalidField(field+".userId", "userId must be the organiser or a joined player")
+		}
+		if seen[id] {
+			return nil, apperrors.InvalidField(field+".userId", "each player can only be listed once")
+		}
+		seen[id] = true
+		for name := range p.Stats {
+			if !slices.Contains(stats, name) {
+				return nil, apperrors.InvalidField(field+".stats",
+					fmt.Sprintf("%s records %s", match.Sport.Name, strings.Join(stats, ", ")))
+			}
+		}
+		sides[p.Side]++
+
+		outcome, points := outcomeFor(p.Side, home, away)
+		result.Players = append(result.Players, models.PlayerResult{
+			UserID:   id,
+			SportID:  match.SportID,
+			TurfID:   match.Booking.TurfID,
+			City:     match.Booking.Turf.City,
+			Side:     p.Side,
+			Outcome:  outcome,
+			Points:   points,
+			Stats:    p.Stats,
+			PlayedAt: match.Booking.StartsAt,
+		})
+	}
+	if sides[models.SideHome] == 0 || sides[models.SideAway] == 0 {
+		return nil, apperrors.InvalidField("players", "both sides need at least one player")
+	}
+	for i := range result.Players {
+		if result.Players[i].Stats == nil {
+			result.Players[i].Stats = map[string]int{}
+		}
+	}
+
+	if err := s.results.Create(ctx, result, match.SportID, rateResult(result.Players, match.SportID, match.Booking.StartsAt)); err != nil {
+		return nil, err
+	}
+	s.addToBoards(ctx, result.Players)
+	return s.results.GetByMatch(ctx, match.ID.String())
+}
+
+// outcomeFor returns how a side did and the points that earned.
+func outcomeFor(side string, home, away int) (string, int) {
+	ours, theirs := home, away
+	if side == models.SideAway {
+		ours, theirs = away, home
+	}
+	switch {
+	case ours > theirs:
+		return models.OutcomeWon, pointsForWin
+	case ours == theirs:
+		return models.OutcomeDrawn, pointsForDraw
+	default:
+		return models.OutcomeLost, 0
+	}
+}
+
+// addToBoards credits each player on their sport's overall, turf and city boards. A
+// failure is logged for that player and the rest are still credited, rather than
+// failing the request: the result is safely recorded and RebuildLeaderboards restores
+// the boards from it.
+func (s *StatsService) addToBoards(ctx context.Context, players []models.PlayerResult) {
+	for _, p := range players {
+		turfID := p.TurfID
+		scopes := []leaderboard.Scope{{SportID: p.SportID}, {SportID: p.SportID, TurfID: &turfID}}
+		if leaderboard.NormalizeCity(p.City) != "" {
+			scopes = append(scopes, leaderboard.Scope{SportID: p.SportID, City: p.City})
+		}
+		if err := s.board.Add(ctx, p.UserID, p.Points, scopes...); err != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "failed to update leaderboards",
+				slog.String("user_id", p.UserID.String()), slog.String("sport_id", p.SportID.String()), logging.Err(err))
+		}
+	}
+}
+
+func (s *StatsService) GetResult(ctx context.Context, matchID string) (*models.MatchResult, error) {
+	if _, err := s.matches.GetByID(ctx, matchID); err != nil {
+		return nil, err
+	}
+	return s.results.GetByMatch(ctx, matchID)
+}
+
+// Profile returns a player's games played, won, drawn and lost, with stat totals, per
+// sport.
+func (s *StatsService) Profile(ctx context.Context, userID string) (*PlayerProfile, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.results.Records(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.results.StatTotals(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &PlayerProfile{User: *user, Sports: make([]SportProfile, 0, len(records))}
+	for _, record := range records {
+		sport, err := s.sports.GetSportsByID(ctx, record.SportID.String())
+		if err != nil {
+			return nil, err
+		}
+		stats := map[string]int{}
+		for _, t := range totals {
+			if t.SportID == record.SportID {
+				stats[t.Name] = t.Total
+			}
+		}
+		profile.Sports = append(profile.Sports, SportProfile{Sport: sport, Record: record, Stats: stats})
+	}
+	return profile, nil
+}
+
+// Leaderboard ranks a sport's players by points, overall or within one turf or city.
+// It reads the Redis board, falling back to Postgres while Redis is unavailable.
+func (s *StatsService) Leaderboard(ctx context.Context, sportID string, q types.LeaderboardQuery) ([]Ranking, error) {
+	sport, err := s.sports.GetSportsByID(ctx, sportID)
+	if err != nil {
+		return nil, err
+	}
+	if q.TurfID != "" && q.City != "" {
+		return nil, apperrors.Validation("filter by turfId or city, not both",
+			apperrors.Field("turfId", "filter by turfId or city, not both"),
+			apperrors.Field("city", "filter by turfId or city, not both"))
+	}
+	scope := leaderboard.Scope{SportID: sport.ID, City: leaderboard.NormalizeCity(q.City)}
+	if q.TurfID != "" {
+		turfID, err := uuid.Parse(q.TurfID)
+		if err != nil {
+			return nil, apperrors.InvalidField("turfId", "turfId must be a valid uuid")
+		}
+		scope.TurfID = &turfID
+	}
+
+	standings, err := s.board.Top(ctx, scope, q.Limit)
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "leaderboard unavailable in Redis, reading from Postgres", logging.Err(err))
+		rows, err := s.results.Board(ctx, repositories.BoardFilter(scope), q.Limit)
+		if err != nil {
+			return nil, err
+		}
+		standings = make([]leaderboard.Standing, 0, len(rows))
+		for _, row := range rows {
+			standings = append(standings, leaderboard.Standing(row))
+		}
+	}
+	return s.rank(ctx, standings)
+}
+
+// rank attaches users to standings and numbers them, giving tied points the same rank.
+func (s *StatsService) rank(ctx context.Context, standings []leaderboard.Standing) ([]Ranking, error) {
+	ids := make([]uuid.UUID, 0, len(standings))
+	for _, st := range standings {
+		ids = append(ids, st.UserID)
+	}
+	users, err := s.users.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]models.User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+
+	rankings := make([]Ranking, 0, len(standings))
+	for i, st := range standings {
+		user, ok := byID[st.UserID]
+		if !ok {
+			continue
+		}
+		rank := i + 1
+		if n := len(rankings); n > 0 && rankings[n-1].Points == st.Points {
+			rank = rankings[n-1].Rank
+		}
+		rankings = append(rankings, Ranking{Rank: rank, User: user, Points: st.Points, Played: st.Played})
+	}
+	return rankings, nil
+}
+
+// RebuildLeaderboards replaces every board with totals recomputed from the recorded
+// results, and reports how many boards and entries it wrote.
+func (s *StatsService) RebuildLeaderboards(ctx context.Context) (int, int, error) {
+	totals, err := s.results.PlayerTotals(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	type key struct {
+		sport, turf uuid.UUID
+		city        string
+	}
+	boards := map[key]map[uuid.UUID]*leaderboard.Standing{}
+	credit := func(k key, t repositories.PlayerTotal) {
+		board, ok := boards[k]
+		if !ok {
+			board = map[uuid.UUID]*leaderboard.Standing{}
+			boards[k] = board
+		}
+		st, ok := board[t.UserID]
+		if !ok {
+			st = &leaderboard.Standing{UserID: t.UserID}
+			board[t.UserID] = st
+		}
+		st.Points += t.Points
+		st.Played += t.Played
+	}
+	for _, t := range totals {
+		credit(key{sport: t.SportID}, t)
+		credit(key{sport: t.SportID, turf: t.TurfID}, t)
+		if city := leaderboard.NormalizeCity(t.City); city != "" {
+			credit(key{sport: t.SportID, city: city}, t)
+		}
+	}
+
+	entries := 0
+	for k, board := range boards {
+		scope := leaderboard.Scope{SportID: k.sport, City: k.city}
+		if k.turf != uuid.Nil {
+			turf := k.turf
+			scope.TurfID = &turf
+		}
+		standings := make([]leaderboard.Standing, 0, len(board))
+		for _, st := range board {
+			standings = append(standings, *st)
+		}
+		if err := s.board.Replace(ctx, scope, standings); err != nil {
+			return 0, 0, err
+		}
+		entries += len(standings)
+	}
+	return len(boards), entries, nil
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/logging"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/notifications"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+)
+
+// invitationTTL is how long an invitation can be answered.
+const invitationTTL = 7 * 24 * time.Hour
+
+type TeamService struct {
+	teams    *repositories.TeamRepository
+	sports   *repositories.SportsRepository
+	users    *repositories.UserRepository
+	notifier *notifications.Notifier
+}
+
+func NewTeamService(
+	teams *repositories.TeamRepository,
+	sports *repositories.SportsRepository,
+	users *repositories.UserRepository,
+	notifier *notifications.Notifier,
+) *TeamService {
+	return &TeamService{
+		teams:    teams,
+		sports:   sports,
+		users:    users,
+		notifier: notifier,
+	}
+}
+
+// requireCaptain fails unless userID captains team.
+func requireCaptain(team *models.Team, userID uuid.UUID) error {
+	if team.CaptainID != userID {
+		return apperrors.Forbidden("only the team captain can do this")
+	}
+	return nil
+}
+
+func isMember(team *models.Team, userID uuid.UUID) bool {
+	return slices.ContainsFunc(team.Members, func(m models.TeamMember) bool { return m.UserID == userID })
+}
+
+// CreateTeam creates a team for a sport with captainID as its captain and only member.
+func (s *TeamService) CreateTeam(ctx context.Context, captainID uuid.UUID, req types.CreateTeamRequest) (*models.Team, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperrors.InvalidField("name", "name is required")
+	}
+	sport, err := s.sports.GetSportsByID(ctx, req.SportID)
+	if err != nil {
+		return nil, err
+	}
+
+	team := &models.Team{Name: name, SportID: sport.ID, CaptainID: captainID}
+	if err := s.teams.Create(ctx, team); err != nil {
+		return nil, fmt.Errorf("failed to create team: %w", err)
+	}
+	return team, nil
+}
+
+// GetTeam returns a team with its members. Teams are visible to their members only.
+func (s *TeamService) GetTeam(ctx context.Context, id string, userID uuid.UUID) (*models.Team, error) {
+	team, err := s.teams.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember(team, userID) {
+		return nil, apperrors.NotFound("team")
+	}
+	return team, nil
+}
+
+// ListTeams returns the teams userID belongs to.
+func (s *TeamService) ListTeams(ctx context.Context, userID uuid.UUID) ([]models.Team, error) {
+	return s.teams.ListByMember(ctx, userID)
+}
+
+// DeleteTeam disbands a team. Only its captain may do this.
+func (s *TeamService) DeleteTeam(ctx context.Context, id string, userID uuid.UUID) error {
+	team, err := s.GetTeam(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if err := requireCaptain(team, userID); err != nil {
+		return err
+	}
+	return s.teams.Delete(ctx, team.ID)
+}
+
+// Invite invites someone to the team by email or phone and notifies them the same way.
+// Pending invitations count towards the sport's MaxPlayers so a team cannot be
+// over-invited.
+func (s *TeamService) Invite(ctx context.Context, teamID string, userID uuid.UUID, req types.InviteToTeamRequest) (*models.TeamInvitation, error) {
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	phone := strings.TrimSpace(req.Phone)
+	if (email == "") == (phone == "") {
+		return nil, apperrors.Validation("provide exactly one of email or phone",
+			apperrors.Field("email", "provide exactly one of email or phone"),
+			apperrors.Field("phone", "provide exactly one of email or phone"))
+	}
+
+	team, err := s.GetTeam(ctx, teamID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireCaptain(team, userID); err != nil {
+		return nil, err
+	}
+
+	if invitee, err := s.findUser(ctx, email, phone); err != nil {
+		return nil, err
+	} else if invitee != nil && isMember(team, invitee.ID) {
+		return nil, apperrors.Conflict("already a member of this team")
+	}
+
+	pending, err := s.teams.CountPendingInvitations(ctx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(team.Members)+int(pending) >= team.Sport.MaxPlayers {
+		return nil, apperrors.Conflict(fmt.Sprintf("team is full: %s allows at most %d players", team.Sport.Name, team.Sport.MaxPlayers))
+	}
+	duplicate, err := s.teams.HasPendingInvitation(ctx, team.ID, email, phone)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate {
+		return nil, apperrors.Conflict("an invitation is already pending")
+	}
+
+	invitation := &models.TeamInvitation{
+		TeamID:      team.ID,
+		InvitedByID: userID,
+		Email:       email,
+		Phone:       phone,
+		Status:      models.InvitationPending,
+		ExpiresAt:   time.Now().Add(invitationTTL),
+	}
+	if err := s.teams.CreateInvitation(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+	invitation.Team = *team
+
+	s.notifyInvitation(ctx, team, invitation)
+	return invitation, nil
+}
+
+// findUser looks up an account by email or phone, returning nil when there is none.
+func (s *TeamService) findUser(ctx context.Context, email, phone string) (*models.User, error) {
+	var user *models.User
+	var err error
+	if email != "" {
+		user, err = s.users.GetUserByEmail(ctx, email)
+	} else {
+		user, err = s.users.GetUserByPhone(ctx, phone)
+	}
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// notifyInvitation tells the invitee about the invitation. A failed send is logged
+// rather than failing the request: the invitation is still listed for the invitee.
+func (s *TeamService) notifyInvitation(ctx context.Context, team *models.Team, invitation *models.TeamInvitation) {
+	message := fmt.Sprintf("You have been invited to join the %s team %q. Sign in to the app to accept or decline before %s.",
+		team.Sport.Name, team.Name, invitation.ExpiresAt.Format("2 Jan 2006"))
+
+	var err error
+	if invitation.Email != "" {
+		err = s.notifier.SendEmail(ctx, invitation.Email, "You're invited to join "+team.Name, message)
+	} else {
+		err = s.notifier.SendSMS(ctx, invitation.Phone, message)
+	}
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to send team invitation",
+			slog.String("invitation_id", invitation.ID.String()), logging.Err(err))
+	}
+}
+
+// ListInvitations returns the pending invitations addressed to userID's email or phone.
+func (s *TeamService) ListInvitations(ctx context.Context, userID uuid.UUID) ([]models.TeamInvitation, error) {
+	user, err := s.users.GetUserByID(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	return s.teams.ListPendingInvitations(ctx, strings.ToLower(user.Email), user.Phone)
+}
+
+// invitationFor loads a pending invitation addressed to userID. Invitations addressed to
+// someone else are reported as not found.
+func (s *TeamService) invitationFor(ctx context.Context, id string, userID uuid.UUID) (*models.TeamInvitation, error) {
+	user, err := s.users.GetUserByID(ctx, userID.String())
+	if err != nil {
+		return nil, err
+	}
+	invitation, err := s.teams.GetInvitation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	addressed := (invitation.Email != "" && strings.EqualFold(invitation.Email, user.Email)) ||
+		(invitation.Phone != "" && invitation.Phone == user.Phone)
+	if !addressed {
+		return nil, apperrors.NotFound("invitation")
+	}
+	if invitation.Status != models.InvitationPending {
+		return nil, apperrors.Conflict("invitation has already been answered")
+	}
+	if time.Now().After(invitation.ExpiresAt) {
+		return nil, apperrors.Conflict("invitation has expired")
+	}
+	return invitation, nil
+}
+
+// AcceptInvitation joins userID to the invitation's team, provided the team still has
+// room under the sport's MaxPlayers.
+func (s *TeamService) AcceptInvitation(ctx context.Context, id string, userID uuid.UUID) (*models.Team, error) {
+	invitation, err := s.invitationFor(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.teams.AcceptInvitation(ctx, invitation, userID, invitation.Team.Sport.MaxPlayers); err != nil {
+		return nil, err
+	}
+	return s.teams.GetByID(ctx, invitation.TeamID.String())
+}
+
+func (s *TeamService) DeclineInvitation(ctx context.Context, id string, userID uuid.UUID) (*models.TeamInvitation, error) {
+	invitation, err := s.invitationFor(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.teams.DeclineInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// Leave removes userID from the team. A departing captain hands over to the
+// longest-standing member; the last member leaving disbands the team.
+func (s *TeamService) Leave(ctx context.Context, teamID string, userID uuid.UUID) error {
+	team, err := s.GetTeam(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+	if len(team.Members) == 1 {
+		return s.teams.Delete(ctx, team.ID)
+	}
+
+	var successor *uuid.UUID
+	if team.CaptainID == userID {
+		for _, m := range team.Members {
+			if m.UserID != userID {
+				successor = &m.UserID
+				break
+			}
+		}
+	}
+	return s.teams.RemoveMember(ctx, team.ID, userID, successor)
+}
+
+// RemoveMember kicks memberID from the team. Only the captain may do this, and the
+// captain leaves with Leave instead.
+func (s *TeamService) RemoveMember(ctx context.Context, teamID string, userID uuid.UUID, memberID string) error {
+	team, err := s.GetTeam(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+	if err := requireCaptain(team, userID); err != nil {
+		return err
+	}
+	member, err := uuid.Parse(memberID)
+	if err != nil {
+		return apperrors.InvalidField("userId", "userId must be a valid uuid")
+	}
+	if member == userID {
+		return apperrors.InvalidField("userId", "the captain cannot remove themselves; leave the team instead")
+	}
+	return s.teams.RemoveMember(ctx, team.ID, member, nil)
+}
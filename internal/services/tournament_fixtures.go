@@ -0,0 +1,174 @@
+package services
+
+import (
+	"math/bits"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+// scheduleHorizon is how far past a tournament's start fixtures may be scheduled.
+const scheduleHorizon = 30 * 24 * time.Hour
+
+// plannedFixture is a fixture before it has a field and kick-off time. A nil team
+// with no seed is filled by the winner of the feeding knockout fixture.
+type plannedFixture struct {
+	stage              string
+	round, group, slot int
+	home, away         *uuid.UUID
+	homeSeed, awaySeed int
+}
+
+// bracketOrder returns seeds 1..size in standard bracket order, so that adjacent pairs
+// meet in round one and the top seeds can only meet late.
+func bracketOrder(size int) []int {
+	order := []int{1}
+	for n := 2; n <= size; n *= 2 {
+		next := make([]int, 0, n)
+		for _, seed := range order {
+			next = append(next, seed, n+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// knockoutRoundCount is how many rounds a bracket for entrants teams has.
+func knockoutRoundCount(entrants int) int {
+	return bits.Len(uint(entrants - 1))
+}
+
+// knockoutRounds returns the rounds of a single-elimination bracket for entrants seeds.
+// When entrants is not a power of two the top seeds get byes and enter in round two.
+// Sides carry seeds; zero means the winner of the feeding fixture.
+func knockoutRounds(entrants int) [][]plannedFixture {
+	size := 1 << knockoutRoundCount(entrants)
+	order := bracketOrder(size)
+
+	rounds := [][]plannedFixture{}
+	var byes map[int][2]int // round-two slot -> seeds advancing on a bye
+	for round, slots := 1, size/2; slots >= 1; round, slots = round+1, slots/2 {
+		fixtures := []plannedFixture{}
+		nextByes := map[int][2]int{}
+		for slot := 0; slot < slots; slot++ {
+			f := plannedFixture{stage: models.StageKnockout, round: round, slot: slot}
+			if round == 1 {
+				f.homeSeed, f.awaySeed = order[2*slot], order[2*slot+1]
+				if f.awaySeed > entrants {
+					seeds := nextByes[slot/2]
+					seeds[slot%2] = f.homeSeed
+					nextByes[slot/2] = seeds
+					continue
+				}
+			} else if seeds, ok := byes[slot]; ok {
+				f.homeSeed, f.awaySeed = seeds[0], seeds[1]
+			}
+			fixtures = append(fixtures, f)
+		}
+		rounds = append(rounds, fixtures)
+		byes = nextByes
+	}
+	return rounds
+}
+
+// roundRobinRounds pairs every team with every other once using the circle method, so
+// no team plays twice in a round. With an odd number of teams one sits out each round.
+func roundRobinRounds(teams []uuid.UUID, stage string, group int) [][]plannedFixture {
+	circle := make([]*uuid.UUID, 0, len(teams)+1)
+	for i := range teams {
+		circle = append(circle, &teams[i])
+	}
+	if len(circle)%2 == 1 {
+		circle = append(circle, nil)
+	}
+
+	n := len(circle)
+	rounds := make([][]plannedFixture, 0, n-1)
+	for round := 1; round < n; round++ {
+		fixtures := []plannedFixture{}
+		for i := 0; i < n/2; i++ {
+			home, away := circle[i], circle[n-1-i]
+			if home == nil || away == nil {
+				continue
+			}
+			// The fixed team alternates home and away. The others rotate through the
+			// first half of the circle, at home, and the second, away, so they are at
+			// home about half the time as they stand.
+			if i == 0 && round%2 == 0 {
+				home, away = away, home
+			}
+			fixtures = append(fixtures, plannedFixture{stage: stage, round: round, group: group, slot: len(fixtures), home: home, away: away})
+		}
+		rounds = append(rounds, fixtures)
+		// Keep the first team fixed and rotate the rest one place.
+		last := circle[n-1]
+		copy(circle[2:], circle[1:n-1])
+		circle[1] = last
+	}
+	return rounds
+}
+
+// fieldSlot is one field held for one match.
+type fieldSlot struct {
+	field      int
+	start, end time.Time
+}
+
+// slotFinder hands out field slots within a turf's opening hours, around the bookings
+// already on its fields and its closures. Times are in the turf's time zone, the same
+// way booking times are compared with opening hours.
+type slotFinder struct {
+	turf         *models.Turf
+	match, pause time.Duration
+	busy         []models.Booking
+	closed       []models.TurfClosure
+	next, limit  time.Time
+}
+
+// withinHours returns the first time at or after t when a whole match fits inside the
+// turf's opening hours, or a time past the limit when none does before it.
+func (f *slotFinder) withinHours(t time.Time) time.Time {
+	for !t.After(f.limit) {
+		opensAt, closesAt, open := openingHours(f.turf, t.Weekday())
+		opens := time.Date(t.Year(), t.Month(), t.Day(), opensAt, 0, 0, 0, t.Location())
+		closes := time.Date(t.Year(), t.Month(), t.Day(), closesAt, 0, 0, 0, t.Location())
+		if open && t.Before(opens) {
+			t = opens
+		}
+		if open && !t.Add(f.match).After(closes) {
+			return t
+		}
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+	}
+	return t
+}
+
+func (f *slotFinder) free(field int, start, end time.Time) bool {
+	return !fieldTaken(f.busy, field, start, end) && !fieldClosed(f.closed, field, start, end)
+}
+
+// take holds n slots for one round, running matches on parallel fields, and moves the
+// next round past the last of them so every team rests between rounds.
+func (f *slotFinder) take(n int) ([]fieldSlot, error) {
+	slots := make([]fieldSlot, 0, n)
+	for t := f.next; len(slots) < n; t = t.Add(f.match + f.pause) {
+		t = f.withinHours(t)
+		if t.After(f.limit) {
+			return nil, apperrors.Conflict("not enough free field time to schedule every fixture within 30 days of the start")
+		}
+		for field := 1; field <= f.turf.NoOfFields && len(slots) < n; field++ {
+			end := t.Add(f.match)
+			if f.free(field, t, end) {
+				slots = append(slots, fieldSlot{field: field, start: t, end: end})
+			}
+		}
+	}
+	for _, s := range slots {
+		if next := s.end.Add(f.pause); next.After(f.next) {
+			f.next = next
+		}
+	}
+	return slots, nil
+}
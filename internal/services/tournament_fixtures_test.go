@@ -0,0 +1,198 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+)
+
+func TestKnockoutRounds(t *testing.T) {
+	for entrants := 2; entrants <= 16; entrants++ {
+		t.Run(fmt.Sprint(entrants), func(t *testing.T) {
+			rounds := knockoutRounds(entrants)
+			size := 1 << knockoutRoundCount(entrants)
+			if size < entrants || size/2 >= entrants {
+				t.Fatalf("bracket of %d for %d entrants", size, entrants)
+			}
+			if len(rounds) != knockoutRoundCount(entrants) {
+				t.Fatalf("%d rounds, want %d", len(rounds), knockoutRoundCount(entrants))
+			}
+
+			// Every match knocks one team out, and every round halves the field.
+			total := 0
+			for i, round := range rounds {
+				total += len(round)
+				if i > 0 && len(round) != size>>(i+1) {
+					t.Errorf("round %d has %d fixtures, want %d", i+1, len(round), size>>(i+1))
+				}
+			}
+			if total != entrants-1 {
+				t.Errorf("%d fixtures, want %d", total, entrants-1)
+			}
+
+			// Each seed enters exactly once: in round one, or in round two on a bye.
+			// The byes go to the top seeds.
+			byes := size - entrants
+			entered := map[int]int{}
+			for _, f := range rounds[0] {
+				entered[f.homeSeed]++
+				entered[f.awaySeed]++
+				if f.homeSeed <= byes || f.awaySeed <= byes {
+					t.Errorf("seed with a bye plays round one: %d v %d", f.homeSeed, f.awaySeed)
+				}
+			}
+			if len(rounds) > 1 {
+				for _, f := range rounds[1] {
+					for _, seed := range []int{f.homeSeed, f.awaySeed} {
+						if seed != 0 {
+							entered[seed]++
+						}
+					}
+				}
+			}
+			for seed := 1; seed <= entrants; seed++ {
+				if entered[seed] != 1 {
+					t.Errorf("seed %d enters %d times", seed, entered[seed])
+				}
+			}
+			if len(entered) != entrants {
+				t.Errorf("%d seeds entered, want %d", len(entered), entrants)
+			}
+			if final := rounds[len(rounds)-1]; len(final) != 1 {
+				t.Errorf("final round has %d fixtures", len(final))
+			}
+		})
+	}
+}
+
+func TestRoundRobinRounds(t *testing.T) {
+	for n := 2; n <= 16; n++ {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			teams := make([]uuid.UUID, n)
+			for i := range teams {
+				teams[i] = uuid.New()
+			}
+			rounds := roundRobinRounds(teams, models.StageLeague, 0)
+
+			wantRounds := n - 1
+			if n%2 == 1 {
+				wantRounds = n
+			}
+			if len(rounds) != wantRounds {
+				t.Fatalf("%d rounds, want %d", len(rounds), wantRounds)
+			}
+
+			met := map[[2]uuid.UUID]int{}
+			sitOut := map[uuid.UUID]int{}
+			home := map[uuid.UUID]int{}
+			for r, round := range rounds {
+				played := map[uuid.UUID]bool{}
+				for slot, f := range round {
+					if f.round != r+1 || f.slot != slot {
+						t.Errorf("fixture numbered round %d slot %d, want %d/%d", f.round, f.slot, r+1, slot)
+					}
+					for _, team := range []uuid.UUID{*f.home, *f.away} {
+						if played[team] {
+							t.Errorf("round %d: a team plays twice", r+1)
+						}
+						played[team] = true
+					}
+					pair := [2]uuid.UUID{*f.home, *f.away}
+					if pair[1].String() < pair[0].String() {
+						pair[0], pair[1] = pair[1], pair[0]
+					}
+					met[pair]++
+					home[*f.home]++
+				}
+				// Everyone plays each round, bar one team when the count is odd.
+				if len(played) != n-n%2 {
+					t.Errorf("round %d: %d teams play, want %d", r+1, len(played), n-n%2)
+				}
+				for _, team := range teams {
+					if !played[team] {
+						sitOut[team]++
+					}
+				}
+			}
+
+			if len(met) != n*(n-1)/2 {
+				t.Errorf("%d pairings, want %d", len(met), n*(n-1)/2)
+			}
+			for pair, times := range met {
+				if times != 1 {
+					t.Errorf("%v meet %d times", pair, times)
+				}
+			}
+			for _, team := range teams {
+				if want := n % 2; sitOut[team] != want {
+					t.Errorf("team sits out %d rounds, want %d", sitOut[team], want)
+				}
+				if games := n - 1; home[team] < games/2 || home[team] > (games+1)/2 {
+					t.Errorf("team is at home %d of %d games", home[team], games)
+				}
+			}
+		})
+	}
+}
+
+func TestSlotFinderTake(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC) }
+	one := 1
+	// 7 March 2026 is a Saturday. The turf opens 08:00 to 22:00, but not on
+	// Sundays and only 18:00 to 20:00 on Mondays.
+	turf := &models.Turf{
+		NoOfFields: 2,
+		StartTime:  8,
+		EndTime:    22,
+		Hours: []models.TurfHours{
+			{Weekday: int(time.Sunday), Closed: true},
+			{Weekday: int(time.Monday), OpensAt: 18, ClosesAt: 20},
+		},
+	}
+	newFinder := func(limit time.Time) *slotFinder {
+		return &slotFinder{
+			turf:   turf,
+			match:  time.Hour,
+			busy:   []models.Booking{{FieldNumber: 2, StartsAt: at(7, 21), EndsAt: at(7, 22)}},
+			closed: []models.TurfClosure{{FieldNumber: &one, StartsAt: at(7, 20), EndsAt: at(7, 21)}},
+			next:   at(7, 20),
+			limit:  limit,
+		}
+	}
+
+	finder := newFinder(at(7, 20).Add(scheduleHorizon))
+	tests := []struct {
+		n    int
+		want []fieldSlot
+	}{
+		// Field 1 is closed at 20:00 and field 2 booked at 21:00.
+		{2, []fieldSlot{{2, at(7, 20), at(7, 21)}, {1, at(7, 21), at(7, 22)}}},
+		// Saturday has closed and Sunday is shut, so Monday's short hours are next.
+		{2, []fieldSlot{{1, at(9, 18), at(9, 19)}, {2, at(9, 18), at(9, 19)}}},
+		// A match from 20:00 would overrun Monday, so the round carries into Tuesday.
+		{3, []fieldSlot{{1, at(9, 19), at(9, 20)}, {2, at(9, 19), at(9, 20)}, {1, at(10, 8), at(10, 9)}}},
+		// The next round waits for the last match of the one before.
+		{1, []fieldSlot{{1, at(10, 9), at(10, 10)}}},
+	}
+	for i, tt := range tests {
+		got, err := finder.take(tt.n)
+		if err != nil {
+			t.Fatalf("round %d: %v", i+1, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("round %d: got %v, want %v", i+1, got, tt.want)
+		}
+	}
+
+	// Rounds that do not fit before the limit are refused.
+	finder = newFinder(at(9, 0))
+	if _, err := finder.take(3); !errors.Is(err, apperrors.ErrConflict) {
+		t.Errorf("past the limit: err = %v, want conflict", err)
+	}
+}
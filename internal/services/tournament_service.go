@@ -0,0 +1,584 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/internal/apperrors"
+	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/repositories"
+	"github.com/musishere/sportsApp/types"
+)
+
+// League and group points for a win and a draw.
+const (
+	pointsForWin  = 3
+	pointsForDraw = 1
+)
+
+type TournamentService struct {
+	tournaments *repositories.TournamentRepository
+	turfs       *repositories.TurfRepostitory
+	sports      *repositories.SportsRepository
+	teams       *repositories.TeamRepository
+	bookings    *repositories.BookingRepository
+	calendar    *repositories.CalendarRepository
+}
+
+func NewTournamentService(
+	tournaments *repositories.TournamentRepository,
+	turfs *repositories.TurfRepostitory,
+	sports *repositories.SportsRepository,
+	teams *repositories.TeamRepository,
+	bookings *repositories.BookingRepository,
+	calendar *repositories.CalendarRepository,
+) *TournamentService {
+	return &TournamentService{
+		tournaments: tournaments,
+		turfs:       turfs,
+		sports:      sports,
+		teams:       teams,
+		bookings:    bookings,
+		calendar:    calendar,
+	}
+}
+
+// Standing is a team's record in a league or group table.
+type Standing struct {
+	Team         models.Team
+	Seed         int
+	Played       int
+	Won          int
+	Drawn        int
+	Lost         int
+	GoalsFor     int
+	GoalsAgainst int
+	Points       int
+}
+
+// GroupTable ranks the teams of one group; a round robin has a single table, group 0.
+type GroupTable struct {
+	Group int
+	Rows  []Standing
+}
+
+func requireOrganizer(tournament *models.Tournament, userID uuid.UUID) error {
+	if tournament.OrganizerID != userID {
+		return apperrors.Forbidden("only the tournament organiser can do this")
+	}
+	return nil
+}
+
+// CreateTournament opens registration for a tournament on a turf the caller owns.
+func (s *TournamentService) CreateTournament(ctx context.Context, userID uuid.UUID, req types.CreateTournamentRequest) (*models.Tournament, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, apperrors.InvalidField("name", "name is required")
+	}
+	if !req.StartsAt.After(time.Now()) {
+		return nil, apperrors.InvalidField("startsAt", "startsAt must be in the future")
+	}
+
+	turf, err := s.turfs.GetTurfByID(ctx, req.TurfID)
+	if err != nil {
+		return nil, err
+	}
+	if turf.OwnerID != userID {
+		return nil, apperrors.Forbidden("only the turf owner can run tournaments on it")
+	}
+	if !strings.EqualFold(turf.Status, "active") {
+		return nil, apperrors.Conflict("turf is not accepting bookings")
+	}
+	// A match must fit on every open day, or some days could never host one.
+	day, opensAt, closesAt, open := shortestOpenDay(turf)
+	if !open {
+		return nil, apperrors.Conflict("turf is closed every day of the week")
+	}
+	if req.MatchMinutes > (closesAt-opensAt)*60 {
+		return nil, apperrors.InvalidField("matchMinutes",
+			fmt.Sprintf("a match must fit within the turf's shortest opening hours, %s %02d:00 to %02d:00", day, opensAt, closesAt))
+	}
+	sport, err := s.sports.GetSportsByID(ctx, req.SportID)
+	if err != nil {
+		return nil, err
+	}
+
+	tournament := &models.Tournament{
+		Name:         name,
+		SportID:      sport.ID,
+		TurfID:       turf.ID,
+		OrganizerID:  userID,
+		Format:       req.Format,
+		Status:       models.TournamentRegistration,
+		StartsAt:     req.StartsAt,
+		MatchMinutes: req.MatchMinutes,
+		BreakMinutes: req.BreakMinutes,
+		MaxTeams:     req.MaxTeams,
+	}
+	if req.Format == models.FormatGroupKnockout {
+		if req.GroupCount == 0 || req.AdvancePerGroup == 0 {
+			return nil, apperrors.Validation("groupCount and advancePerGroup are required for group_knockout",
+				apperrors.Field("groupCount", "groupCount is required"),
+				apperrors.Field("advancePerGroup", "advancePerGroup is required"))
+		}
+		if req.MaxTeams < req.GroupCount*(req.AdvancePerGroup+1) {
+			return nil, apperrors.InvalidField("maxTeams",
+				fmt.Sprintf("maxTeams must be at least %d so every group has more teams than advance", req.GroupCount*(req.AdvancePerGroup+1)))
+		}
+		tournament.GroupCount = req.GroupCount
+		tournament.AdvancePerGroup = req.AdvancePerGroup
+	}
+
+	if err := s.tournaments.Create(ctx, tournament); err != nil {
+		return nil, fmt.Errorf("failed to create tournament: %w", err)
+	}
+	return s.tournaments.GetByID(ctx, tournament.ID.String())
+}
+
+func (s *TournamentService) GetTournament(ctx context.Context, id string) (*models.Tournament, error) {
+	return s.tournaments.GetByID(ctx, id)
+}
+
+func (s *TournamentService) ListTournaments(ctx context.Context, q types.TournamentListQuery, page, pageSize int) ([]models.Tournament, int64, error) {
+	filter := repositories.TournamentFilter{Status: q.Status}
+	if q.SportID != "" {
+		id, err := uuid.Parse(q.SportID)
+		if err != nil {
+			return nil, 0, apperrors.InvalidField("sportId", "sportId must be a valid uuid")
+		}
+		filter.SportID = &id
+	}
+	if q.TurfID != "" {
+		id, err := uuid.Parse(q.TurfID)
+		if err != nil {
+			return nil, 0, apperrors.InvalidField("turfId", "turfId must be a valid uuid")
+		}
+		filter.TurfID = &id
+	}
+	return s.tournaments.List(ctx, filter, page, pageSize)
+}
+
+// RegisterTeam enters a team the caller captains. The team must play the tournament's
+// sport and have enough players for it.
+func (s *TournamentService) RegisterTeam(ctx context.Context, id string, userID uuid.UUID, req types.RegisterTournamentTeamRequest) (*models.Tournament, error) {
+	tournament, err := s.tournaments.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	team, err := s.teams.GetByID(ctx, req.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireCaptain(team, userID); err != nil {
+		return nil, err
+	}
+	if team.SportID != tournament.SportID {
+		return nil, apperrors.InvalidField("teamId", fmt.Sprintf("this tournament is for %s teams", tournament.Sport.Name))
+	}
+	if len(team.Members) < team.Sport.MinPlayers {
+		return nil, apperrors.InvalidField("teamId",
+			fmt.Sprintf("%s needs at least %d players; the team has %d", team.Sport.Name, team.Sport.MinPlayers, len(team.Members)))
+	}
+
+	if err := s.tournaments.RegisterTeam(ctx, tournament.ID, team.ID); err != nil {
+		return nil, err
+	}
+	return s.tournaments.GetByID(ctx, id)
+}
+
+// WithdrawTeam removes a team before scheduling. Its captain or the organiser may do this.
+func (s *TournamentService) WithdrawTeam(ctx context.Context, id, teamID string, userID uuid.UUID) error {
+	tournament, err := s.tournaments.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	team, err := s.teams.GetByID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if team.CaptainID != userID && tournament.OrganizerID != userID {
+		return apperrors.Forbidden("only the team captain or the organiser can withdraw a team")
+	}
+	return s.tournaments.WithdrawTeam(ctx, tournament.ID, team.ID)
+}
+
+// Schedule closes registration and generates every fixture, booking the turf's fields
+// for each one. Rounds are played one after another; within a round matches run in
+// parallel on as many free fields as the turf has.
+func (s *TournamentService) Schedule(ctx context.Context, id string, userID uuid.UUID) (*models.Tournament, error) {
+	tournament, err := s.tournaments.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOrganizer(tournament, userID); err != nil {
+		return nil, err
+	}
+	if tournament.Status != models.TournamentRegistration {
+		return nil, apperrors.Conflict("the tournament is already scheduled")
+	}
+	if len(tournament.Teams) < 2 {
+		return nil, apperrors.Conflict("at least two teams must register before scheduling")
+	}
+
+	rounds, groups, err := planFixtures(tournament)
+	if err != nil {
+		return nil, err
+	}
+
+	start := tournament.StartsAt.In(tournament.Turf.Location())
+	limit := start.Add(scheduleHorizon)
+	busy, err := s.bookings.ListHolding(ctx, tournament.TurfID, start, limit)
+	if err != nil {
+		return nil, err
+	}
+	closed, err := s.calendar.ListClosures(ctx, tournament.TurfID, start, limit, "")
+	if err != nil {
+		return nil, err
+	}
+	finder := &slotFinder{
+		turf:   &tournament.Turf,
+		match:  time.Duration(tournament.MatchMinutes) * time.Minute,
+		pause:  time.Duration(tournament.BreakMinutes) * time.Minute,
+		busy:   busy,
+		closed: closed,
+		next:   start,
+		limit:  limit,
+	}
+
+	var fixtures []models.Fixture
+	var bookings []models.Booking
+	for _, round := range rounds {
+		slots, err := finder.take(len(round))
+		if err != nil {
+			return nil, err
+		}
+		for i, planned := range round {
+			slot := slots[i]
+			fixtures = append(fixtures, models.Fixture{
+				TournamentID: tournament.ID,
+				Stage:        planned.stage,
+				Round:        planned.round,
+				GroupNumber:  planned.group,
+				Slot:         planned.slot,
+				HomeTeamID:   planned.home,
+				AwayTeamID:   planned.away,
+				HomeSeed:     planned.homeSeed,
+				AwaySeed:     planned.awaySeed,
+				FieldNumber:  slot.field,
+				StartsAt:     slot.start,
+				EndsAt:       slot.end,
+				Status:       models.FixtureScheduled,
+			})
+			bookings = append(bookings, models.Booking{
+				TurfID:      tournament.TurfID,
+				FieldNumber: slot.field,
+				StartsAt:    slot.start,
+				EndsAt:      slot.end,
+				BookedByID:  tournament.OrganizerID,
+				Status:      models.BookingConfirmed,
+			})
+		}
+	}
+
+	if err := s.tournaments.Schedule(ctx, tournament, groups, fixtures, bookings); err != nil {
+		return nil, err
+	}
+	return s.tournaments.GetByID(ctx, id)
+}
+
+// planFixtures lays out the tournament's rounds in playing order and, for a
+// group+knockout tournament, which group each team plays in.
+func planFixtures(tournament *models.Tournament) ([][]plannedFixture, map[uuid.UUID]int, error) {
+	teamIDs := make([]uuid.UUID, len(tournament.Teams))
+	for i, entry := range tournament.Teams {
+		teamIDs[i] = entry.TeamID
+	}
+
+	switch tournament.Format {
+	case models.FormatRoundRobin:
+		return roundRobinRounds(teamIDs, models.StageLeague, 0), nil, nil
+
+	case models.FormatKnockout:
+		rounds := knockoutRounds(len(teamIDs))
+		for _, round := range rounds {
+			for i := range round {
+				f := &round[i]
+				if f.homeSeed > 0 {
+					f.home, f.homeSeed = &teamIDs[f.homeSeed-1], 0
+				}
+				if f.awaySeed > 0 {
+					f.away, f.awaySeed = &teamIDs[f.awaySeed-1], 0
+				}
+			}
+		}
+		return rounds, nil, nil
+
+	case models.FormatGroupKnockout:
+		count := tournament.GroupCount
+		if len(teamIDs) < count*(tournament.AdvancePerGroup+1) {
+			return nil, nil, apperrors.Conflict(fmt.Sprintf("at least %d teams must register so every group has more teams than advance",
+				count*(tournament.AdvancePerGroup+1)))
+		}
+		// Snake the seeds across the groups so each gets a similar spread of strength.
+		members := make([][]uuid.UUID, count)
+		groups := make(map[uuid.UUID]int, len(teamIDs))
+		for i, id := range teamIDs {
+			g := i % count
+			if (i/count)%2 == 1 {
+				g = count - 1 - g
+			}
+			members[g] = append(members[g], id)
+			groups[id] = g + 1
+		}
+
+		// Round r of every group is played together.
+		var rounds [][]plannedFixture
+		for g, ids := range members {
+			for r, round := range roundRobinRounds(ids, models.StageGroup, g+1) {
+				if r == len(rounds) {
+					rounds = append(rounds, nil)
+				}
+				rounds[r] = append(rounds[r], round...)
+			}
+		}
+		return append(rounds, knockoutRounds(count*tournament.AdvancePerGroup)...), groups, nil
+	}
+	return nil, nil, fmt.Errorf("unknown tournament format %q", tournament.Format)
+}
+
+// RecordResult enters a fixture's final score and moves the tournament on: knockout
+// winners advance, a finished group stage fills the bracket, and the last result
+// crowns the champion.
+func (s *TournamentService) RecordResult(ctx context.Context, id, fixtureID string, userID uuid.UUID, req types.FixtureResultRequest) (*models.Fixture, error) {
+	tournament, err := s.tournaments.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOrganizer(tournament, userID); err != nil {
+		return nil, err
+	}
+	fixture, err := s.tournaments.GetFixture(ctx, tournament.ID, fixtureID)
+	if err != nil {
+		return nil, err
+	}
+	if fixture.HomeTeamID == nil || fixture.AwayTeamID == nil {
+		return nil, apperrors.Conflict("the fixture is still waiting for its teams")
+	}
+
+	home, away := *req.HomeScore, *req.AwayScore
+	var winner *uuid.UUID
+	switch {
+	case home > away:
+		winner = fixture.HomeTeamID
+	case away > home:
+		winner = fixture.AwayTeamID
+	case fixture.Stage == models.StageKnockout:
+		if req.WinnerTeamID == nil {
+			return nil, apperrors.InvalidField("winnerTeamId", "a drawn knockout fixture needs a winnerTeamId")
+		}
+		for _, side := range []*uuid.UUID{fixture.HomeTeamID, fixture.AwayTeamID} {
+			if side.String() == *req.WinnerTeamID {
+				winner = side
+			}
+		}
+		if winner == nil {
+			return nil, apperrors.InvalidField("winnerTeamId", "winnerTeamId must be one of the two teams")
+		}
+	}
+	if req.WinnerTeamID != nil && (winner == nil || winner.String() != *req.WinnerTeamID) {
+		return nil, apperrors.InvalidField("winnerTeamId", "winnerTeamId does not match the score")
+	}
+
+	if err := s.tournaments.RecordResult(ctx, fixture, home, away, winner); err != nil {
+		return nil, err
+	}
+	if err := s.advance(ctx, tournament, fixture); err != nil {
+		return nil, err
+	}
+	return fixture, nil
+}
+
+// advance acts on a stage that the given result may have finished.
+func (s *TournamentService) advance(ctx context.Context, tournament *models.Tournament, fixture *models.Fixture) error {
+	if fixture.Stage == models.StageKnockout {
+		entrants := len(tournament.Teams)
+		if tournament.Format == models.FormatGroupKnockout {
+			entrants = tournament.GroupCount * tournament.AdvancePerGroup
+		}
+		if fixture.Round == knockoutRoundCount(entrants) {
+			return s.tournaments.Complete(ctx, tournament.ID, *fixture.WinnerID)
+		}
+		return nil
+	}
+
+	unplayed, err := s.tournaments.CountUnplayed(ctx, tournament.ID, fixture.Stage)
+	if err != nil || unplayed > 0 {
+		return err
+	}
+	fixtures, err := s.tournaments.ListFixtures(ctx, tournament.ID)
+	if err != nil {
+		return err
+	}
+	// Group numbers were assigned at scheduling, after tournament was first loaded.
+	if tournament, err = s.tournaments.GetByID(ctx, tournament.ID.String()); err != nil {
+		return err
+	}
+	tables := standings(tournament, fixtures)
+
+	if fixture.Stage == models.StageLeague {
+		return s.tournaments.Complete(ctx, tournament.ID, tables[0].Rows[0].Team.ID)
+	}
+	// Seed the group winners first, then the runners-up and so on.
+	qualifiers := make(map[int]uuid.UUID)
+	for place := 0; place < tournament.AdvancePerGroup; place++ {
+		for g, table := range tables {
+			qualifiers[place*len(tables)+g+1] = table.Rows[place].Team.ID
+		}
+	}
+	return s.tournaments.SeedKnockout(ctx, tournament.ID, qualifiers)
+}
+
+// Cancel calls the tournament off and frees the fields of its unplayed fixtures.
+func (s *TournamentService) Cancel(ctx context.Context, id string, userID uuid.UUID) (*models.Tournament, error) {
+	tournament, err := s.tournaments.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireOrganizer(tournament, userID); err != nil {
+		return nil, err
+	}
+	if err := s.tournaments.Cancel(ctx, tournament); err != nil {
+		return nil, err
+	}
+	return tournament, nil
+}
+
+func (s *TournamentService) ListFixtures(ctx context.Context, id string) ([]models.Fixture, error) {
+	tournament, err := s.tournaments.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.tournaments.ListFixtures(ctx, tournament.ID)
+}
+
+// Standings returns the league table, or one table per group. Knockout tournaments
+// have none.
+func (s *TournamentService) Standings(ctx context.Context, id string) ([]GroupTable, error) {
+	tournament, err := s.tournaments.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	fixtures, err := s.tournaments.ListFixtures(ctx, tournament.ID)
+	if err != nil {
+		return nil, err
+	}
+	return standings(tournament, fixtures), nil
+}
+
+// Bracket returns the knockout fixtures in bracket order and how many knockout rounds
+// there are.
+func (s *TournamentService) Bracket(ctx context.Context, id string) ([]models.Fixture, int, error) {
+	fixtures, err := s.ListFixtures(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	knockout := []models.Fixture{}
+	rounds := 0
+	for _, f := range fixtures {
+		if f.Stage == models.StageKnockout {
+			knockout = append(knockout, f)
+			rounds = max(rounds, f.Round)
+		}
+	}
+	sort.Slice(knockout, func(i, j int) bool {
+		if knockout[i].Round != knockout[j].Round {
+			return knockout[i].Round < knockout[j].Round
+		}
+		return knockout[i].Slot < knockout[j].Slot
+	})
+	return knockout, rounds, nil
+}
+
+// standings tallies completed league or group fixtures into ranked tables: points,
+// then goal difference, then goals scored, then seed.
+func standings(tournament *models.Tournament, fixtures []models.Fixture) []GroupTable {
+	var groupCount int
+	switch tournament.Format {
+	case models.FormatRoundRobin:
+		groupCount = 1
+	case models.FormatGroupKnockout:
+		groupCount = tournament.GroupCount
+	default:
+		return []GroupTable{}
+	}
+
+	tables := make([]GroupTable, groupCount)
+	rows := make(map[uuid.UUID]*Standing, len(tournament.Teams))
+	for _, entry := range tournament.Teams {
+		g := 0
+		if tournament.Format == models.FormatGroupKnockout {
+			if entry.GroupNumber == 0 {
+				continue // groups are drawn at scheduling
+			}
+			g = entry.GroupNumber - 1
+		}
+		tables[g].Rows = append(tables[g].Rows, Standing{Team: entry.Team, Seed: entry.Seed})
+	}
+	for g := range tables {
+		if tournament.Format == models.FormatGroupKnockout {
+			tables[g].Group = g + 1
+		}
+		for i := range tables[g].Rows {
+			rows[tables[g].Rows[i].Team.ID] = &tables[g].Rows[i]
+		}
+	}
+
+	for _, f := range fixtures {
+		if f.Stage == models.StageKnockout || f.Status != models.FixtureCompleted {
+			continue
+		}
+		home, away := rows[*f.HomeTeamID], rows[*f.AwayTeamID]
+		if home == nil || away == nil {
+			continue
+		}
+		home.tally(*f.HomeScore, *f.AwayScore)
+		away.tally(*f.AwayScore, *f.HomeScore)
+	}
+
+	for _, table := range tables {
+		sort.SliceStable(table.Rows, func(i, j int) bool {
+			a, b := table.Rows[i], table.Rows[j]
+			if a.Points != b.Points {
+				return a.Points > b.Points
+			}
+			if gdA, gdB := a.GoalsFor-a.GoalsAgainst, b.GoalsFor-b.GoalsAgainst; gdA != gdB {
+				return gdA > gdB
+			}
+			if a.GoalsFor != b.GoalsFor {
+				return a.GoalsFor > b.GoalsFor
+			}
+			return a.Seed < b.Seed
+		})
+	}
+	return tables
+}
+
+func (st *Standing) tally(scored, conceded int) {
+	st.Played++
+	st.GoalsFor += scored
+	st.GoalsAgainst += conceded
+	switch {
+	case scored > conceded:
+		st.Won++
+		st.Points += pointsForWin
+	case scored == conceded:
+		st.Drawn++
+		st.Points += pointsForDraw
+	default:
+		st.Lost++
+	}
+}
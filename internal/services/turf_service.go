@@ -4,36 +4,82 @@ import (
 	"context"
 	"fmt"
 	"strconv"
-	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/apperrors"
 	"github.com/musishere/sportsApp/internal/helpers"
 	"github.com/musishere/sportsApp/internal/models"
 	"github.com/musishere/sportsApp/internal/repositories"
 	"github.com/musishere/sportsApp/internal/validators"
 	"github.com/musishere/sportsApp/types"
+	"golang.org/x/sync/errgroup"
 )
 
 type TurfService struct {
 	repo     *repositories.TurfRepostitory
 	uploader *helpers.ImageUploader
+	geocoder *helpers.Geocoder
+	cfg      config.TurfsConfig
 }
 
-func NewTurfService(repo *repositories.TurfRepostitory, uploader *helpers.ImageUploader) *TurfService {
+func NewTurfService(repo *repositories.TurfRepostitory, uploader *helpers.ImageUploader, geocoder *helpers.Geocoder, cfg config.TurfsConfig) *TurfService {
 	return &TurfService{
 		repo:     repo,
 		uploader: uploader,
+		geocoder: geocoder,
+		cfg:      cfg,
 	}
 }
 
+// resolveCoordinates sets the turf location from a manual pin-drop when latitude/longitude
+// are supplied, and falls back to geocoding the turf address via LocationIQ otherwise.
+// A pin clears the geocoded city, state and postcode, which may no longer match it.
+func (s *TurfService) resolveCoordinates(ctx context.Context, turf *models.Turf, latitude, longitude *float64) error {
+	if latitude != nil && longitude != nil {
+		turf.Latitude = *latitude
+		turf.Longitude = *longitude
+		turf.CoordinateSource = models.CoordinateSourceManual
+		turf.City, turf.State, turf.Postcode = "", "", ""
+		return nil
+	}
+
+	// Resolve address to lat/lng via LocationIQ Geocoding API
+	locationResponse, err := s.geocoder.GeocodeAddress(ctx, turf.Address)
+	if err != nil {
+		return fmt.Errorf("geocoding address: %w", err)
+	}
+
+	// Convert string coordinates to float64
+	lat, err := strconv.ParseFloat(locationResponse.Lat, 64)
+	if err != nil {
+		return fmt.Errorf("invalid latitude: %w", err)
+	}
+	lng, err := strconv.ParseFloat(locationResponse.Lon, 64)
+	if err != nil {
+		return fmt.Errorf("invalid longitude: %w", err)
+	}
+
+	turf.Latitude = lat
+	turf.Longitude = lng
+	turf.CoordinateSource = models.CoordinateSourceGeocoded
+	turf.City, turf.State, turf.Postcode = locationResponse.AddressParts()
+	return nil
+}
+
 // CreateTurf creates a turf with 3 required images (uploaded to Cloudinary).
+// latitude/longitude are optional; when both are nil the address is geocoded. An empty
+// timeZone takes the configured default.
 func (s *TurfService) CreateTurf(
+	ctx context.Context,
 	name string,
 	startTime, endTime int,
 	status string,
 	noOfFields int,
 	address string,
+	latitude, longitude *float64,
+	timeZone string,
 	ownerID uuid.UUID,
 	img1, img2, img3 []byte,
 	filename1, filename2, filename3 string,
@@ -45,46 +91,14 @@ func (s *TurfService) CreateTurf(
 		return nil, err
 	}
 
-	// Resolve address to lat/lng via LocationIQ Geocoding API
-	locationResponse, err := helpers.GeocodeAddress(address)
-	if err != nil {
-		return nil, fmt.Errorf("geocoding address: %w", err)
-	}
-
-	// Convert string coordinates to float64
-	lat, err := strconv.ParseFloat(locationResponse.Lat, 64)
-	if err != nil {
-		return nil, fmt.Errorf("invalid latitude: %w", err)
-	}
-	lng, err := strconv.ParseFloat(locationResponse.Lon, 64)
-	if err != nil {
-		return nil, fmt.Errorf("invalid longitude: %w", err)
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
-
-	upload := func(data []byte, filename string) (string, error) {
-		return s.uploader.UploadFromBytes(ctx, data, filename, "turfs")
-	}
-
-	var url1, url2, url3 string
-	var err1, err2, err3 error
-	var wg sync.WaitGroup
-	wg.Add(3)
-	go func() { defer wg.Done(); url1, err1 = upload(img1, filename1) }()
-	go func() { defer wg.Done(); url2, err2 = upload(img2, filename2) }()
-	go func() { defer wg.Done(); url3, err3 = upload(img3, filename3) }()
-	wg.Wait()
-
-	if err1 != nil {
-		return nil, fmt.Errorf("upload image 1: %w", err1)
+	if err := validators.ValidateTurfCoordinates(latitude, longitude); err != nil {
+		return nil, err
 	}
-	if err2 != nil {
-		return nil, fmt.Errorf("upload image 2: %w", err2)
+	if timeZone == "" {
+		timeZone = s.cfg.DefaultTimeZone
 	}
-	if err3 != nil {
-		return nil, fmt.Errorf("upload image 3: %w", err3)
+	if err := validators.ValidateTimeZone(timeZone); err != nil {
+		return nil, err
 	}
 
 	turf := &models.Turf{
@@ -94,33 +108,73 @@ func (s *TurfService) CreateTurf(
 		Status:     status,
 		NoOfFields: noOfFields,
 		Address:    address,
-		Latitude:   lat,
-		Longitude:  lng,
-		TurfImages: []string{url1, url2, url3},
+		TimeZone:   timeZone,
 		OwnerID:    ownerID,
 	}
-	if err := s.repo.Create(turf); err != nil {
+	if err := s.resolveCoordinates(ctx, turf, latitude, longitude); err != nil {
+		return nil, err
+	}
+
+	// Upload the three images in parallel; the first failure cancels the others.
+	images := []struct {
+		data     []byte
+		filename string
+	}{{img1, filename1}, {img2, filename2}, {img3, filename3}}
+	urls := make([]string, len(images))
+
+	group, uploadCtx := errgroup.WithContext(ctx)
+	for i, img := range images {
+		group.Go(func() error {
+			url, err := s.uploader.UploadFromBytes(uploadCtx, img.data, img.filename, "turfs")
+			if err != nil {
+				return fmt.Errorf("upload image %d: %w", i+1, err)
+			}
+			urls[i] = url
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	turf.TurfImages = urls
+	if err := s.repo.Create(ctx, turf); err != nil {
 		return nil, fmt.Errorf("failed to create turf: %w", err)
 	}
 	return turf, nil
 }
 
-func (r *TurfService) GetTurfByID(id string) (*models.Turf, error) {
-	return r.repo.GetTurfByID(id)
+func (r *TurfService) GetTurfByID(ctx context.Context, id string) (*models.Turf, error) {
+	return r.repo.GetTurfByID(ctx, id)
 }
 
-func (r *TurfService) GetAllTurf(page, pageSize int) ([]models.Turf, int64, error) {
-	turfs, total, err := r.repo.GetAllTurfsRepo(page, pageSize)
+// GetAllTurf returns a page of turfs in sort order, one of the types.TurfSort values or
+// "" for none.
+func (r *TurfService) GetAllTurf(ctx context.Context, page, pageSize int, sort string) ([]models.Turf, int64, error) {
+	turfs, total, err := r.repo.GetAllTurfsRepo(ctx, page, pageSize, sort)
 	if err != nil {
 		return nil, 0, err
 	}
 	return turfs, total, nil
 }
-func (r *TurfService) UpdateTurf(id string, req types.UpdateTurfRequest) (*models.Turf, error) {
-	turf, err := r.repo.GetTurfByID(id)
+func (r *TurfService) UpdateTurf(ctx context.Context, id string, req types.UpdateTurfRequest) (*models.Turf, error) {
+	turf, err := r.repo.GetTurfByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	return r.applyUpdate(ctx, turf, req)
+}
+
+// UpdateOwnedTurf changes the fields req sets on one of userID's turfs.
+func (r *TurfService) UpdateOwnedTurf(ctx context.Context, id string, userID uuid.UUID, req types.UpdateTurfRequest) (*models.Turf, error) {
+	turf, err := r.ownedTurf(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.applyUpdate(ctx, turf, req)
+}
+
+func (r *TurfService) applyUpdate(ctx context.Context, turf *models.Turf, req types.UpdateTurfRequest) (*models.Turf, error) {
 	if req.Name != nil {
 		turf.Name = *req.Name
 	}
@@ -138,35 +192,62 @@ func (r *TurfService) UpdateTurf(id string, req types.UpdateTurfRequest) (*model
 	}
 	if req.Address != nil {
 		turf.Address = *req.Address
-		// update lat/lng when address changes
-		locationResponse, gerr := helpers.GeocodeAddress(turf.Address)
-		if gerr != nil {
-			return nil, fmt.Errorf("geocoding address: %w", gerr)
-		}
-		lat, err := strconv.ParseFloat(locationResponse.Lat, 64)
-		if err != nil {
-			return nil, fmt.Errorf("invalid latitude: %w", err)
-		}
-		lng, err := strconv.ParseFloat(locationResponse.Lon, 64)
-		if err != nil {
-			return nil, fmt.Errorf("invalid longitude: %w", err)
+	}
+	if req.TimeZone != nil {
+		if err := validators.ValidateTimeZone(*req.TimeZone); err != nil {
+			return nil, err
 		}
-		turf.Latitude = lat
-		turf.Longitude = lng
+		turf.TimeZone = *req.TimeZone
 	}
 
 	if err := validators.ValidateTurfInput(turf.Name, turf.StartTime, turf.EndTime, turf.Status, turf.NoOfFields, turf.Address); err != nil {
 		return nil, err
 	}
+	if err := validators.ValidateTurfCoordinates(req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
+	if req.Geocode && req.Latitude != nil {
+		return nil, apperrors.InvalidField("geocode", "geocode cannot be combined with latitude and longitude")
+	}
+
+	// A new pin always wins, and an existing one is kept when only the address changes
+	// unless geocode asks to drop it; a geocoded turf is re-geocoded when its address
+	// changes.
+	pinned := turf.CoordinateSource == models.CoordinateSourceManual
+	if req.Latitude != nil || req.Geocode || (req.Address != nil && !pinned) {
+		if err := r.resolveCoordinates(ctx, turf, req.Latitude, req.Longitude); err != nil {
+			return nil, err
+		}
+	}
 
 	turf.UpdatedAt = time.Now()
 
-	if err := r.repo.UpdateTurf(turf); err != nil {
+	if err := r.repo.UpdateTurf(ctx, turf); err != nil {
 		return nil, fmt.Errorf("failed to update turf: %w", err)
 	}
 	return turf, nil
 }
 
-func (r *TurfService) DeleteTurf(id string) error {
-	return r.repo.DeleteTurf(id)
+func (r *TurfService) DeleteTurf(ctx context.Context, id string) error {
+	return r.repo.DeleteTurf(ctx, id)
+}
+
+// DeleteOwnedTurf deletes one of userID's turfs.
+func (r *TurfService) DeleteOwnedTurf(ctx context.Context, id string, userID uuid.UUID) error {
+	turf, err := r.ownedTurf(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	return r.repo.DeleteTurf(ctx, turf.ID.String())
+}
+
+func (r *TurfService) ownedTurf(ctx context.Context, id string, userID uuid.UUID) (*models.Turf, error) {
+	turf, err := r.repo.GetTurfByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if turf.OwnerID != userID {
+		return nil, apperrors.Forbidden("only the turf owner can change it")
+	}
+	return turf, nil
 }
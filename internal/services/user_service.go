@@ -1,48 +1,92 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/apperrors"
 	"github.com/musishere/sportsApp/internal/auth"
+	"github.com/musishere/sportsApp/internal/helpers"
+	"github.com/musishere/sportsApp/internal/logging"
 	"github.com/musishere/sportsApp/internal/models"
+	"github.com/musishere/sportsApp/internal/notifications"
 	"github.com/musishere/sportsApp/internal/repositories"
 	"github.com/musishere/sportsApp/internal/validators"
 	"github.com/musishere/sportsApp/types"
 )
 
 type UserService struct {
-	userRepo     *repositories.UserRepository
-	locationRepo *repositories.LocationRepository
-	jwtSecret    string
+	userRepo      *repositories.UserRepository
+	locationRepo  *repositories.LocationRepository
+	loginRepo     *repositories.LoginHistoryRepository
+	notifier      *notifications.Notifier
+	mailer        *helpers.Mailer
+	otpStore      *helpers.OTPStore
+	loginFailures *helpers.LoginFailures
+	jwtSecret     string
+	jwtTTL        time.Duration
 }
 
 func NewUserService(
 	userRepo *repositories.UserRepository,
 	locationRepo *repositories.LocationRepository,
-	jwtSecret string,
+	loginRepo *repositories.LoginHistoryRepository,
+	notifier *notifications.Notifier,
+	mailer *helpers.Mailer,
+	otpStore *helpers.OTPStore,
+	loginFailures *helpers.LoginFailures,
+	authCfg config.AuthConfig,
 ) *UserService {
 	return &UserService{
-		userRepo:     userRepo,
-		locationRepo: locationRepo,
-		jwtSecret:    jwtSecret,
+		userRepo:      userRepo,
+		locationRepo:  locationRepo,
+		loginRepo:     loginRepo,
+		notifier:      notifier,
+		mailer:        mailer,
+		otpStore:      otpStore,
+		loginFailures: loginFailures,
+		jwtSecret:     authCfg.JWTSecret,
+		jwtTTL:        authCfg.JWTTTL,
 	}
 }
 
-func (s *UserService) Register(req types.RegisterRequest) (*models.User, string, error) {
+// IssueEmailOTP generates an OTP, stores it against the email and sends it by email.
+func (s *UserService) IssueEmailOTP(ctx context.Context, email string) error {
+	otpStr := strconv.Itoa(helpers.GenerateOTP())
+
+	// Store OTP temporarily (using email as identifier for email verification)
+	if err := s.otpStore.Store(ctx, email, otpStr); err != nil {
+		return err
+	}
+
+	// Send OTP via email
+	if err := s.mailer.SendOTP(ctx, email, otpStr); err != nil {
+		return apperrors.Internal(fmt.Errorf("failed to send OTP email: %w", err))
+	}
+	return nil
+}
+
+func (s *UserService) Register(ctx context.Context, req types.RegisterRequest) (*models.User, string, error) {
 	if err := validators.ValidateRegisterInput(req.Name, req.Email, req.Password, req.Gender, req.Phone, req.Latitude, req.Longitude); err != nil {
 		return nil, "", err
 	}
 
-	existingUser, _ := s.userRepo.GetUserByEmail(req.Email)
+	existingUser, _ := s.userRepo.GetUserByEmail(ctx, req.Email)
 	if existingUser != nil {
-		return nil, "", errors.New("email already registered")
+		return nil, "", apperrors.Conflict("email already registered")
 	}
 
-	existingPhoneNumber, _ := s.userRepo.GetUserByPhone(req.Phone)
+	existingPhoneNumber, _ := s.userRepo.GetUserByPhone(ctx, req.Phone)
 	if existingPhoneNumber != nil {
-		return nil, "", errors.New("phone number already registered")
+		return nil, "", apperrors.Conflict("phone number already registered")
 	}
 
 	hashedPassword, err := auth.HashPassword(req.Password)
@@ -63,7 +107,7 @@ func (s *UserService) Register(req types.RegisterRequest) (*models.User, string,
 	// OTP flow commented out for testing - create user directly with is_active true
 
 	// 2. Store OTP and phone in Redis
-	// if err := helpers.StoreOTP(phone, otpStr); err != nil {
+	// if err := s.otpStore.Store(ctx, phone, otpStr); err != nil {
 	// 	return nil, "", err
 	// }
 	// 3. Send OTP to phone
@@ -80,14 +124,14 @@ func (s *UserService) Register(req types.RegisterRequest) (*models.User, string,
 		Password:  hashedPassword,
 		Phone:     req.Phone,
 		Gender:    req.Gender,
-		Role:      "player",
+		Role:      models.RolePlayer,
 		IsActive:  true, // Set to true for testing without OTP
 		Cnic:      cnicNumber,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
-	if err := s.userRepo.CreateUser(user); err != nil {
+	if err := s.userRepo.CreateUser(ctx, user); err != nil {
 		return nil, "", err
 	}
 
@@ -100,14 +144,14 @@ func (s *UserService) Register(req types.RegisterRequest) (*models.User, string,
 		UpdatedAt: time.Now(),
 	}
 
-	if err := s.locationRepo.CreateLocation(location); err != nil {
+	if err := s.locationRepo.CreateLocation(ctx, location); err != nil {
 		return nil, "", err
 	}
 
 	user.Location = *location
 
 	// Generate token immediately (OTP verification disabled for testing)
-	token, err := auth.GenerateJWT(user.ID, user.Email, user.Name, s.jwtSecret)
+	token, err := auth.GenerateJWT(user.ID, user.Email, user.Name, user.Role, s.jwtSecret, s.jwtTTL)
 	if err != nil {
 		return nil, "", err
 	}
@@ -115,25 +159,25 @@ func (s *UserService) Register(req types.RegisterRequest) (*models.User, string,
 	return user, token, nil
 }
 
-// ActivateUserByPhone sets user is_active to true after OTP was verified (call helpers.VerifyOTP first).
-func (s *UserService) ActivateUserByPhone(req types.ActivateUserRequest) (*models.User, string, error) {
-	user, err := s.userRepo.GetUserByPhone(req.Phone)
+// ActivateUserByPhone sets user is_active to true after OTP was verified (call VerifyOTP first).
+func (s *UserService) ActivateUserByPhone(ctx context.Context, req types.ActivateUserRequest) (*models.User, string, error) {
+	user, err := s.userRepo.GetUserByPhone(ctx, req.Phone)
 	if err != nil {
-		return nil, "", errors.New("user not found for this phone")
+		return nil, "", apperrors.NotFound("user").WithCause(err)
 	}
 
 	user.IsActive = true
 	user.UpdatedAt = time.Now()
-	if err := s.userRepo.UpdateUser(user); err != nil {
+	if err := s.userRepo.UpdateUser(ctx, user); err != nil {
 		return nil, "", err
 	}
 
-	location, _ := s.locationRepo.GetLocationByUserID(user.ID.String())
+	location, _ := s.locationRepo.GetLocationByUserID(ctx, user.ID.String())
 	if location != nil {
 		user.Location = *location
 	}
 
-	token, err := auth.GenerateJWT(user.ID, user.Email, user.Name, s.jwtSecret)
+	token, err := auth.GenerateJWT(user.ID, user.Email, user.Name, user.Role, s.jwtSecret, s.jwtTTL)
 	if err != nil {
 		return nil, "", err
 	}
@@ -141,25 +185,37 @@ func (s *UserService) ActivateUserByPhone(req types.ActivateUserRequest) (*model
 	return user, token, nil
 }
 
-// ActivateUserByEmail sets user is_active to true after OTP was verified via email (call helpers.VerifyOTP first).
-func (s *UserService) ActivateUserByEmail(email string) (*models.User, string, error) {
-	user, err := s.userRepo.GetUserByEmail(email)
+// VerifyOTP checks a code previously sent to identifier (an email or phone number).
+func (s *UserService) VerifyOTP(ctx context.Context, identifier, otp string) error {
+	ok, err := s.otpStore.Verify(ctx, identifier, otp)
 	if err != nil {
-		return nil, "", errors.New("user not found for this email")
+		return err
+	}
+	if !ok {
+		return apperrors.InvalidField("otp", "invalid or expired OTP")
+	}
+	return nil
+}
+
+// ActivateUserByEmail sets user is_active to true after OTP was verified via email (call VerifyOTP first).
+func (s *UserService) ActivateUserByEmail(ctx context.Context, email string) (*models.User, string, error) {
+	user, err := s.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, "", apperrors.NotFound("user").WithCause(err)
 	}
 
 	user.IsActive = true
 	user.UpdatedAt = time.Now()
-	if err := s.userRepo.UpdateUser(user); err != nil {
+	if err := s.userRepo.UpdateUser(ctx, user); err != nil {
 		return nil, "", err
 	}
 
-	location, _ := s.locationRepo.GetLocationByUserID(user.ID.String())
+	location, _ := s.locationRepo.GetLocationByUserID(ctx, user.ID.String())
 	if location != nil {
 		user.Location = *location
 	}
 
-	token, err := auth.GenerateJWT(user.ID, user.Email, user.Name, s.jwtSecret)
+	token, err := auth.GenerateJWT(user.ID, user.Email, user.Name, user.Role, s.jwtSecret, s.jwtTTL)
 	if err != nil {
 		return nil, "", err
 	}
@@ -167,34 +223,56 @@ func (s *UserService) ActivateUserByEmail(email string) (*models.User, string, e
 	return user, token, nil
 }
 
-func (s *UserService) Login(req types.LoginRequest) (*models.User, string, error) {
-	user, err := s.userRepo.GetUserByEmail(req.Email)
+// Login authenticates by email and password. Repeated failures are delayed
+// progressively and then locked out, and unknown emails fail exactly as wrong passwords
+// do, delays and lockouts included.
+func (s *UserService) Login(ctx context.Context, req types.LoginRequest) (*models.User, string, error) {
+	var failed error
+	user, err := s.userRepo.AttemptLogin(ctx, req.Email, func(user *models.User) error {
+		failed = attemptLogin(user, time.Now(), func() error {
+			return auth.VerifyPassword(user.Password, req.Password)
+		})
+		return nil
+	})
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, "", s.attemptUnknownLogin(ctx, req)
+	}
 	if err != nil {
 		return nil, "", err
 	}
+	if failed != nil {
+		reason := "invalid_credentials"
+		if errors.Is(failed, apperrors.ErrRateLimited) {
+			reason = "locked"
+		}
+		s.recordLogin(ctx, user, req, false, reason, 0)
+		return nil, "", failed
+	}
 
 	if !user.IsActive {
-		return nil, "", errors.New("please verify your phone with OTP first")
+		return nil, "", apperrors.Forbidden("please verify your phone with OTP first")
 	}
 
-	if err := auth.VerifyPassword(user.Password, req.Password); err != nil {
+	token, err := auth.GenerateJWT(user.ID, user.Email, user.Name, user.Role, s.jwtSecret, s.jwtTTL)
+	if err != nil {
 		return nil, "", err
 	}
 
-	token, err := auth.GenerateJWT(user.ID, user.Email, user.Name, s.jwtSecret)
+	location, err := s.locationRepo.GetLocationByUserID(ctx, user.ID.String())
 	if err != nil {
 		return nil, "", err
 	}
 
-	location, err := s.locationRepo.GetLocationByUserID(user.ID.String())
-	if err != nil {
-		return nil, "", err
+	// Compare against the stored location before it is overwritten with this login's position.
+	distance := helpers.HaversineKm(location.Latitude, location.Longitude, req.Latitude, req.Longitude)
+	if entry := s.recordLogin(ctx, user, req, true, "", distance); entry != nil && entry.Suspicious {
+		s.notifyNewLocation(ctx, user, entry)
 	}
 
 	location.Latitude = req.Latitude
 	location.Longitude = req.Longitude
 
-	if err := s.locationRepo.UpdateLocation(location); err != nil {
+	if err := s.locationRepo.UpdateLocation(ctx, location); err != nil {
 		return nil, "", err
 	}
 
@@ -203,17 +281,85 @@ func (s *UserService) Login(req types.LoginRequest) (*models.User, string, error
 	return user, token, nil
 }
 
-func (s *UserService) GetByID(id string) (*models.User, error) {
+// attemptUnknownLogin fails a login to an email with no account the way a wrong
+// password fails, delays and lockouts included, so neither the response nor its timing
+// tells the caller whether the email is registered.
+func (s *UserService) attemptUnknownLogin(ctx context.Context, req types.LoginRequest) error {
+	var failed error
+	err := s.loginFailures.Update(ctx, req.Email, func(user *models.User) error {
+		failed = attemptLogin(user, time.Now(), func() error {
+			auth.VerifyDummyPassword(req.Password)
+			return errInvalidCredentials
+		})
+		return nil
+	})
+	if err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to count login failure", logging.Err(err))
+	}
+	if failed == nil {
+		failed = errInvalidCredentials
+	}
+	return failed
+}
+
+// recordLogin writes a login history entry. Failures are logged, not returned, so an
+// audit hiccup never blocks a login.
+func (s *UserService) recordLogin(ctx context.Context, user *models.User, req types.LoginRequest, success bool, reason string, distanceKm float64) *models.LoginHistory {
+	entry := &models.LoginHistory{
+		UserID:        user.ID,
+		IP:            req.IP,
+		UserAgent:     truncate(req.UserAgent, 512),
+		Latitude:      coarseCoordinate(req.Latitude),
+		Longitude:     coarseCoordinate(req.Longitude),
+		Success:       success,
+		FailureReason: reason,
+		DistanceKm:    math.Round(distanceKm*10) / 10,
+		Suspicious:    success && distanceKm > suspiciousLoginDistanceKm,
+		CreatedAt:     time.Now(),
+	}
+	if err := s.loginRepo.Create(ctx, entry); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to record login history",
+			slog.String("user_id", user.ID.String()), logging.Err(err))
+		return nil
+	}
+	return entry
+}
+
+func (s *UserService) notifyNewLocation(ctx context.Context, user *models.User, entry *models.LoginHistory) {
+	body := newLocationEmailBody(user.Name, entry)
+	if err := s.notifier.SendEmail(ctx, user.Email, "New sign-in from an unfamiliar location", body); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "failed to send new-location alert",
+			slog.String("user_id", user.ID.String()), logging.Err(err))
+	}
+}
+
+// GetLoginHistory returns the user's most recent login attempts.
+func (s *UserService) GetLoginHistory(ctx context.Context, userID string) ([]models.LoginHistory, error) {
+	return s.loginRepo.ListByUser(ctx, userID, 50)
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	// Cut at a rune boundary so the stored text stays valid UTF-8.
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max]
+}
+
+func (s *UserService) GetByID(ctx context.Context, id string) (*models.User, error) {
 	if id == "" {
-		return nil, errors.New("Please provide an ID")
+		return nil, apperrors.InvalidField("id", "Please provide an ID")
 	}
 
-	user, err := s.userRepo.GetUserByID(id)
+	user, err := s.userRepo.GetUserByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	location, err := s.locationRepo.GetLocationByUserID(user.ID.String())
+	location, err := s.locationRepo.GetLocationByUserID(ctx, user.ID.String())
 	if err != nil {
 		return nil, err
 	}
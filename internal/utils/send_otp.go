@@ -3,35 +3,55 @@ package utils
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/musishere/sportsApp/config"
+	"github.com/musishere/sportsApp/internal/logging"
 )
 
-// SendExistingOTP sends a provided OTP to the given phone number
-func SendOTPToPhoneNumber(phone string, otp string) (string, error) {
-	cfg, err := config.LoadDefaultConfig(context.Background())
+// SMSSender delivers text messages through Amazon SNS.
+type SMSSender struct {
+	client *sns.Client
+}
+
+func NewSMSSender(ctx context.Context, cfg config.AWSConfig) (*SMSSender, error) {
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.Region))
 	if err != nil {
-		log.Printf("AWS config error: %v", err)
-		return "", err
+		return nil, fmt.Errorf("aws config: %w", err)
 	}
+	return &SMSSender{client: sns.NewFromConfig(awsCfg)}, nil
+}
 
-	client := sns.NewFromConfig(cfg)
-
+// SendOTPToPhoneNumber sends a provided OTP to the given phone number
+func (s *SMSSender) SendOTPToPhoneNumber(ctx context.Context, phone string, otp string) (string, error) {
 	message := fmt.Sprintf("Your OTP is %s. Valid for 5 minutes.", otp)
 
-	output, err := client.Publish(context.Background(), &sns.PublishInput{
+	output, err := s.client.Publish(ctx, &sns.PublishInput{
 		Message:     aws.String(message),
 		PhoneNumber: aws.String(phone),
 	})
 
 	if err != nil {
-		log.Printf("SNS Publish failed: %v", err)
-		return "", err
+		return "", fmt.Errorf("sns publish: %w", err)
 	}
 
-	log.Printf("SNS MessageID: %s", *output.MessageId)
+	logging.FromContext(ctx).InfoContext(ctx, "otp sms sent", slog.String("message_id", aws.ToString(output.MessageId)))
 	return "otp sent", nil
 }
+
+// Send delivers a plain-text message to phone.
+func (s *SMSSender) Send(ctx context.Context, phone, message string) error {
+	output, err := s.client.Publish(ctx, &sns.PublishInput{
+		Message:     aws.String(message),
+		PhoneNumber: aws.String(phone),
+	})
+	if err != nil {
+		return fmt.Errorf("sns publish: %w", err)
+	}
+
+	logging.FromContext(ctx).InfoContext(ctx, "sms sent", slog.String("message_id", aws.ToString(output.MessageId)))
+	return nil
+}
@@ -1,8 +1,10 @@
 package validators
 
 import (
-	"errors"
 	"strings"
+	"time"
+
+	"github.com/musishere/sportsApp/internal/apperrors"
 )
 
 // Allowed turf status values
@@ -10,43 +12,74 @@ var allowedTurfStatuses = map[string]bool{
 	"active": true, "inactive": true,
 }
 
+// Opening hours are whole hours of day: a turf opens at the start of startTime and
+// closes at endTime, so endTime 24 keeps it open until midnight. Weekday overrides,
+// pricing rules and saved searches use the same ranges.
 const (
 	minHour       = 0
 	maxHour       = 23
+	minEndHour    = 1
+	maxEndHour    = 24
 	maxNoOfFields = 50
 )
 
 // ValidateTurfInput validates turf name, start/end time, status, and noOfFields.
-// startTime and endTime are hours of day (0-23).
+// startTime is the hour of day the turf opens (0-23), endTime the hour it closes (1-24).
 func ValidateTurfInput(name string, startTime, endTime int, status string, noOfFields int, address string) error {
 	if strings.TrimSpace(name) == "" {
-		return errors.New("name is required")
+		return apperrors.InvalidField("name", "name is required")
 	}
 
 	if startTime < minHour || startTime > maxHour {
-		return errors.New("startTime must be between 0 and 23 (hour of day)")
+		return apperrors.InvalidField("startTime", "startTime must be between 0 and 23 (hour of day)")
 	}
-	if endTime < minHour || endTime > maxHour {
-		return errors.New("endTime must be between 0 and 23 (hour of day)")
+	if endTime < minEndHour || endTime > maxEndHour {
+		return apperrors.InvalidField("endTime", "endTime must be between 1 and 24 (the hour the turf closes)")
 	}
 	if endTime <= startTime {
-		return errors.New("endTime must be after startTime")
+		return apperrors.InvalidField("endTime", "endTime must be after startTime")
 	}
 
 	if status != "" && !allowedTurfStatuses[strings.ToLower(status)] {
-		return errors.New("status must be one of: active, inactive")
+		return apperrors.InvalidField("status", "status must be one of: active, inactive")
 	}
 
 	if noOfFields < 1 {
-		return errors.New("noOfFields must be at least 1")
+		return apperrors.InvalidField("noOfFields", "noOfFields must be at least 1")
 	}
 	if noOfFields > maxNoOfFields {
-		return errors.New("noOfFields exceeds maximum allowed")
+		return apperrors.InvalidField("noOfFields", "noOfFields exceeds maximum allowed")
 	}
 
 	if strings.TrimSpace(address) == "" {
-		return errors.New("address is required")
+		return apperrors.InvalidField("address", "address is required")
+	}
+
+	return nil
+}
+
+// ValidateTurfCoordinates validates an optional manual pin-drop for a turf.
+// Both latitude and longitude must be supplied together; when neither is set the
+// turf address is geocoded instead.
+func ValidateTurfCoordinates(latitude, longitude *float64) error {
+	if latitude == nil && longitude == nil {
+		return nil
+	}
+	if latitude == nil || longitude == nil {
+		return apperrors.Validation("latitude and longitude must be provided together",
+			apperrors.Field("latitude", "required with longitude"),
+			apperrors.Field("longitude", "required with latitude"))
 	}
+	return ValidateCoordinates(*latitude, *longitude)
+}
 
+// ValidateTimeZone checks that name is an IANA time zone, such as Asia/Karachi.
+func ValidateTimeZone(name string) error {
+	if name == "" || name == "Local" {
+		return apperrors.InvalidField("timeZone", "timeZone must be an IANA time zone such as Asia/Karachi")
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return apperrors.InvalidField("timeZone", "timeZone must be an IANA time zone such as Asia/Karachi")
+	}
 	return nil
 }
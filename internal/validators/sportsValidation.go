@@ -1,30 +1,31 @@
 package validators
 
 import (
-	"errors"
 	"strings"
+
+	"github.com/musishere/sportsApp/internal/apperrors"
 )
 
 func ValidateSportInput(name string, minPlayers, maxPlayers int) error {
 
 	if strings.TrimSpace(name) == "" {
-		return errors.New("name is required")
+		return apperrors.InvalidField("name", "name is required")
 	}
 
 	if minPlayers < 1 {
-		return errors.New("minPlayers must be at least 1")
+		return apperrors.InvalidField("minPlayers", "minPlayers must be at least 1")
 	}
 
 	if maxPlayers < 1 {
-		return errors.New("maxPlayers must be at least 1")
+		return apperrors.InvalidField("maxPlayers", "maxPlayers must be at least 1")
 	}
 
 	if minPlayers > maxPlayers {
-		return errors.New("minPlayers cannot be greater than maxPlayers")
+		return apperrors.InvalidField("minPlayers", "minPlayers cannot be greater than maxPlayers")
 	}
 
 	if maxPlayers > 100 { // reasonable upper limit
-		return errors.New("maxPlayers seems too high")
+		return apperrors.InvalidField("maxPlayers", "maxPlayers seems too high")
 	}
 
 	return nil
@@ -1,8 +1,9 @@
 package validators
 
 import (
-	"errors"
 	"strings"
+
+	"github.com/musishere/sportsApp/internal/apperrors"
 )
 
 const (
@@ -13,50 +14,39 @@ const (
 	maxLongitude      = 180
 )
 
-// ValidationError represents a validation failure so handlers can return 400.
-type ValidationError struct {
-	Err error
-}
-
-func (e *ValidationError) Error() string {
-	if e.Err != nil {
-		return e.Err.Error()
-	}
-	return "validation failed"
-}
-
-func (e *ValidationError) Unwrap() error {
-	return e.Err
-}
-
 // ValidateRegisterInput validates user registration input.
 // Name, email, password, gender, and phone are required. Password must be at least 6 characters.
 // Latitude/longitude must be within valid geographic ranges.
-// Returns a *ValidationError so callers can use errors.As to respond with 400.
+// Returns an *apperrors.Error with code validation_failed so handlers respond with 400.
 func ValidateRegisterInput(name, email, password, gender, phone string, latitude, longitude float64) error {
 	if strings.TrimSpace(name) == "" {
-		return &ValidationError{Err: errors.New("name is required")}
+		return apperrors.InvalidField("name", "name is required")
 	}
 	if strings.TrimSpace(email) == "" {
-		return &ValidationError{Err: errors.New("email is required")}
+		return apperrors.InvalidField("email", "email is required")
 	}
 	if strings.TrimSpace(password) == "" {
-		return &ValidationError{Err: errors.New("password is required")}
+		return apperrors.InvalidField("password", "password is required")
 	}
 	if len(password) < minPasswordLength {
-		return &ValidationError{Err: errors.New("password must be at least 6 characters")}
+		return apperrors.InvalidField("password", "password must be at least 6 characters")
 	}
 	if strings.TrimSpace(gender) == "" {
-		return &ValidationError{Err: errors.New("gender is required")}
+		return apperrors.InvalidField("gender", "gender is required")
 	}
 	if strings.TrimSpace(phone) == "" {
-		return &ValidationError{Err: errors.New("phone is required")}
+		return apperrors.InvalidField("phone", "phone is required")
 	}
+	return ValidateCoordinates(latitude, longitude)
+}
+
+// ValidateCoordinates checks that latitude/longitude are within valid geographic ranges.
+func ValidateCoordinates(latitude, longitude float64) error {
 	if latitude < minLatitude || latitude > maxLatitude {
-		return &ValidationError{Err: errors.New("latitude must be between -90 and 90")}
+		return apperrors.InvalidField("latitude", "latitude must be between -90 and 90")
 	}
 	if longitude < minLongitude || longitude > maxLongitude {
-		return &ValidationError{Err: errors.New("longitude must be between -180 and 180")}
+		return apperrors.InvalidField("longitude", "longitude must be between -180 and 180")
 	}
 	return nil
 }
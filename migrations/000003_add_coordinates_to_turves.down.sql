@@ -0,0 +1,6 @@
+ALTER TABLE turves DROP COLUMN IF EXISTS postcode;
+ALTER TABLE turves DROP COLUMN IF EXISTS state;
+ALTER TABLE turves DROP COLUMN IF EXISTS city;
+ALTER TABLE turves DROP COLUMN IF EXISTS coordinate_source;
+ALTER TABLE turves DROP COLUMN IF EXISTS longitude;
+ALTER TABLE turves DROP COLUMN IF EXISTS latitude;
@@ -0,0 +1,7 @@
+-- Turf coordinates: manual pin-drop or geocoded, plus structured address parts from LocationIQ
+ALTER TABLE turves ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE turves ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE turves ADD COLUMN IF NOT EXISTS coordinate_source VARCHAR(20) NOT NULL DEFAULT 'geocoded';
+ALTER TABLE turves ADD COLUMN IF NOT EXISTS city VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE turves ADD COLUMN IF NOT EXISTS state VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE turves ADD COLUMN IF NOT EXISTS postcode VARCHAR(20) NOT NULL DEFAULT '';
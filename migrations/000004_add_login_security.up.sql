@@ -0,0 +1,21 @@
+-- Failed-login tracking for progressive delays and lockout
+ALTER TABLE users ADD COLUMN IF NOT EXISTS failed_login_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_failed_login_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;
+
+-- login_histories (one row per login attempt against an existing account)
+CREATE TABLE IF NOT EXISTS login_histories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    latitude DECIMAL(10,2) NOT NULL DEFAULT 0,
+    longitude DECIMAL(11,2) NOT NULL DEFAULT 0,
+    success BOOLEAN NOT NULL DEFAULT false,
+    failure_reason VARCHAR(50) NOT NULL DEFAULT '',
+    distance_km DOUBLE PRECISION NOT NULL DEFAULT 0,
+    suspicious BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_login_histories_user_id ON login_histories(user_id);
+CREATE INDEX IF NOT EXISTS idx_login_histories_created_at ON login_histories(created_at);
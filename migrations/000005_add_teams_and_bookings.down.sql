@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bookings;
+DROP TABLE IF EXISTS team_invitations;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_turves_latitude_longitude;
+DROP TABLE IF EXISTS match_players;
+DROP TABLE IF EXISTS matches;
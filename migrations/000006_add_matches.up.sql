@@ -0,0 +1,33 @@
+-- matches (an open game on a booked field that other players can join)
+CREATE TABLE IF NOT EXISTS matches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    booking_id UUID NOT NULL UNIQUE REFERENCES bookings(id) ON DELETE CASCADE,
+    sport_id UUID NOT NULL REFERENCES sports(id),
+    organizer_id UUID NOT NULL REFERENCES users(id),
+    skill_level VARCHAR(20) NOT NULL DEFAULT 'any',
+    party_size INT NOT NULL DEFAULT 1,
+    open_spots INT NOT NULL,
+    notes VARCHAR(500) NOT NULL DEFAULT '',
+    cutoff_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_matches_sport_id ON matches(sport_id);
+CREATE INDEX IF NOT EXISTS idx_matches_organizer_id ON matches(organizer_id);
+CREATE INDEX IF NOT EXISTS idx_matches_cutoff_at ON matches(cutoff_at);
+CREATE INDEX IF NOT EXISTS idx_matches_status ON matches(status);
+
+-- match_players (joined or waitlisted, in join order)
+CREATE TABLE IF NOT EXISTS match_players (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    match_id UUID NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_match_players_match_user ON match_players(match_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_match_players_user_id ON match_players(user_id);
+
+-- nearby search filters turfs by a bounding box before computing distances
+CREATE INDEX IF NOT EXISTS idx_turves_latitude_longitude ON turves(latitude, longitude);
@@ -0,0 +1,47 @@
+-- payment_splits (a booking's cost divided between its players, in minor units)
+CREATE TABLE IF NOT EXISTS payment_splits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    booking_id UUID NOT NULL UNIQUE REFERENCES bookings(id) ON DELETE CASCADE,
+    organizer_id UUID NOT NULL REFERENCES users(id),
+    total_amount BIGINT NOT NULL CHECK (total_amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    deadline TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'collecting',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_payment_splits_organizer_id ON payment_splits(organizer_id);
+CREATE INDEX IF NOT EXISTS idx_payment_splits_deadline ON payment_splits(deadline);
+CREATE INDEX IF NOT EXISTS idx_payment_splits_status ON payment_splits(status);
+
+-- payment_shares (what each player owes; paid_at is set once their payment succeeds)
+CREATE TABLE IF NOT EXISTS payment_shares (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    split_id UUID NOT NULL REFERENCES payment_splits(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    paid_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_shares_split_user ON payment_shares(split_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_payment_shares_user_id ON payment_shares(user_id);
+
+-- payments (successful charges, kept after refund)
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    share_id UUID NOT NULL UNIQUE REFERENCES payment_shares(id),
+    split_id UUID NOT NULL REFERENCES payment_splits(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    charge_id VARCHAR(255) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL,
+    refund_id VARCHAR(255) NOT NULL DEFAULT '',
+    refunded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_payments_split_id ON payments(split_id);
+CREATE INDEX IF NOT EXISTS idx_payments_user_id ON payments(user_id);
+CREATE INDEX IF NOT EXISTS idx_payments_status ON payments(status);
@@ -0,0 +1,60 @@
+-- tournaments (a competition between teams of one sport on the organiser's turf)
+CREATE TABLE IF NOT EXISTS tournaments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    sport_id UUID NOT NULL REFERENCES sports(id),
+    turf_id UUID NOT NULL REFERENCES turves(id) ON DELETE CASCADE,
+    organizer_id UUID NOT NULL REFERENCES users(id),
+    format VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'registration',
+    starts_at TIMESTAMPTZ NOT NULL,
+    match_minutes INT NOT NULL,
+    break_minutes INT NOT NULL DEFAULT 0,
+    max_teams INT NOT NULL,
+    group_count INT NOT NULL DEFAULT 0,
+    advance_per_group INT NOT NULL DEFAULT 0,
+    champion_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_tournaments_sport_id ON tournaments(sport_id);
+CREATE INDEX IF NOT EXISTS idx_tournaments_turf_id ON tournaments(turf_id);
+CREATE INDEX IF NOT EXISTS idx_tournaments_organizer_id ON tournaments(organizer_id);
+CREATE INDEX IF NOT EXISTS idx_tournaments_status ON tournaments(status);
+
+-- tournament_teams (registered teams, seeded in registration order)
+CREATE TABLE IF NOT EXISTS tournament_teams (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tournament_id UUID NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    seed INT NOT NULL,
+    group_number INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tournament_teams_tournament_team ON tournament_teams(tournament_id, team_id);
+CREATE INDEX IF NOT EXISTS idx_tournament_teams_team_id ON tournament_teams(team_id);
+
+-- fixtures (one match each, on a field held by a booking)
+CREATE TABLE IF NOT EXISTS fixtures (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tournament_id UUID NOT NULL REFERENCES tournaments(id) ON DELETE CASCADE,
+    stage VARCHAR(20) NOT NULL,
+    round INT NOT NULL,
+    group_number INT NOT NULL DEFAULT 0,
+    slot INT NOT NULL,
+    home_team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    away_team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    home_seed INT NOT NULL DEFAULT 0,
+    away_seed INT NOT NULL DEFAULT 0,
+    home_score INT,
+    away_score INT,
+    winner_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    booking_id UUID NOT NULL UNIQUE REFERENCES bookings(id) ON DELETE CASCADE,
+    field_number INT NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_fixtures_tournament_stage_round ON fixtures(tournament_id, stage, round);
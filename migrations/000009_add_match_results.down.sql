@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS player_results;
+DROP TABLE IF EXISTS match_results;
@@ -0,0 +1,28 @@
+-- match_results (the final score of a played open match)
+CREATE TABLE IF NOT EXISTS match_results (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    match_id UUID NOT NULL UNIQUE REFERENCES matches(id) ON DELETE CASCADE,
+    home_score INT NOT NULL,
+    away_score INT NOT NULL,
+    recorded_by_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- player_results (one row per player; sport, turf and city are copied for aggregation)
+CREATE TABLE IF NOT EXISTS player_results (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    result_id UUID NOT NULL REFERENCES match_results(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sport_id UUID NOT NULL REFERENCES sports(id),
+    turf_id UUID NOT NULL REFERENCES turves(id),
+    city VARCHAR(255) NOT NULL DEFAULT '',
+    side VARCHAR(10) NOT NULL,
+    outcome VARCHAR(10) NOT NULL,
+    points INT NOT NULL,
+    stats JSONB NOT NULL DEFAULT '{}',
+    played_at TIMESTAMPTZ NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_player_results_result_user ON player_results(result_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_player_results_user_id ON player_results(user_id);
+CREATE INDEX IF NOT EXISTS idx_player_results_sport_id ON player_results(sport_id);
+CREATE INDEX IF NOT EXISTS idx_player_results_turf_id ON player_results(turf_id);
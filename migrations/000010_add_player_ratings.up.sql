@@ -0,0 +1,13 @@
+-- player_ratings (Glicko rating per player per sport, updated from match results)
+CREATE TABLE IF NOT EXISTS player_ratings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    sport_id UUID NOT NULL REFERENCES sports(id) ON DELETE CASCADE,
+    rating DOUBLE PRECISION NOT NULL,
+    deviation DOUBLE PRECISION NOT NULL,
+    games INT NOT NULL DEFAULT 0,
+    last_played_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_player_ratings_user_sport ON player_ratings(user_id, sport_id);
+CREATE INDEX IF NOT EXISTS idx_player_ratings_sport_id ON player_ratings(sport_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS review_reports;
+DROP TABLE IF EXISTS turf_reviews;
+DROP INDEX IF EXISTS idx_turves_rating;
+ALTER TABLE turves DROP COLUMN IF EXISTS rating_count;
+ALTER TABLE turves DROP COLUMN IF EXISTS rating_average;
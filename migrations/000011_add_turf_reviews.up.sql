@@ -0,0 +1,34 @@
+-- Rating summary of a turf's visible reviews, kept up to date by the API
+ALTER TABLE turves ADD COLUMN IF NOT EXISTS rating_average DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE turves ADD COLUMN IF NOT EXISTS rating_count INT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_turves_rating ON turves(rating_average DESC, rating_count DESC);
+
+-- turf_reviews (one review per player per turf; owners reply, admins hide)
+CREATE TABLE IF NOT EXISTS turf_reviews (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    turf_id UUID NOT NULL REFERENCES turves(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating INT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    body TEXT NOT NULL DEFAULT '',
+    photos JSONB NOT NULL DEFAULT '[]',
+    owner_reply TEXT NOT NULL DEFAULT '',
+    replied_at TIMESTAMPTZ,
+    status VARCHAR(20) NOT NULL DEFAULT 'visible',
+    hidden_reason VARCHAR(500) NOT NULL DEFAULT '',
+    report_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_turf_reviews_turf_user ON turf_reviews(turf_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_turf_reviews_turf_status_created ON turf_reviews(turf_id, status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_turf_reviews_report_count ON turf_reviews(report_count) WHERE report_count > 0;
+
+-- review_reports (one report per user per review)
+CREATE TABLE IF NOT EXISTS review_reports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    review_id UUID NOT NULL REFERENCES turf_reviews(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_review_reports_review_user ON review_reports(review_id, user_id);
@@ -0,0 +1,28 @@
+-- favourite_turfs (turfs a player has saved for quick rebooking)
+CREATE TABLE IF NOT EXISTS favourite_turfs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    turf_id UUID NOT NULL REFERENCES turves(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_favourite_turfs_user_turf ON favourite_turfs(user_id, turf_id);
+CREATE INDEX IF NOT EXISTS idx_favourite_turfs_turf_id ON favourite_turfs(turf_id);
+
+-- saved_searches (slot alerts: area, optional sport and time window, notified when a booking is cancelled)
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    sport_id UUID REFERENCES sports(id) ON DELETE CASCADE,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    radius_km DOUBLE PRECISION NOT NULL,
+    window_start TIMESTAMPTZ NOT NULL,
+    window_end TIMESTAMPTZ NOT NULL,
+    from_hour INT NOT NULL DEFAULT 0,
+    to_hour INT NOT NULL DEFAULT 24,
+    last_notified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user_id ON saved_searches(user_id);
+CREATE INDEX IF NOT EXISTS idx_saved_searches_window_end ON saved_searches(window_end);
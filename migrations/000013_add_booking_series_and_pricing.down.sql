@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS pricing_rules;
+DROP INDEX IF EXISTS idx_bookings_series_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS amount;
+ALTER TABLE bookings DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS booking_series;
+ALTER TABLE turves DROP COLUMN IF EXISTS time_zone;
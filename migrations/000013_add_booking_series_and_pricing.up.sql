@@ -0,0 +1,40 @@
+-- turves.time_zone (the IANA zone a turf's hours, prices and recurring bookings are read in;
+-- existing turfs take the platform default)
+ALTER TABLE turves ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT 'Asia/Karachi';
+
+-- booking_series (a field booked on every date of a weekly recurrence rule)
+CREATE TABLE IF NOT EXISTS booking_series (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    turf_id UUID NOT NULL REFERENCES turves(id) ON DELETE CASCADE,
+    field_number INT NOT NULL,
+    booked_by_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+    rrule VARCHAR(255) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    duration_minutes INT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_booking_series_turf_id ON booking_series(turf_id);
+CREATE INDEX IF NOT EXISTS idx_booking_series_booked_by_id ON booking_series(booked_by_id);
+CREATE INDEX IF NOT EXISTS idx_booking_series_team_id ON booking_series(team_id);
+
+-- Occurrences of a series are ordinary bookings carrying its id and their price
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS series_id UUID REFERENCES booking_series(id) ON DELETE SET NULL;
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS amount BIGINT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_bookings_series_id ON bookings(series_id);
+
+-- pricing_rules (a turf's hourly rate for some hours of some days)
+CREATE TABLE IF NOT EXISTS pricing_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    turf_id UUID NOT NULL REFERENCES turves(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    days JSONB NOT NULL DEFAULT '[]',
+    from_hour INT NOT NULL,
+    to_hour INT NOT NULL,
+    hourly_rate BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_pricing_rules_turf_id ON pricing_rules(turf_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS booking_closures;
+DROP INDEX IF EXISTS idx_bookings_closure_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS closure_id;
+DROP TABLE IF EXISTS turf_closures;
+DROP TABLE IF EXISTS turf_hours;
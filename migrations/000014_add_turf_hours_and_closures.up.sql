@@ -0,0 +1,35 @@
+-- turf_hours (a turf's opening hours on one weekday, overriding start_time/end_time)
+CREATE TABLE IF NOT EXISTS turf_hours (
+    turf_id UUID NOT NULL REFERENCES turves(id) ON DELETE CASCADE,
+    weekday INT NOT NULL,
+    opens_at INT NOT NULL,
+    closes_at INT NOT NULL,
+    closed BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (turf_id, weekday)
+);
+
+-- turf_closures (a field, or the whole turf when field_number is NULL, blocked for a time range)
+CREATE TABLE IF NOT EXISTS turf_closures (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    turf_id UUID NOT NULL REFERENCES turves(id) ON DELETE CASCADE,
+    field_number INT,
+    reason VARCHAR(20) NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    created_by_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_turf_closures_turf_start ON turf_closures(turf_id, starts_at);
+
+-- Bookings a closure overlaps when it is set are flagged with it
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS closure_id UUID REFERENCES turf_closures(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_bookings_closure_id ON bookings(closure_id);
+
+-- booking_closures (every closure that flagged a booking; bookings.closure_id keeps one of them)
+CREATE TABLE IF NOT EXISTS booking_closures (
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    turf_closure_id UUID NOT NULL REFERENCES turf_closures(id) ON DELETE CASCADE,
+    PRIMARY KEY (booking_id, turf_closure_id)
+);
+CREATE INDEX IF NOT EXISTS idx_booking_closures_turf_closure_id ON booking_closures(turf_closure_id);
@@ -0,0 +1,12 @@
+package types
+
+import "time"
+
+// CreateBookingRequest reserves a field. TeamID makes a team the booking party.
+type CreateBookingRequest struct {
+	TurfID      string    `json:"turfId" binding:"required,uuid"`
+	FieldNumber int       `json:"fieldNumber" binding:"required,min=1"`
+	StartsAt    time.Time `json:"startsAt" binding:"required"`
+	EndsAt      time.Time `json:"endsAt" binding:"required"`
+	TeamID      *string   `json:"teamId" binding:"omitempty,uuid"`
+}
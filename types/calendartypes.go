@@ -0,0 +1,28 @@
+package types
+
+import "time"
+
+// SetOpeningHoursRequest replaces a turf's weekday opening hours. Weekdays left out
+// use the turf's startTime and endTime.
+type SetOpeningHoursRequest struct {
+	Days []OpeningHoursDay `json:"days" binding:"required,max=7,dive"`
+}
+
+// OpeningHoursDay sets one weekday's hours; 0 is Sunday. OpensAt and ClosesAt are
+// hours like the turf's startTime and endTime, and are ignored on a Closed day.
+type OpeningHoursDay struct {
+	Weekday  *int `json:"weekday" binding:"required,min=0,max=6"`
+	OpensAt  int  `json:"opensAt" binding:"min=0,max=23"`
+	ClosesAt int  `json:"closesAt" binding:"min=0,max=24"`
+	Closed   bool `json:"closed"`
+}
+
+// CreateClosureRequest blocks FieldNumber, or the whole turf when it is omitted, from
+// StartsAt to EndsAt.
+type CreateClosureRequest struct {
+	FieldNumber *int      `json:"fieldNumber" binding:"omitempty,min=1"`
+	Reason      string    `json:"reason" binding:"required,oneof=maintenance private_event holiday"`
+	Note        string    `json:"note" binding:"max=255"`
+	StartsAt    time.Time `json:"startsAt" binding:"required"`
+	EndsAt      time.Time `json:"endsAt" binding:"required"`
+}
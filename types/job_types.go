@@ -1,6 +1,15 @@
 package types
 
+// Job types processed by the SQS worker pool.
+const (
+	JobTypeSendEmail = "send_email"
+	JobTypeSendSMS   = "send_sms"
+)
+
 type Job struct {
 	JobType string                 `json:"job_type"`
 	Payload map[string]interface{} `json:"payload"`
+
+	// RequestID links the job's logs to the request that enqueued it.
+	RequestID string `json:"request_id,omitempty"`
 }
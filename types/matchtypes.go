@@ -0,0 +1,45 @@
+package types
+
+import "time"
+
+// CreateMatchRequest opens a booked field to other players. PartySize is how many
+// players the organiser brings, themselves included (default 1); CutoffAt defaults to
+// the configured interval before kick-off.
+type CreateMatchRequest struct {
+	BookingID  string     `json:"bookingId" binding:"required,uuid"`
+	SportID    string     `json:"sportId" binding:"required,uuid"`
+	SkillLevel string     `json:"skillLevel" binding:"required,oneof=any beginner intermediate advanced"`
+	OpenSpots  int        `json:"openSpots" binding:"required,min=1"`
+	PartySize  int        `json:"partySize" binding:"omitempty,min=1"`
+	CutoffAt   *time.Time `json:"cutoffAt"`
+	Notes      string     `json:"notes" binding:"max=500"`
+}
+
+// NearbyMatchesQuery filters the open matches around the caller's saved location.
+type NearbyMatchesQuery struct {
+	RadiusKm   float64
+	SportID    string
+	SkillLevel string
+}
+
+// RecordMatchResultRequest is the final score of a played match and how each player
+// did. Stats are counts keyed by the sport's stat names, e.g. goals and assists.
+type RecordMatchResultRequest struct {
+	HomeScore *int                  `json:"homeScore" binding:"required,min=0"`
+	AwayScore *int                  `json:"awayScore" binding:"required,min=0"`
+	Players   []PlayerResultRequest `json:"players" binding:"required,min=2,dive"`
+}
+
+type PlayerResultRequest struct {
+	UserID string         `json:"userId" binding:"required,uuid"`
+	Side   string         `json:"side" binding:"required,oneof=home away"`
+	Stats  map[string]int `json:"stats" binding:"omitempty,dive,min=0"`
+}
+
+// RecommendedMatchesQuery finds nearby open matches within Band rating points of the
+// caller's rating.
+type RecommendedMatchesQuery struct {
+	RadiusKm float64
+	SportID  string
+	Band     float64
+}
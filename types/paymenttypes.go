@@ -0,0 +1,18 @@
+package types
+
+import "time"
+
+// CreateSplitBookingRequest books a field whose price, under the turf's pricing rules,
+// is shared between the organiser and ParticipantIDs. The booking holds the field until
+// PaymentDeadline, which defaults to the configured interval.
+type CreateSplitBookingRequest struct {
+	CreateBookingRequest
+	ParticipantIDs  []string   `json:"participantIds" binding:"required,min=1,max=49,dive,uuid"`
+	PaymentDeadline *time.Time `json:"paymentDeadline"`
+}
+
+// PayShareRequest pays the caller's share with a payment method tokenised by the
+// payment provider on the client.
+type PayShareRequest struct {
+	PaymentToken string `json:"paymentToken" binding:"required"`
+}
@@ -0,0 +1,29 @@
+package types
+
+// ReviewPhoto is a photo attached to a review, read from the multipart form.
+type ReviewPhoto struct {
+	FileBytes []byte
+	Filename  string
+}
+
+// CreateReviewRequest contains a player's rating of a turf, sent as a multipart form.
+type CreateReviewRequest struct {
+	Rating int
+	Body   string
+	Photos []ReviewPhoto
+}
+
+// ReplyToReviewRequest is the turf owner's public reply to a review.
+type ReplyToReviewRequest struct {
+	Reply string `json:"reply" binding:"required,max=1000"`
+}
+
+// ReportReviewRequest flags a review for moderation.
+type ReportReviewRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// HideReviewRequest hides a review from the turf's listing and rating.
+type HideReviewRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
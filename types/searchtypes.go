@@ -0,0 +1,18 @@
+package types
+
+import "time"
+
+// CreateSavedSearchRequest asks to be told when a field frees up within RadiusKm of the
+// caller's saved location between WindowStart and WindowEnd. FromHour and ToHour
+// narrow each day to those hours (default the whole day), read in the time zone of
+// the turf a slot is at. SportID limits it to turfs that have hosted the sport, or
+// nothing yet.
+type CreateSavedSearchRequest struct {
+	Name        string    `json:"name" binding:"required,max=100"`
+	SportID     *string   `json:"sportId" binding:"omitempty,uuid"`
+	RadiusKm    float64   `json:"radiusKm" binding:"required,min=0.1,max=50"`
+	WindowStart time.Time `json:"windowStart" binding:"required"`
+	WindowEnd   time.Time `json:"windowEnd" binding:"required"`
+	FromHour    *int      `json:"fromHour" binding:"omitempty,min=0,max=23"`
+	ToHour      *int      `json:"toHour" binding:"omitempty,min=1,max=24"`
+}
@@ -0,0 +1,30 @@
+package types
+
+import "time"
+
+// CreateBookingSeriesRequest books a field on every date of RRule, a weekly recurrence
+// rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU;COUNT=12". StartsAt and EndsAt are the
+// first occurrence; the rest keep its wall-clock time in the turf's time zone.
+type CreateBookingSeriesRequest struct {
+	CreateBookingRequest
+	RRule string `json:"rrule" binding:"required,max=255"`
+}
+
+// RescheduleOccurrenceRequest moves one occurrence of a series. FieldNumber defaults to
+// the occurrence's field.
+type RescheduleOccurrenceRequest struct {
+	StartsAt    time.Time `json:"startsAt" binding:"required"`
+	EndsAt      time.Time `json:"endsAt" binding:"required"`
+	FieldNumber *int      `json:"fieldNumber" binding:"omitempty,min=1"`
+}
+
+// CreatePricingRuleRequest sets a turf's hourly rate, in the payments currency's minor
+// unit, from FromHour to ToHour on Days (time.Weekday numbers, 0 is Sunday; none means
+// every day).
+type CreatePricingRuleRequest struct {
+	Name       string `json:"name" binding:"max=100"`
+	Days       []int  `json:"days" binding:"omitempty,max=7,dive,min=0,max=6"`
+	FromHour   *int   `json:"fromHour" binding:"required,min=0,max=23"`
+	ToHour     *int   `json:"toHour" binding:"required,min=1,max=24"`
+	HourlyRate int64  `json:"hourlyRate" binding:"required,min=1"`
+}
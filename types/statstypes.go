@@ -0,0 +1,8 @@
+package types
+
+// LeaderboardQuery narrows a sport's leaderboard to one turf or one city.
+type LeaderboardQuery struct {
+	TurfID string
+	City   string
+	Limit  int
+}
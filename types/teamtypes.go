@@ -0,0 +1,13 @@
+package types
+
+// CreateTeamRequest creates a team for a sport; the caller becomes its captain.
+type CreateTeamRequest struct {
+	Name    string `json:"name" binding:"required,max=100"`
+	SportID string `json:"sportId" binding:"required,uuid"`
+}
+
+// InviteToTeamRequest invites someone by exactly one of email or phone.
+type InviteToTeamRequest struct {
+	Email string `json:"email" binding:"omitempty,email"`
+	Phone string `json:"phone" binding:"omitempty,max=15"`
+}
@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+// CreateTournamentRequest sets up a tournament on one of the caller's turfs.
+// GroupCount and AdvancePerGroup apply to the group_knockout format only.
+type CreateTournamentRequest struct {
+	Name            string    `json:"name" binding:"required,max=100"`
+	SportID         string    `json:"sportId" binding:"required,uuid"`
+	TurfID          string    `json:"turfId" binding:"required,uuid"`
+	Format          string    `json:"format" binding:"required,oneof=knockout round_robin group_knockout"`
+	StartsAt        time.Time `json:"startsAt" binding:"required"`
+	MatchMinutes    int       `json:"matchMinutes" binding:"required,min=10,max=240"`
+	BreakMinutes    int       `json:"breakMinutes" binding:"min=0,max=120"`
+	MaxTeams        int       `json:"maxTeams" binding:"required,min=2,max=64"`
+	GroupCount      int       `json:"groupCount" binding:"omitempty,min=2,max=16"`
+	AdvancePerGroup int       `json:"advancePerGroup" binding:"omitempty,min=1,max=8"`
+}
+
+// RegisterTournamentTeamRequest enters a team the caller captains.
+type RegisterTournamentTeamRequest struct {
+	TeamID string `json:"teamId" binding:"required,uuid"`
+}
+
+// FixtureResultRequest records a final score. A drawn knockout fixture, e.g. one
+// decided on penalties, also names its winner.
+type FixtureResultRequest struct {
+	HomeScore    *int    `json:"homeScore" binding:"required,min=0"`
+	AwayScore    *int    `json:"awayScore" binding:"required,min=0"`
+	WinnerTeamID *string `json:"winnerTeamId" binding:"omitempty,uuid"`
+}
+
+// TournamentListQuery filters a page of tournaments.
+type TournamentListQuery struct {
+	SportID string
+	TurfID  string
+	Status  string
+}
@@ -1,11 +1,24 @@
 package types
 
-// UpdateTurfRequest contains optional fields for updating a turf
+// Turf list orders. The default lists turfs in no particular order.
+const (
+	TurfSortRating = "rating"
+	TurfSortNewest = "newest"
+)
+
+// UpdateTurfRequest contains optional fields for updating a turf.
+// Latitude/Longitude set a manual pin-drop; when omitted and the address changes,
+// the address is geocoded instead unless the turf already has a manual pin. Geocode
+// drops a manual pin and geocodes the address again.
 type UpdateTurfRequest struct {
-    Name       *string  `json:"name" form:"name"`
-    StartTime  *int     `json:"startTime" form:"startTime"`
-    EndTime    *int     `json:"endTime" form:"endTime"`
-    Status     *string  `json:"status" form:"status"`
-    NoOfFields *int     `json:"noOfFields" form:"noOfFields"`
-    Address    *string  `json:"address" form:"address"`
+	Name       *string  `json:"name" form:"name"`
+	StartTime  *int     `json:"startTime" form:"startTime"`
+	EndTime    *int     `json:"endTime" form:"endTime"`
+	Status     *string  `json:"status" form:"status"`
+	NoOfFields *int     `json:"noOfFields" form:"noOfFields"`
+	Address    *string  `json:"address" form:"address"`
+	Latitude   *float64 `json:"latitude" form:"latitude"`
+	Longitude  *float64 `json:"longitude" form:"longitude"`
+	Geocode    bool     `json:"geocode" form:"geocode"`
+	TimeZone   *string  `json:"timeZone" form:"timeZone"`
 }
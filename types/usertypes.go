@@ -12,12 +12,15 @@ type RegisterRequest struct {
 	Longitude float64 `json:"longitude" binding:"required"`
 }
 
-// LoginRequest contains all parameters needed for user login
+// LoginRequest contains all parameters needed for user login.
+// IP and UserAgent are filled in by the handler for the login history.
 type LoginRequest struct {
 	Email     string  `json:"email" binding:"required,email"`
 	Password  string  `json:"password" binding:"required,min=6"`
 	Latitude  float64 `json:"latitude" binding:"required"`
 	Longitude float64 `json:"longitude" binding:"required"`
+	IP        string  `json:"-"`
+	UserAgent string  `json:"-"`
 }
 
 // ActivateUserRequest contains parameters needed to activate a user
@@ -48,3 +51,9 @@ type ActivateUserResponse struct {
 	User  interface{}
 	Token string
 }
+
+// VerifyEmailRequest contains the email and OTP to verify (API v2)
+type VerifyEmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Otp   string `json:"otp" binding:"required"`
+}